@@ -1,31 +1,44 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"log"
+	"os"
 
 	"github.com/EletricSaw/rbtree/rbtree"
 )
 
 func main() {
+	importPath := flag.String("import", "", "샘플 데이터 대신 읽어 들일 CSV 파일 (key,value 두 열)")
+	exportPath := flag.String("export", "", "마지막 트리 내용을 쓸 CSV 파일")
+	flag.Parse()
+
 	tree := rbtree.New[string, string]()
 
-	// 샘플 데이터 삽입
-	samples := []struct {
-		key   string
-		value string
-	}{
-		{"k", "카카오"},
-		{"g", "구글"},
-		{"a", "애플"},
-		{"n", "네이버"},
-		{"b", "배달의민족"},
-	}
-	for _, s := range samples {
-		tree.Insert(s.key, s.value)
-	}
+	if *importPath != "" {
+		if err := importCSV(tree, *importPath); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		// 샘플 데이터 삽입
+		samples := []struct {
+			key   string
+			value string
+		}{
+			{"k", "카카오"},
+			{"g", "구글"},
+			{"a", "애플"},
+			{"n", "네이버"},
+			{"b", "배달의민족"},
+		}
+		for _, s := range samples {
+			tree.Insert(s.key, s.value)
+		}
 
-	// 하나를 삭제해본다.
-	tree.Delete("g")
+		// 하나를 삭제해본다.
+		tree.Delete("g")
+	}
 
 	// 검색 예시
 	if node := tree.Search("n"); node != nil {
@@ -34,4 +47,37 @@ func main() {
 
 	fmt.Println("\n=== RBTree 구조 ===")
 	tree.PrintStdout()
+
+	if *exportPath != "" {
+		if err := exportCSV(tree, *exportPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func importCSV(tree *rbtree.Tree[string, string], path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	identity := func(s string) (string, error) { return s, nil }
+	n, err := tree.ImportCSV(f, identity, identity)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s에서 %d개를 읽었습니다.\n", path, n)
+	return nil
+}
+
+func exportCSV(tree *rbtree.Tree[string, string], path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := tree.ExportCSV(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
 }
@@ -0,0 +1,87 @@
+package rbtree
+
+import "testing"
+
+// 조회와 순회는 힙에 아무것도 할당하지 않아야 한다. 키를 interface로 감싸거나 클로저가 힙으로
+// 빠져나가면 이 테스트가 잡아낸다.
+func TestZeroAllocReads(t *testing.T) {
+	tree := New[int, int]()
+	for i := 0; i < 1000; i++ {
+		tree.Insert(i*2, i)
+	}
+	sum := 0
+	visit := func(key, value int) { sum += value }
+	visitUntil := func(key, value int) bool { sum += value; return true }
+	cases := []struct {
+		name string
+		fn   func()
+	}{
+		{"Search", func() { tree.Search(500) }},
+		{"Get", func() { tree.Get(501) }},
+		{"Contains", func() { tree.Contains(1998) }},
+		{"Floor", func() { tree.Floor(777) }},
+		{"Ceiling", func() { tree.Ceiling(777) }},
+		{"Min", func() { tree.Min() }},
+		{"Max", func() { tree.Max() }},
+		{"Rank", func() { tree.Rank(1000) }},
+		{"Select", func() { tree.Select(321) }},
+		{"All", func() {
+			for _, v := range tree.All() {
+				sum += v
+			}
+		}},
+		{"Backward", func() {
+			for _, v := range tree.Backward() {
+				sum += v
+			}
+		}},
+		{"InOrder", func() { tree.InOrder(visit) }},
+		{"AscendRange", func() { tree.AscendRange(100, 900, visitUntil) }},
+		{"Descend", func() { tree.Descend(visitUntil) }},
+		{"DescendRange", func() { tree.DescendRange(900, 100, visitUntil) }},
+		{"Iterator", func() {
+			it := tree.Iter()
+			for it.Next() {
+				sum += it.Value()
+			}
+			it.Seek(1000)
+			for it.Prev() {
+				sum += it.Value()
+			}
+		}},
+	}
+	for _, c := range cases {
+		if allocs := testing.AllocsPerRun(100, c.fn); allocs != 0 {
+			t.Errorf("%s allocates %.1f times per call", c.name, allocs)
+		}
+	}
+}
+
+// 변경 연산은 새 노드 말고는 할당하지 않는다. WithNodePool을 쓰면 지웠다 다시 넣는 반복도 할당 없이 돈다.
+func TestMutationAllocs(t *testing.T) {
+	if debugChecks {
+		t.Skip("rbtree_debug validates after every change, which allocates")
+	}
+	for _, c := range []struct {
+		name string
+		tree *Tree[int, int]
+		want float64
+	}{
+		{"plain", New[int, int](), 1},
+		{"pooled", New[int, int](WithNodePool()), 0},
+	} {
+		for i := 0; i < 1000; i++ {
+			c.tree.Insert(i*1000, i)
+		}
+		churn := testing.AllocsPerRun(100, func() {
+			c.tree.Delete(500_000)
+			c.tree.Insert(500_000, 1)
+		})
+		if churn > c.want {
+			t.Errorf("%s: Delete+Insert allocates %.1f times, want at most %.0f", c.name, churn, c.want)
+		}
+		if update := testing.AllocsPerRun(100, func() { c.tree.Put(500_000, 2) }); update != 0 {
+			t.Errorf("%s: Put on an existing key allocates %.1f times", c.name, update)
+		}
+	}
+}
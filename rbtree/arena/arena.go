@@ -0,0 +1,402 @@
+// Package arena는 노드를 한 슬라이스에 모아 두고 부모·자식을 포인터 대신 int32 인덱스로 잇는
+// 레드블랙 트리를 제공한다.
+//
+// rbtree.Tree는 노드마다 따로 할당하고 세 개의 포인터로 잇는다. 원소가 수백만 개가 되면 노드당
+// 포인터 세 개(64비트에서 24바이트)와 할당 단위 오버헤드가 쌓이고, GC는 매번 모든 노드를 따라가야
+// 한다. 이 패키지의 트리는 노드를 슬라이스 하나에 이어 붙이고 링크를 int32 세 개(12바이트)로
+// 담으므로 링크 비용이 절반이고, 이웃 노드가 메모리에서도 가까이 있다. 키와 값에 포인터가 없으면
+// 슬라이스 전체에 포인터가 없어 GC가 아예 훑지 않는다.
+//
+// 인덱스 0은 CLRS의 NIL 경계 노드로 쓴다. 잎의 자식과 루트의 부모는 모두 0을 가리키며, 경계 노드는
+// 항상 검정이다. 지운 노드의 칸은 빈칸 목록에 모아 두었다가 다음 삽입에 다시 쓰므로 슬라이스는
+// 가장 많았던 원소 수만큼만 자란다.
+//
+// 포인터로 노드를 건네지 않으므로 API는 키와 값만 주고받는다. 순서 통계, 톰스톤, 스냅숏 같은
+// rbtree.Tree의 부가 기능은 없다.
+package arena
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"slices"
+)
+
+// Tree는 노드를 슬라이스 하나에 담는 레드블랙 트리다. 제로값은 쓸 수 없으므로 New나 NewFunc로 만든다.
+// 여러 고루틴이 함께 쓰려면 호출하는 쪽에서 잠가야 한다.
+type Tree[K any, V any] struct {
+	nodes   []node[K, V] // nodes[0]은 NIL 경계 노드다.
+	root    int32
+	free    int32 // 빈칸 목록의 머리. 빈칸끼리는 right로 잇는다. 0이면 빈칸이 없다.
+	size    int
+	compare func(a, b K) int
+}
+
+type node[K any, V any] struct {
+	key                 K
+	value               V
+	left, right, parent int32
+	red                 bool
+}
+
+// nilIndex는 NIL 경계 노드의 인덱스다.
+const nilIndex = 0
+
+// New는 K의 기본 순서를 쓰는 빈 트리를 만든다.
+func New[K cmp.Ordered, V any]() *Tree[K, V] {
+	return &Tree[K, V]{nodes: make([]node[K, V], 1), compare: cmp.Compare[K]}
+}
+
+// NewFunc는 less로 키 순서를 정하는 빈 트리를 만든다. less는 엄격한 약순서여야 하며,
+// less(a, b)와 less(b, a)가 모두 false인 두 키는 같은 키로 취급한다.
+func NewFunc[K any, V any](less func(a, b K) bool) *Tree[K, V] {
+	return &Tree[K, V]{nodes: make([]node[K, V], 1), compare: func(a, b K) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	}}
+}
+
+// Size는 원소 수를 돌려준다.
+func (t *Tree[K, V]) Size() int {
+	return t.size
+}
+
+// Grow는 원소 n개를 더 넣어도 다시 할당하지 않도록 노드 슬라이스의 용량을 늘린다. 넣을 원소 수를
+// 미리 알면 자라면서 생기는 복사를 없앨 수 있다.
+func (t *Tree[K, V]) Grow(n int) {
+	t.nodes = slices.Grow(t.nodes, n)
+}
+
+// Get은 키에 대응하는 값을 돌려준다. 없으면 V의 제로값과 false다.
+func (t *Tree[K, V]) Get(key K) (V, bool) {
+	if i := t.find(key); i != nilIndex {
+		return t.nodes[i].value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains는 키가 트리에 있는지 알려 준다.
+func (t *Tree[K, V]) Contains(key K) bool {
+	return t.find(key) != nilIndex
+}
+
+func (t *Tree[K, V]) find(key K) int32 {
+	i := t.root
+	for i != nilIndex {
+		n := &t.nodes[i]
+		switch c := t.compare(key, n.key); {
+		case c < 0:
+			i = n.left
+		case c > 0:
+			i = n.right
+		default:
+			return i
+		}
+	}
+	return nilIndex
+}
+
+// Min은 가장 작은 원소를 돌려준다. 비었으면 ok가 false다.
+func (t *Tree[K, V]) Min() (key K, value V, ok bool) {
+	if t.root == nilIndex {
+		return key, value, false
+	}
+	n := &t.nodes[t.minimum(t.root)]
+	return n.key, n.value, true
+}
+
+// Max는 가장 큰 원소를 돌려준다. 비었으면 ok가 false다.
+func (t *Tree[K, V]) Max() (key K, value V, ok bool) {
+	if t.root == nilIndex {
+		return key, value, false
+	}
+	i := t.root
+	for t.nodes[i].right != nilIndex {
+		i = t.nodes[i].right
+	}
+	n := &t.nodes[i]
+	return n.key, n.value, true
+}
+
+func (t *Tree[K, V]) minimum(i int32) int32 {
+	for t.nodes[i].left != nilIndex {
+		i = t.nodes[i].left
+	}
+	return i
+}
+
+// Insert는 key에 value를 넣는다. 키가 이미 있으면 값만 바꾼다.
+func (t *Tree[K, V]) Insert(key K, value V) {
+	parent, i := int32(nilIndex), t.root
+	c := 0
+	for i != nilIndex {
+		parent = i
+		c = t.compare(key, t.nodes[i].key)
+		switch {
+		case c < 0:
+			i = t.nodes[i].left
+		case c > 0:
+			i = t.nodes[i].right
+		default:
+			t.nodes[i].value = value
+			return
+		}
+	}
+
+	// alloc은 슬라이스를 키울 수 있으므로 그 뒤로는 노드를 인덱스로만 가리킨다.
+	z := t.alloc(key, value)
+	t.nodes[z].parent = parent
+	switch {
+	case parent == nilIndex:
+		t.root = z
+	case c < 0:
+		t.nodes[parent].left = z
+	default:
+		t.nodes[parent].right = z
+	}
+	t.size++
+	t.insertFixup(z)
+}
+
+// alloc은 빈칸이 있으면 다시 쓰고, 없으면 슬라이스 끝에 빨강 노드를 하나 붙인다.
+func (t *Tree[K, V]) alloc(key K, value V) int32 {
+	if i := t.free; i != nilIndex {
+		t.free = t.nodes[i].right
+		t.nodes[i] = node[K, V]{key: key, value: value, red: true}
+		return i
+	}
+	if len(t.nodes) > math.MaxInt32 {
+		panic("arena: tree is full")
+	}
+	t.nodes = append(t.nodes, node[K, V]{key: key, value: value, red: true})
+	return int32(len(t.nodes) - 1)
+}
+
+// release는 떼어 낸 노드의 키와 값을 지우고 빈칸 목록에 넣는다.
+func (t *Tree[K, V]) release(i int32) {
+	t.nodes[i] = node[K, V]{right: t.free}
+	t.free = i
+}
+
+// insertFixup은 CLRS의 RB-INSERT-FIXUP이다. z의 부모가 빨강인 동안 삼촌 색에 따라 색을 바꾸거나 회전한다.
+func (t *Tree[K, V]) insertFixup(z int32) {
+	n := t.nodes
+	for n[n[z].parent].red {
+		p := n[z].parent
+		g := n[p].parent
+		if p == n[g].left {
+			if u := n[g].right; n[u].red {
+				// 경우 1: 삼촌이 빨강이면 부모·삼촌을 검정, 조부모를 빨강으로 바꾸고 위로 올라간다.
+				n[p].red, n[u].red, n[g].red = false, false, true
+				z = g
+				continue
+			}
+			if z == n[p].right {
+				// 경우 2: 꺾인 모양이면 부모에서 돌려 경우 3으로 만든다.
+				z = p
+				t.rotateLeft(z)
+				p = n[z].parent
+			}
+			// 경우 3: 부모를 검정, 조부모를 빨강으로 바꾸고 조부모에서 돌린다.
+			n[p].red, n[g].red = false, true
+			t.rotateRight(g)
+		} else {
+			if u := n[g].left; n[u].red {
+				n[p].red, n[u].red, n[g].red = false, false, true
+				z = g
+				continue
+			}
+			if z == n[p].left {
+				z = p
+				t.rotateRight(z)
+				p = n[z].parent
+			}
+			n[p].red, n[g].red = false, true
+			t.rotateLeft(g)
+		}
+	}
+	n[t.root].red = false
+}
+
+// Delete는 key를 지우고, 있었으면 true를 돌려준다.
+func (t *Tree[K, V]) Delete(key K) bool {
+	z := t.find(key)
+	if z == nilIndex {
+		return false
+	}
+	n := t.nodes
+	y, removedRed := z, n[z].red
+	var x int32
+	switch {
+	case n[z].left == nilIndex:
+		x = n[z].right
+		t.transplant(z, x)
+	case n[z].right == nilIndex:
+		x = n[z].left
+		t.transplant(z, x)
+	default:
+		// 자식이 둘이면 후속 노드 y를 z 자리로 옮긴다.
+		y = t.minimum(n[z].right)
+		removedRed = n[y].red
+		x = n[y].right
+		if n[y].parent == z {
+			n[x].parent = y // x가 경계 노드여도 보정이 부모를 찾아갈 수 있게 한다.
+		} else {
+			t.transplant(y, x)
+			n[y].right = n[z].right
+			n[n[y].right].parent = y
+		}
+		t.transplant(z, y)
+		n[y].left = n[z].left
+		n[n[y].left].parent = y
+		n[y].red = n[z].red
+	}
+	if !removedRed {
+		t.deleteFixup(x)
+	}
+	t.release(z)
+	t.size--
+	return true
+}
+
+// transplant는 u 자리에 v를 붙인다. v가 경계 노드여도 parent를 적어 둔다.
+func (t *Tree[K, V]) transplant(u, v int32) {
+	n := t.nodes
+	p := n[u].parent
+	switch {
+	case p == nilIndex:
+		t.root = v
+	case u == n[p].left:
+		n[p].left = v
+	default:
+		n[p].right = v
+	}
+	n[v].parent = p
+}
+
+// deleteFixup은 CLRS의 RB-DELETE-FIXUP이다. x에 남은 검정 하나를 형제 쪽 색과 회전으로 해소한다.
+func (t *Tree[K, V]) deleteFixup(x int32) {
+	n := t.nodes
+	for x != t.root && !n[x].red {
+		p := n[x].parent
+		if x == n[p].left {
+			w := n[p].right
+			if n[w].red {
+				// 경우 1: 형제가 빨강이면 돌려서 검정 형제를 만든다.
+				n[w].red, n[p].red = false, true
+				t.rotateLeft(p)
+				w = n[p].right
+			}
+			if !n[n[w].left].red && !n[n[w].right].red {
+				// 경우 2: 형제의 자식이 모두 검정이면 형제를 빨강으로 바꾸고 위로 올라간다.
+				n[w].red = true
+				x = p
+				continue
+			}
+			if !n[n[w].right].red {
+				// 경우 3: 가까운 조카만 빨강이면 형제에서 돌려 경우 4로 만든다.
+				n[n[w].left].red, n[w].red = false, true
+				t.rotateRight(w)
+				w = n[p].right
+			}
+			// 경우 4: 먼 조카가 빨강이면 부모에서 돌리고 끝낸다.
+			n[w].red, n[p].red, n[n[w].right].red = n[p].red, false, false
+			t.rotateLeft(p)
+			x = t.root
+		} else {
+			w := n[p].left
+			if n[w].red {
+				n[w].red, n[p].red = false, true
+				t.rotateRight(p)
+				w = n[p].left
+			}
+			if !n[n[w].right].red && !n[n[w].left].red {
+				n[w].red = true
+				x = p
+				continue
+			}
+			if !n[n[w].left].red {
+				n[n[w].right].red, n[w].red = false, true
+				t.rotateLeft(w)
+				w = n[p].left
+			}
+			n[w].red, n[p].red, n[n[w].left].red = n[p].red, false, false
+			t.rotateRight(p)
+			x = t.root
+		}
+	}
+	n[x].red = false
+}
+
+func (t *Tree[K, V]) rotateLeft(x int32) {
+	n := t.nodes
+	y := n[x].right
+	n[x].right = n[y].left
+	if n[y].left != nilIndex {
+		n[n[y].left].parent = x
+	}
+	t.replaceChild(n[x].parent, x, y)
+	n[y].left = x
+	n[x].parent = y
+}
+
+func (t *Tree[K, V]) rotateRight(x int32) {
+	n := t.nodes
+	y := n[x].left
+	n[x].left = n[y].right
+	if n[y].right != nilIndex {
+		n[n[y].right].parent = x
+	}
+	t.replaceChild(n[x].parent, x, y)
+	n[y].right = x
+	n[x].parent = y
+}
+
+// replaceChild는 parent의 자식 old를 new로 바꾸고 new의 부모를 parent로 맞춘다.
+func (t *Tree[K, V]) replaceChild(parent, old, new int32) {
+	n := t.nodes
+	switch {
+	case parent == nilIndex:
+		t.root = new
+	case n[parent].left == old:
+		n[parent].left = new
+	default:
+		n[parent].right = new
+	}
+	n[new].parent = parent
+}
+
+// All은 모든 원소를 키 오름차순으로 내놓는다. 부모 인덱스를 따라 움직이므로 추가 메모리가 들지 않는다.
+// 순회 도중 트리를 고치면 안 된다.
+func (t *Tree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if t.root == nilIndex {
+			return
+		}
+		for i := t.minimum(t.root); i != nilIndex; i = t.successor(i) {
+			if !yield(t.nodes[i].key, t.nodes[i].value) {
+				return
+			}
+		}
+	}
+}
+
+// successor는 i 다음 키를 가진 노드의 인덱스다. 없으면 nilIndex다.
+func (t *Tree[K, V]) successor(i int32) int32 {
+	n := t.nodes
+	if n[i].right != nilIndex {
+		return t.minimum(n[i].right)
+	}
+	p := n[i].parent
+	for p != nilIndex && i == n[p].right {
+		i, p = p, n[p].parent
+	}
+	return p
+}
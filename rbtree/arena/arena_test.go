@@ -0,0 +1,166 @@
+package arena
+
+import (
+	"math/rand"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/EletricSaw/rbtree/rbtree"
+)
+
+// checkRB는 레드블랙 규칙, 부모 인덱스, 키 순서, 원소 수와 경계 노드가 검정인지 확인한다.
+func checkRB[K any, V any](t *testing.T, tree *Tree[K, V]) {
+	t.Helper()
+	n := tree.nodes
+	if n[nilIndex].red {
+		t.Fatalf("sentinel must stay black")
+	}
+	if n[tree.root].red {
+		t.Fatalf("root must be black")
+	}
+	var walk func(i, parent int32, lo, hi *K) (count, black int)
+	walk = func(i, parent int32, lo, hi *K) (int, int) {
+		if i == nilIndex {
+			return 0, 1
+		}
+		if n[i].parent != parent {
+			t.Fatalf("node %v has parent %d, want %d", n[i].key, n[i].parent, parent)
+		}
+		if n[i].red && (n[n[i].left].red || n[n[i].right].red) {
+			t.Fatalf("two reds in a row at %v", n[i].key)
+		}
+		if (lo != nil && tree.compare(n[i].key, *lo) <= 0) || (hi != nil && tree.compare(n[i].key, *hi) >= 0) {
+			t.Fatalf("key %v out of order", n[i].key)
+		}
+		lc, lb := walk(n[i].left, i, lo, &n[i].key)
+		rc, rb := walk(n[i].right, i, &n[i].key, hi)
+		if lb != rb {
+			t.Fatalf("black height mismatch at %v: %d vs %d", n[i].key, lb, rb)
+		}
+		if !n[i].red {
+			lb++
+		}
+		return lc + rc + 1, lb
+	}
+	if count, _ := walk(tree.root, nilIndex, nil, nil); count != tree.Size() {
+		t.Fatalf("size %d disagrees with node count %d", tree.Size(), count)
+	}
+}
+
+func TestAgainstMap(t *testing.T) {
+	tree := New[int, int]()
+	model := map[int]int{}
+	for i := 0; i < 5000; i++ {
+		k := rand.Intn(500)
+		if rand.Intn(3) == 0 {
+			_, want := model[k]
+			if got := tree.Delete(k); got != want {
+				t.Fatalf("Delete(%d) = %v, want %v", k, got, want)
+			}
+			delete(model, k)
+		} else {
+			tree.Insert(k, i)
+			model[k] = i
+		}
+		checkRB(t, tree)
+	}
+
+	var keys []int
+	for k, v := range tree.All() {
+		if model[k] != v {
+			t.Fatalf("key %d has value %d, want %d", k, v, model[k])
+		}
+		keys = append(keys, k)
+	}
+	if len(keys) != len(model) || !slices.IsSorted(keys) {
+		t.Fatalf("All yielded %d keys (sorted %v), want %d", len(keys), slices.IsSorted(keys), len(model))
+	}
+	for k, want := range model {
+		if got, ok := tree.Get(k); !ok || got != want {
+			t.Fatalf("Get(%d) = %d, %v; want %d", k, got, ok, want)
+		}
+	}
+	if min, _, _ := tree.Min(); min != keys[0] {
+		t.Fatalf("Min = %d, want %d", min, keys[0])
+	}
+	if max, _, _ := tree.Max(); max != keys[len(keys)-1] {
+		t.Fatalf("Max = %d, want %d", max, keys[len(keys)-1])
+	}
+}
+
+func TestReusesFreedSlots(t *testing.T) {
+	tree := New[int, *int]()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, new(int))
+	}
+	slots := len(tree.nodes)
+	for round := 0; round < 10; round++ {
+		for i := 0; i < 100; i += 2 {
+			tree.Delete(i)
+		}
+		for i := 0; i < 100; i += 2 {
+			tree.Insert(i, new(int))
+		}
+	}
+	if len(tree.nodes) != slots {
+		t.Fatalf("churn grew the arena from %d to %d slots", slots, len(tree.nodes))
+	}
+	for i := 0; i < 100; i++ {
+		tree.Delete(i)
+	}
+	checkRB(t, tree)
+	for i, n := range tree.nodes {
+		if n.value != nil {
+			t.Fatalf("freed slot %d still holds its value", i)
+		}
+	}
+	if _, _, ok := tree.Min(); ok || tree.Size() != 0 {
+		t.Fatalf("tree should be empty")
+	}
+}
+
+func TestNewFunc(t *testing.T) {
+	tree := NewFunc[string, int](func(a, b string) bool { return len(a) < len(b) })
+	tree.Insert("aaa", 3)
+	tree.Insert("b", 1)
+	tree.Insert("cc", 2)
+	tree.Insert("d", 4) // 길이가 같으므로 "b"를 덮어쓴다.
+	var got []int
+	for _, v := range tree.All() {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{4, 2, 3}) {
+		t.Fatalf("All = %v, want [4 2 3]", got)
+	}
+}
+
+// 원소 1백만 개를 담은 트리를 살려 둔 채 GC 한 번에 걸리는 시간(ns/op)을 잰다. 키와 값에 포인터가 없으면
+// arena 트리의 슬라이스는 GC가 훑지 않는다.
+func BenchmarkGC(b *testing.B) {
+	const n = 1_000_000
+	keys := rand.Perm(n)
+	measure := func(b *testing.B, keep any) {
+		runtime.GC()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			runtime.GC()
+		}
+		runtime.KeepAlive(keep)
+	}
+	b.Run("rbtree", func(b *testing.B) {
+		tree := rbtree.New[int, int]()
+		for _, k := range keys {
+			tree.Insert(k, k)
+		}
+		measure(b, tree)
+	})
+	b.Run("arena", func(b *testing.B) {
+		tree := New[int, int]()
+		tree.Grow(n)
+		for _, k := range keys {
+			tree.Insert(k, k)
+		}
+		measure(b, tree)
+	})
+}
@@ -0,0 +1,184 @@
+package rbtree
+
+// Augment는 서브트리마다 유지할 집계 A를 정의한다. 트리는 삽입, 삭제, 회전, 값 변경으로 서브트리가
+// 바뀔 때마다 바뀐 노드에서 루트까지 집계를 다시 계산하므로, 보정 코드를 고치지 않고도 합계 트리,
+// 구간 최댓값(interval max) 트리, 순서 통계 같은 보강 트리를 만들 수 있다.
+//
+// 노드의 집계는 Combine(Combine(왼쪽 서브트리, FromNode(노드)), 오른쪽 서브트리)이다. 빈 서브트리와
+// 톰스톤 노드는 빠지므로 항등원이 필요 없다. 왼쪽·자신·오른쪽의 키 순서를 지켜 합치므로 Combine은
+// 결합법칙만 만족하면 되고 교환법칙은 필요 없다.
+type Augment[K any, V any, A any] interface {
+	// FromNode는 원소 하나의 집계다.
+	FromNode(key K, value V) A
+	// Combine은 키 순서로 이웃한 두 구간의 집계를 합친다. a가 작은 키 쪽이다.
+	Combine(a, b A) A
+}
+
+// Augmentation은 AugmentWith로 트리에 붙인 집계를 읽는 손잡이다.
+type Augmentation[K any, V any, A any] struct {
+	t   *Tree[K, V]
+	aug Augment[K, V, A]
+}
+
+// augmenter는 Tree가 집계 타입 A를 모른 채 노드의 집계를 다시 계산하게 하는 내부 인터페이스다.
+type augmenter[K any, V any] interface {
+	refresh(n *Node[K, V])
+}
+
+// augmentSlot은 노드 하나의 집계다. ok가 false면 서브트리에 살아 있는 원소가 없다.
+type augmentSlot[A any] struct {
+	value A
+	ok    bool
+}
+
+// AugmentWith는 t에 aug가 정의한 집계를 붙이고, 집계를 읽을 손잡이를 돌려준다. 붙이는 순간 모든
+// 노드의 집계를 O(n)에 계산하고, 그 뒤로는 바뀐 경로만 O(log n)에 다시 계산한다. 한 트리에는
+// 집계를 하나만 붙일 수 있으며, 다시 부르면 앞의 것을 바꾼다.
+//
+// InsertMany, Split, Join, DeleteRange처럼 트리를 통째로 다시 엮는 연산과 Snapshot 뒤 첫 쓰기는
+// 집계를 모두 다시 계산하느라 O(n)이 더 든다. Search로 얻은 노드의 Value를 직접 고치면 집계가
+// 따라가지 않으므로 Put이나 Update로 고친다. Clone, Snapshot, Split 결과에는 집계가 따라가지 않는다.
+func AugmentWith[K any, V any, A any](t *Tree[K, V], aug Augment[K, V, A]) *Augmentation[K, V, A] {
+	t.willWrite()
+	a := &Augmentation[K, V, A]{t: t, aug: aug}
+	t.aug = a
+	t.augmentAll()
+	return a
+}
+
+// Total은 트리 전체의 집계를 돌려준다. 트리가 비었으면 ok가 false다.
+func (a *Augmentation[K, V, A]) Total() (A, bool) {
+	return a.Subtree(a.t.root)
+}
+
+// Subtree는 node를 뿌리로 하는 서브트리의 집계를 돌려준다. 집계를 따라 내려가며 답을 찾는 질의
+// (예: 구간 최댓값 트리에서 겹치는 구간 찾기)를 직접 짤 때 쓴다. node가 nil이거나 서브트리에 살아
+// 있는 원소가 없으면 ok가 false다.
+func (a *Augmentation[K, V, A]) Subtree(node *Node[K, V]) (A, bool) {
+	if s := slotOf[A](node); s != nil && s.ok {
+		return s.value, true
+	}
+	var zero A
+	return zero, false
+}
+
+// AggregateRange는 [lo, hi) 구간에 있는 원소들의 집계를 키 순서대로 합쳐 돌려준다. 구간 양 끝을
+// 찾아 내려가는 두 경로에서 경로 밖으로 통째로 들어가는 서브트리는 저장된 집계를 그대로 쓰므로,
+// 구간이 넓어도 FromNode와 Combine을 O(log n)번만 부른다. 시각을 키로 둔 잔액에서 기간 합계를
+// 구하는 것처럼 창 단위 집계에 쓴다. 구간에 원소가 없거나 lo >= hi이면 ok가 false다.
+func (a *Augmentation[K, V, A]) AggregateRange(lo, hi K) (A, bool) {
+	var acc A
+	ok := false
+	t := a.t
+	if t.compare(lo, hi) >= 0 {
+		return acc, false
+	}
+	// 두 경로가 갈라지는 노드, 즉 구간 안에 든 가장 높은 노드를 찾는다.
+	n := t.root
+	for n != nil {
+		if t.compare(n.Key, lo) < 0 {
+			n = n.Right
+		} else if t.compare(n.Key, hi) >= 0 {
+			n = n.Left
+		} else {
+			break
+		}
+	}
+	if n == nil {
+		return acc, false
+	}
+
+	// 왼쪽 경로: n.Left에서 lo 이상인 부분. 내려갈수록 작은 키이므로 앞에 붙인다.
+	for x := n.Left; x != nil; {
+		if t.compare(x.Key, lo) < 0 {
+			x = x.Right
+			continue
+		}
+		v, vok := a.self(x)
+		r, rok := a.Subtree(x.Right)
+		v, vok = a.join(v, vok, r, rok)
+		acc, ok = a.join(v, vok, acc, ok)
+		x = x.Left
+	}
+	v, vok := a.self(n)
+	acc, ok = a.join(acc, ok, v, vok)
+	// 오른쪽 경로: n.Right에서 hi 미만인 부분. 내려갈수록 큰 키이므로 뒤에 붙인다.
+	for x := n.Right; x != nil; {
+		if t.compare(x.Key, hi) >= 0 {
+			x = x.Left
+			continue
+		}
+		l, lok := a.Subtree(x.Left)
+		v, vok := a.self(x)
+		l, lok = a.join(l, lok, v, vok)
+		acc, ok = a.join(acc, ok, l, lok)
+		x = x.Right
+	}
+	return acc, ok
+}
+
+func (a *Augmentation[K, V, A]) refresh(n *Node[K, V]) {
+	s, _ := n.agg.(*augmentSlot[A])
+	if s == nil {
+		s = new(augmentSlot[A])
+		n.agg = s
+	}
+	acc, ok := a.Subtree(n.Left)
+	v, vok := a.self(n)
+	acc, ok = a.join(acc, ok, v, vok)
+	r, rok := a.Subtree(n.Right)
+	s.value, s.ok = a.join(acc, ok, r, rok)
+}
+
+// self는 node 원소 하나의 집계다. 톰스톤이면 ok가 false다.
+func (a *Augmentation[K, V, A]) self(n *Node[K, V]) (A, bool) {
+	if n.deleted {
+		var zero A
+		return zero, false
+	}
+	return a.aug.FromNode(n.Key, n.Value), true
+}
+
+// join은 키 순서로 앞선 집계 x와 뒤따르는 집계 y를 합친다. 빈 쪽은 건너뛴다.
+func (a *Augmentation[K, V, A]) join(x A, xok bool, y A, yok bool) (A, bool) {
+	switch {
+	case !xok:
+		return y, yok
+	case !yok:
+		return x, xok
+	}
+	return a.aug.Combine(x, y), true
+}
+
+// slotOf는 node의 집계 칸을 돌려준다. node가 nil이거나 아직 계산하지 않았으면 nil이다.
+func slotOf[A any, K any, V any](node *Node[K, V]) *augmentSlot[A] {
+	if node == nil {
+		return nil
+	}
+	s, _ := node.agg.(*augmentSlot[A])
+	return s
+}
+
+// augmentNode는 집계가 붙어 있으면 node 하나의 집계를 두 자식으로부터 다시 계산한다.
+func (t *Tree[K, V]) augmentNode(node *Node[K, V]) {
+	if t.aug != nil {
+		t.aug.refresh(node)
+	}
+}
+
+// augmentPath는 집계가 붙어 있으면 node부터 루트까지 집계를 다시 계산한다.
+func (t *Tree[K, V]) augmentPath(node *Node[K, V]) {
+	if t.aug == nil {
+		return
+	}
+	for ; node != nil; node = node.Parent {
+		t.aug.refresh(node)
+	}
+}
+
+// augmentAll은 집계가 붙어 있으면 모든 노드의 집계를 자식부터 다시 계산한다.
+func (t *Tree[K, V]) augmentAll() {
+	if t.aug != nil {
+		postOrder(t.root, t.aug.refresh)
+	}
+}
@@ -0,0 +1,169 @@
+package rbtree
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// sumAug는 값의 합계다.
+type sumAug struct{}
+
+func (sumAug) FromNode(_ int, v int) int { return v }
+func (sumAug) Combine(a, b int) int      { return a + b }
+
+// keysAug는 키를 순서대로 이어 붙인다. 교환법칙이 없으므로 합치는 순서가 틀리면 드러난다.
+type keysAug struct{}
+
+func (keysAug) FromNode(k int, _ int) string { return fmt.Sprint(k, ",") }
+func (keysAug) Combine(a, b string) string   { return a + b }
+
+// checkAugment는 모든 노드의 집계를 직접 다시 계산한 값과 비교한다.
+func checkAugment(t *testing.T, tree *Tree[int, int], a *Augmentation[int, int, string]) {
+	t.Helper()
+	var walk func(n *Node[int, int]) string
+	walk = func(n *Node[int, int]) string {
+		if n == nil {
+			return ""
+		}
+		want := walk(n.Left)
+		if !n.deleted {
+			want += fmt.Sprint(n.Key, ",")
+		}
+		want += walk(n.Right)
+		got, ok := a.Subtree(n)
+		if got != want || ok != (want != "") {
+			t.Fatalf("subtree at %d: aggregate %q (ok %v), want %q", n.Key, got, ok, want)
+		}
+		return want
+	}
+	walk(tree.Root())
+}
+
+func TestAugmentSum(t *testing.T) {
+	tree := New[int, int]()
+	for i := 1; i <= 100; i++ {
+		tree.Insert(i, i)
+	}
+	sum := AugmentWith[int, int, int](tree, sumAug{})
+	if got, _ := sum.Total(); got != 5050 {
+		t.Fatalf("Total = %d, want 5050", got)
+	}
+	tree.Put(50, 0)
+	tree.Delete(100)
+	tree.Update(1, func(old int, _ bool) (int, bool) { return old + 10, true })
+	CompareAndSwap(tree, 2, 2, 20)
+	if got, _ := sum.Total(); got != 5050-50-100+10+18 {
+		t.Fatalf("Total after updates = %d, want %d", got, 5050-50-100+10+18)
+	}
+	tree.Clear()
+	if _, ok := sum.Total(); ok {
+		t.Fatalf("Total of an empty tree should not be ok")
+	}
+}
+
+func TestAugmentMaintained(t *testing.T) {
+	for name, newTree := range map[string]func() *Tree[int, int]{
+		"classic":    func() *Tree[int, int] { return New[int, int]() },
+		"tombstones": func() *Tree[int, int] { return NewWithTombstones[int, int]() },
+		"llrb":       func() *Tree[int, int] { return New[int, int](WithBalancing(LeftLeaningRB)) },
+		"pool":       func() *Tree[int, int] { return New[int, int](WithNodePool()) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(3))
+			tree := newTree()
+			a := AugmentWith[int, int, string](tree, keysAug{})
+			for i := 0; i < 3000; i++ {
+				k := rng.Intn(300)
+				switch op := rng.Intn(10); {
+				case op < 5:
+					tree.Insert(k, k)
+				case op < 8:
+					tree.Delete(k)
+				case op == 8:
+					tree.PopMin()
+				default:
+					c := tree.MutableCursor()
+					if c.Seek(k) {
+						c.DeleteCurrent()
+					}
+				}
+				if i%100 == 0 {
+					checkAugment(t, tree, a)
+				}
+				if i%700 == 0 {
+					_ = tree.Snapshot() // 다음 쓰기에서 노드를 복사하며 집계를 다시 만든다.
+				}
+			}
+			checkAugment(t, tree, a)
+
+			tree.InsertMany([]Pair[int, int]{{1000, 1}, {5, 5}, {1001, 1}})
+			checkAugment(t, tree, a)
+			tree.DeleteRange(100, 200)
+			checkAugment(t, tree, a)
+			if tree.tombstones {
+				tree.Compact(nil)
+				checkAugment(t, tree, a)
+			}
+		})
+	}
+}
+
+func TestAugmentSnapshotIsolated(t *testing.T) {
+	tree := New[int, int]()
+	for i := 1; i <= 10; i++ {
+		tree.Insert(i, i)
+	}
+	sum := AugmentWith[int, int, int](tree, sumAug{})
+	snap := tree.Snapshot()
+	tree.Insert(100, 100)
+	if got, _ := sum.Subtree(snap.Root()); got != 55 {
+		t.Fatalf("snapshot aggregate changed to %d after writing to the original", got)
+	}
+	if got, _ := sum.Total(); got != 155 {
+		t.Fatalf("Total = %d, want 155", got)
+	}
+}
+
+func TestAggregateRange(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	tree := NewWithTombstones[int, int]()
+	keys := AugmentWith[int, int, string](tree, keysAug{})
+	for i := 0; i < 2000; i++ {
+		k := rng.Intn(400)
+		if rng.Intn(3) == 0 {
+			tree.Delete(k)
+		} else {
+			tree.Insert(k, k)
+		}
+	}
+	for i := 0; i < 500; i++ {
+		lo, hi := rng.Intn(420)-10, rng.Intn(420)-10
+		want := ""
+		tree.AscendRange(lo, hi, func(k, _ int) bool {
+			want += fmt.Sprint(k, ",")
+			return true
+		})
+		got, ok := keys.AggregateRange(lo, hi)
+		if got != want || ok != (want != "") {
+			t.Fatalf("AggregateRange(%d, %d) = %q (ok %v), want %q", lo, hi, got, ok, want)
+		}
+	}
+}
+
+func TestAggregateRangeWindowedSum(t *testing.T) {
+	balances := New[int, int]()
+	sum := AugmentWith[int, int, int](balances, sumAug{})
+	for ts := 0; ts < 1000; ts++ {
+		balances.Insert(ts, 1)
+	}
+	if got, _ := sum.AggregateRange(100, 200); got != 100 {
+		t.Fatalf("sum over [100, 200) = %d, want 100", got)
+	}
+	if _, ok := sum.AggregateRange(2000, 3000); ok {
+		t.Fatalf("sum over an empty window should not be ok")
+	}
+	if _, ok := sum.AggregateRange(200, 100); ok {
+		t.Fatalf("sum over an inverted window should not be ok")
+	}
+}
@@ -0,0 +1,76 @@
+package rbtree
+
+import "fmt"
+
+// Balancing은 삽입·삭제 뒤 레드블랙 규칙을 되찾는 방식이다. WithBalancing으로 고른다.
+type Balancing int
+
+const (
+	// ClassicRB는 CLRS 교과서의 레드블랙 트리다(기본값). 삽입은 삼촌 색, 삭제는 형제 색에 따라
+	// 경우를 나눠 아래에서 위로 고친다.
+	ClassicRB Balancing = iota
+	// LeftLeaningRB는 Sedgewick의 좌편향 레드블랙 트리(LLRB)다. 빨강 노드는 항상 왼쪽 자식이고,
+	// 삽입은 돌아 올라오며 세 가지 규칙(오른쪽 빨강이면 왼쪽 회전, 왼쪽으로 빨강이 둘이면 오른쪽
+	// 회전, 양쪽이 빨강이면 색 뒤집기)만 적용하며, 삭제는 루트에서 내려가며 빨강을 끌어내린다.
+	LeftLeaningRB
+)
+
+// String은 "classic" 또는 "left-leaning"을 돌려준다.
+func (b Balancing) String() string {
+	switch b {
+	case ClassicRB:
+		return "classic"
+	case LeftLeaningRB:
+		return "left-leaning"
+	}
+	return fmt.Sprintf("Balancing(%d)", int(b))
+}
+
+// WithBalancing은 트리가 쓸 균형 방식을 고른다. 두 방식 모두 레드블랙 트리이므로 순회, 직렬화,
+// 순서 통계 같은 나머지 기능은 똑같이 동작하고, Record·Metrics로 같은 입력에서 회전과 색 변경이
+// 어떻게 다른지 비교할 수 있다. Explain은 CLRS의 경우 구분을 알려 주므로 ClassicRB에서만 불린다.
+//
+// LeftLeaningRB에서는 InsertMany, Split, Join, DeleteRange처럼 트리를 통째로 다시 엮는 연산이
+// 결과를 좌편향 모양으로 다시 세우느라 O(n log n)이 더 든다.
+func WithBalancing(b Balancing) Option {
+	return func(o *options) { o.balancing = b }
+}
+
+// balancer는 균형 방식마다 달라지는 부분이다. 노드를 찾고 붙이는 일과 순회, 직렬화는 Tree가 함께 쓴다.
+type balancer[K any, V any] interface {
+	// insertFixup은 link가 빨강으로 붙인 node에서 시작해 규칙을 되찾는다.
+	insertFixup(t *Tree[K, V], node *Node[K, V])
+	// unlink는 node를 구조적으로 떼어 내고 규칙을 되찾는다. 다른 노드는 옮기기만 하고 버리지 않는다.
+	unlink(t *Tree[K, V], node *Node[K, V])
+	// normalize는 setRoot처럼 트리를 통째로 다시 엮은 뒤 이 방식의 모양을 되찾는다.
+	normalize(t *Tree[K, V])
+	// check는 레드블랙 규칙 밖에 이 방식이 더 지키는 규칙을 확인한다.
+	check(root *Node[K, V]) error
+}
+
+// balancer는 t가 쓰는 균형 방식이다. 옵션 없이 만든 트리는 ClassicRB다.
+func (t *Tree[K, V]) balancer() balancer[K, V] {
+	if t.balance == nil {
+		return classicBalancer[K, V]{}
+	}
+	return t.balance
+}
+
+// newBalancer는 b에 맞는 균형 방식을 만든다. ClassicRB면 nil이다.
+func newBalancer[K any, V any](b Balancing) balancer[K, V] {
+	switch b {
+	case ClassicRB:
+		return nil
+	case LeftLeaningRB:
+		return llrbBalancer[K, V]{}
+	}
+	panic(fmt.Sprintf("rbtree: unknown balancing %v", b))
+}
+
+// classicBalancer는 rbtree.go의 CLRS 구현을 그대로 부른다.
+type classicBalancer[K any, V any] struct{}
+
+func (classicBalancer[K, V]) insertFixup(t *Tree[K, V], node *Node[K, V]) { t.insertFixup(node) }
+func (classicBalancer[K, V]) unlink(t *Tree[K, V], node *Node[K, V])      { t.deleteNode(node) }
+func (classicBalancer[K, V]) normalize(*Tree[K, V])                       {}
+func (classicBalancer[K, V]) check(*Node[K, V]) error                     { return nil }
@@ -0,0 +1,102 @@
+package rbtree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestLeftLeaningRB(t *testing.T) {
+	tree := New[int, int](WithBalancing(LeftLeaningRB))
+	model := map[int]int{}
+	for i := 0; i < 3000; i++ {
+		k := rand.Intn(400)
+		if rand.Intn(3) == 0 {
+			_, want := model[k]
+			if got := tree.Delete(k); got != want {
+				t.Fatalf("Delete(%d) = %v, want %v", k, got, want)
+			}
+			delete(model, k)
+		} else {
+			tree.Insert(k, i)
+			model[k] = i
+		}
+		assertRBProperties(t, tree)
+	}
+	for k, want := range model {
+		if got, ok := tree.Get(k); !ok || got != want {
+			t.Fatalf("Get(%d) = %d, %v; want %d", k, got, ok, want)
+		}
+	}
+	// 순서 통계도 같은 보강 필드를 쓴다.
+	keys := tree.Keys()
+	for i, k := range keys {
+		if tree.Rank(k) != i {
+			t.Fatalf("Rank(%d) = %d, want %d", k, tree.Rank(k), i)
+		}
+	}
+}
+
+func TestLeftLeaningRBBulkOperations(t *testing.T) {
+	tree := NewWithTombstones[int, int](WithBalancing(LeftLeaningRB))
+	for i := 0; i < 200; i++ {
+		tree.Insert(i, i)
+	}
+	for i := 0; i < 200; i += 3 {
+		tree.Delete(i)
+	}
+	tree.Compact(nil)
+	assertRBProperties(t, tree)
+
+	// 통째로 다시 엮은 결과도 좌편향 모양으로 돌아와야 다음 삭제가 올바르다.
+	tree.InsertMany([]Pair[int, int]{{Key: 500, Value: 1}, {Key: -1, Value: 2}, {Key: 77, Value: 3}})
+	assertRBProperties(t, tree)
+	tree.DeleteRange(50, 120)
+	assertRBProperties(t, tree)
+	left, right := tree.Split(150)
+	assertRBProperties(t, left)
+	assertRBProperties(t, right)
+	for _, k := range []int{-1, 10, 149} {
+		left.Delete(k)
+		assertRBProperties(t, left)
+	}
+	tree = Join(left, right)
+	assertRBProperties(t, tree)
+	for _, k := range tree.Keys() {
+		tree.Delete(k)
+		assertRBProperties(t, tree)
+	}
+}
+
+func TestBalancingsAgree(t *testing.T) {
+	classic := New[int, int]()
+	llrb := New[int, int](WithBalancing(LeftLeaningRB))
+	keys := rand.Perm(1000)
+	for _, k := range keys {
+		classic.Insert(k, k)
+		llrb.Insert(k, k)
+	}
+	for _, k := range keys[:500] {
+		classic.Delete(k)
+		llrb.Delete(k)
+	}
+	if !slices.Equal(classic.Keys(), llrb.Keys()) {
+		t.Fatalf("the two balancings hold different keys")
+	}
+	// 같은 입력이라도 재균형 비용은 다르다. 좌편향 트리는 빨강을 한쪽으로 몰아야 하므로 회전이 더 많다.
+	if c, l := classic.Metrics(), llrb.Metrics(); l.Rotations <= c.Rotations {
+		t.Fatalf("expected more rotations from LLRB: classic %+v, llrb %+v", c, l)
+	}
+}
+
+func TestLeftLeaningRBValidate(t *testing.T) {
+	tree := New[int, int](WithBalancing(LeftLeaningRB))
+	for i := 1; i <= 3; i++ {
+		tree.Insert(i, i)
+	}
+	// 1 <- 2 -> 3 모양에서 자식 둘을 빨강으로 칠해도 레드블랙 규칙은 지키지만 좌편향 규칙은 깨진다.
+	tree.root.Left.Color, tree.root.Right.Color = red, red
+	if err := tree.Validate(); err == nil {
+		t.Fatalf("Validate should reject a red right child in a left-leaning tree")
+	}
+}
@@ -0,0 +1,84 @@
+package rbtree
+
+import "slices"
+
+// batchRebuildRatio는 InsertMany가 트리를 다시 엮는 기준이다. 묶음 크기에 이 값을 곱한 것이
+// 트리 크기 이상이면 전체를 병합해 다시 엮고, 그보다 작으면 하나씩 넣는다.
+// 다시 엮기는 기존 노드를 모두 훑으므로 묶음이 트리만큼 클 때만 이득이다(BenchmarkInsertMany).
+const batchRebuildRatio = 1
+
+// InsertMany는 여러 원소를 한 번에 넣는다. 묶음을 키 순서로 정렬한 뒤, 트리에 비해 묶음이 크면
+// 기존 노드와 새 원소를 중위 순서로 병합해 회전/보정 없이 O(n + m)에 균형 트리로 다시 엮는다.
+// 묶음이 작으면 정렬된 순서로 Insert한다(이웃한 탐색 경로를 연달아 지나 캐시 효율이 좋다).
+// 묶음 안에서 같은 키가 여러 번 나오면 마지막 값이, 트리에 이미 있는 키는 묶음의 값이 이긴다.
+// 어느 경로든 기존 키의 노드는 그대로 재사용되므로 앞서 Search로 얻은 노드 포인터도 유효하다.
+// pairs는 제자리에서 정렬되며, 이미 정렬되어 있으면 정렬을 건너뛴다.
+func (t *Tree[K, V]) InsertMany(pairs []Pair[K, V]) {
+	if len(pairs) == 0 {
+		return
+	}
+	t.willWrite()
+	byKey := func(a, b Pair[K, V]) int {
+		return t.compare(a.Key, b.Key)
+	}
+	if !slices.IsSortedFunc(pairs, byKey) {
+		// 같은 키 사이의 입력 순서를 지켜야 마지막 값이 이긴다.
+		slices.SortStableFunc(pairs, byKey)
+	}
+
+	if len(pairs)*batchRebuildRatio < t.size {
+		for _, p := range pairs {
+			t.Insert(p.Key, p.Value)
+		}
+		return
+	}
+
+	before := t.size
+	nodes := make([]*Node[K, V], 0, t.size+len(pairs))
+	// 콜백과 저널이 바뀐 트리를 보도록, 알림은 모아 두었다가 다시 엮은 뒤에 보낸다.
+	changes := make([]batchChange[K, V], 0, len(pairs))
+	cur := t.first()
+	for i := 0; i < len(pairs); i++ {
+		p := pairs[i]
+		if i+1 < len(pairs) && t.compare(p.Key, pairs[i+1].Key) == 0 {
+			continue // 같은 키가 이어지면 마지막 것만 쓴다.
+		}
+		for cur != nil && t.compare(cur.Key, p.Key) < 0 {
+			nodes = append(nodes, cur)
+			cur = nextLive(cur)
+		}
+		if cur != nil && t.compare(cur.Key, p.Key) == 0 {
+			old := cur.Value
+			cur.Value = p.Value
+			changes = append(changes, batchChange[K, V]{key: p.Key, old: old, new: p.Value, updated: true})
+			nodes = append(nodes, cur)
+			cur = nextLive(cur)
+			continue
+		}
+		nodes = append(nodes, t.newNode(p.Key, p.Value))
+		changes = append(changes, batchChange[K, V]{key: p.Key, new: p.Value})
+	}
+	for ; cur != nil; cur = nextLive(cur) {
+		nodes = append(nodes, cur)
+	}
+
+	// 톰스톤 노드는 병합에서 빠지므로 다시 엮는 김에 함께 정리된다.
+	t.dead = 0
+	t.setRoot(linkBalanced(nodes))
+	t.vars.insertedMany(t.size-before, t.size)
+	for _, c := range changes {
+		if c.updated {
+			t.afterUpdate(c.key, c.old, c.new)
+		} else {
+			t.afterInsert(c.key, c.new)
+		}
+	}
+	t.evictOverflow()
+}
+
+// batchChange는 InsertMany가 다시 엮은 뒤에 알릴 변경 하나다. updated가 false면 새 키다.
+type batchChange[K any, V any] struct {
+	key      K
+	old, new V
+	updated  bool
+}
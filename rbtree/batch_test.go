@@ -0,0 +1,111 @@
+package rbtree
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestInsertMany(t *testing.T) {
+	for _, tree := range []*Tree[int, int]{New[int, int](), NewWithTombstones[int, int]()} {
+		model := map[int]int{}
+		for round := 0; round < 50; round++ {
+			batch := make([]Pair[int, int], rand.Intn(100))
+			for i := range batch {
+				batch[i] = Pair[int, int]{Key: rand.Intn(1000), Value: rand.Int()}
+				model[batch[i].Key] = batch[i].Value // 같은 키는 마지막 값이 이긴다.
+			}
+			tree.InsertMany(batch)
+			for i := 0; i < 20; i++ {
+				k := rand.Intn(1000)
+				tree.Delete(k)
+				delete(model, k)
+			}
+			assertRBProperties(t, tree)
+			if tree.Size() != len(model) {
+				t.Fatalf("size %d disagrees with model %d", tree.Size(), len(model))
+			}
+		}
+		for k, v := range model {
+			if node := tree.Search(k); node == nil || node.Value != v {
+				t.Fatalf("key %d expected %d, got %+v", k, v, node)
+			}
+		}
+	}
+}
+
+func TestInsertManyKeepsExistingNodes(t *testing.T) {
+	tree := New[int, string]()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, "old")
+	}
+	held := tree.Search(42)
+	tree.InsertMany([]Pair[int, string]{{42, "new"}, {1000, "x"}, {-5, "y"}})
+	if tree.Search(42) != held || held.Value != "new" {
+		t.Fatalf("existing nodes should be reused and updated in place")
+	}
+	if tree.Size() != 102 {
+		t.Fatalf("expected 102 keys, got %d", tree.Size())
+	}
+	assertRBProperties(t, tree)
+
+	// 묶음이 트리보다 크면 다시 엮는 경로를 탄다. 이때도 노드는 재사용되어야 한다.
+	batch := make([]Pair[int, string], 200)
+	for i := range batch {
+		batch[i] = Pair[int, string]{Key: i, Value: "rebuilt"}
+	}
+	tree.InsertMany(batch)
+	if tree.Search(42) != held || held.Value != "rebuilt" {
+		t.Fatalf("rebuild should reuse existing nodes")
+	}
+	if tree.Size() != 202 {
+		t.Fatalf("expected 202 keys, got %d", tree.Size())
+	}
+	assertRBProperties(t, tree)
+}
+
+func BenchmarkInsertMany(b *testing.B) {
+	for _, size := range []struct{ n, batch int }{{100_000, 1_000}, {100_000, 20_000}, {10_000, 100_000}} {
+		for _, sorted := range []bool{false, true} {
+			name := fmt.Sprintf("n=%d/batch=%d/sorted=%v", size.n, size.batch, sorted)
+			b.Run(name, func(b *testing.B) {
+				benchmarkInsertMany(b, size.n, size.batch, sorted)
+			})
+		}
+	}
+}
+
+func benchmarkInsertMany(b *testing.B, n, batchSize int, sorted bool) {
+	base := New[int, int]()
+	for _, k := range rand.Perm(n) {
+		base.Insert(k*2, k)
+	}
+	batch := make([]Pair[int, int], batchSize)
+	for i := range batch {
+		batch[i] = Pair[int, int]{Key: rand.Intn(2 * n), Value: i}
+	}
+	if sorted {
+		slices.SortStableFunc(batch, func(a, b Pair[int, int]) int { return a.Key - b.Key })
+	}
+
+	b.Run("Insert", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			tree := base.Clone()
+			b.StartTimer()
+			for _, p := range batch {
+				tree.Insert(p.Key, p.Value)
+			}
+		}
+	})
+	b.Run("InsertMany", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			tree := base.Clone()
+			input := slices.Clone(batch) // InsertMany가 제자리 정렬하므로 매번 원래 입력을 준다.
+			b.StartTimer()
+			tree.InsertMany(input)
+		}
+	})
+}
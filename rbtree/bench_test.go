@@ -0,0 +1,221 @@
+package rbtree
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// 아래 벤치마크는 트리를 내장 map, 정렬된 슬라이스와 같은 작업으로 비교한다. 원소 하나당 시간을
+// 보려면 ns/key 지표를 본다. map은 순서를 지키지 않으므로 순회는 순서 없이 도는 비용이다.
+//
+//	go test -run '^$' -bench '(Insert(Sequential|Random)|Lookup|DeleteRandom|Iterate)/n=100000/' ./rbtree
+
+var benchSizes = []int{1_000, 10_000, 100_000, 1_000_000}
+
+// sliceLimit보다 큰 정렬 슬라이스에는 무작위 삽입·삭제를 돌리지 않는다. 원소마다 O(n) 이동이라
+// 1M에서는 한 번 돌리는 데 몇 분이 걸리고, 그보다 작은 크기에서 이미 차이가 분명하다.
+const sliceLimit = 100_000
+
+// benchContainer는 비교 대상을 같은 모양으로 다루기 위한 최소한의 인터페이스다.
+type benchContainer interface {
+	insert(key, value int)
+	get(key int) (int, bool)
+	delete(key int) bool
+	each(fn func(key, value int))
+}
+
+type benchTree struct{ t *Tree[int, int] }
+
+func (c benchTree) insert(key, value int)        { c.t.Put(key, value) }
+func (c benchTree) get(key int) (int, bool)      { return c.t.Get(key) }
+func (c benchTree) delete(key int) bool          { return c.t.Delete(key) }
+func (c benchTree) each(fn func(key, value int)) { c.t.InOrder(fn) }
+
+type benchMap map[int]int
+
+func (c benchMap) insert(key, value int) { c[key] = value }
+func (c benchMap) get(key int) (int, bool) {
+	v, ok := c[key]
+	return v, ok
+}
+func (c benchMap) delete(key int) bool {
+	_, ok := c[key]
+	delete(c, key)
+	return ok
+}
+func (c benchMap) each(fn func(key, value int)) {
+	for k, v := range c {
+		fn(k, v)
+	}
+}
+
+// benchSlice는 키 순서로 정렬된 쌍 슬라이스로, 이진 탐색으로 찾고 가운데에 끼워 넣는다.
+type benchSlice struct{ pairs []Pair[int, int] }
+
+func (c *benchSlice) find(key int) (int, bool) {
+	return slices.BinarySearchFunc(c.pairs, key, func(p Pair[int, int], key int) int { return p.Key - key })
+}
+
+func (c *benchSlice) insert(key, value int) {
+	i, ok := c.find(key)
+	if ok {
+		c.pairs[i].Value = value
+		return
+	}
+	c.pairs = slices.Insert(c.pairs, i, Pair[int, int]{Key: key, Value: value})
+}
+
+func (c *benchSlice) get(key int) (int, bool) {
+	if i, ok := c.find(key); ok {
+		return c.pairs[i].Value, true
+	}
+	return 0, false
+}
+
+func (c *benchSlice) delete(key int) bool {
+	i, ok := c.find(key)
+	if ok {
+		c.pairs = slices.Delete(c.pairs, i, i+1)
+	}
+	return ok
+}
+
+func (c *benchSlice) each(fn func(key, value int)) {
+	for _, p := range c.pairs {
+		fn(p.Key, p.Value)
+	}
+}
+
+// benchKinds는 비교 대상 이름과 빈 컨테이너를 만드는 함수다.
+var benchKinds = []struct {
+	name string
+	new  func() benchContainer
+}{
+	{"Tree", func() benchContainer { return benchTree{New[int, int]()} }},
+	{"Map", func() benchContainer { return benchMap{} }},
+	{"Slice", func() benchContainer { return &benchSlice{} }},
+}
+
+// runBench는 크기와 비교 대상마다 fn을 하위 벤치마크로 돌린다. 정렬 슬라이스에 O(n²) 작업을 큰
+// 크기로 돌리지 않도록 quadratic이 참이면 sliceLimit을 넘는 크기는 건너뛴다.
+func runBench(b *testing.B, quadratic bool, fn func(b *testing.B, n int, newContainer func() benchContainer)) {
+	for _, n := range benchSizes {
+		for _, kind := range benchKinds {
+			if quadratic && kind.name == "Slice" && n > sliceLimit {
+				continue
+			}
+			b.Run(fmt.Sprintf("n=%d/%s", n, kind.name), func(b *testing.B) {
+				fn(b, n, kind.new)
+			})
+		}
+	}
+}
+
+// fill은 keys를 차례로 넣은 컨테이너를 만든다.
+func fill(newContainer func() benchContainer, keys []int) benchContainer {
+	c := newContainer()
+	for _, k := range keys {
+		c.insert(k, k)
+	}
+	return c
+}
+
+// reportPerKey는 반복마다 n개 원소를 다룬 벤치마크에 원소 하나당 시간을 덧붙인다.
+func reportPerKey(b *testing.B, n int) {
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N)/float64(n), "ns/key")
+}
+
+func sequentialKeys(n int) []int {
+	keys := make([]int, n)
+	for i := range keys {
+		keys[i] = i
+	}
+	return keys
+}
+
+func BenchmarkInsertSequential(b *testing.B) {
+	runBench(b, false, func(b *testing.B, n int, newContainer func() benchContainer) {
+		keys := sequentialKeys(n)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			fill(newContainer, keys)
+		}
+		reportPerKey(b, n)
+	})
+}
+
+func BenchmarkInsertRandom(b *testing.B) {
+	runBench(b, true, func(b *testing.B, n int, newContainer func() benchContainer) {
+		keys := rand.Perm(n)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			fill(newContainer, keys)
+		}
+		reportPerKey(b, n)
+	})
+}
+
+// 찾는 키는 미리 섞어 두어 캐시에 유리한 순차 접근이 되지 않게 한다. 원소는 짝수 키에만 있으므로
+// 홀수 키는 모두 빗나간다.
+func benchmarkLookup(b *testing.B, miss bool) {
+	runBench(b, false, func(b *testing.B, n int, newContainer func() benchContainer) {
+		keys := rand.Perm(n)
+		for i := range keys {
+			keys[i] *= 2
+		}
+		c := fill(newContainer, keys)
+		probes := slices.Clone(keys)
+		rand.Shuffle(len(probes), func(i, j int) { probes[i], probes[j] = probes[j], probes[i] })
+		if miss {
+			for i := range probes {
+				probes[i]++
+			}
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, ok := c.get(probes[i%len(probes)]); ok == miss {
+				b.Fatalf("lookup of %d: found = %v", probes[i%len(probes)], ok)
+			}
+		}
+	})
+}
+
+func BenchmarkLookupHit(b *testing.B)  { benchmarkLookup(b, false) }
+func BenchmarkLookupMiss(b *testing.B) { benchmarkLookup(b, true) }
+
+func BenchmarkDeleteRandom(b *testing.B) {
+	runBench(b, true, func(b *testing.B, n int, newContainer func() benchContainer) {
+		keys := rand.Perm(n)
+		victims := rand.Perm(n)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			c := fill(newContainer, keys)
+			b.StartTimer()
+			for _, k := range victims {
+				c.delete(k)
+			}
+		}
+		reportPerKey(b, n)
+	})
+}
+
+func BenchmarkIterate(b *testing.B) {
+	runBench(b, false, func(b *testing.B, n int, newContainer func() benchContainer) {
+		c := fill(newContainer, rand.Perm(n))
+		sum := 0
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			c.each(func(key, value int) { sum += value })
+		}
+		reportPerKey(b, n)
+		if sum == 0 && n > 1 {
+			b.Fatal("iteration visited nothing")
+		}
+	})
+}
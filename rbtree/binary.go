@@ -0,0 +1,93 @@
+package rbtree
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// ErrChecksumMismatch는 UnmarshalBinary가 읽은 데이터의 체크섬이 기록된 값과 다를 때 돌려주는 에러다.
+var ErrChecksumMismatch = errors.New("rbtree: checksum mismatch")
+
+// checksumSize는 MarshalBinary 결과 끝에 붙는 CRC32 값의 바이트 수다.
+const checksumSize = 4
+
+// Checksum은 모든 (키, 값) 쌍을 정렬 순서대로 gob 인코딩한 바이트열의 CRC32(IEEE)를 돌려준다.
+// MarshalBinary가 기록하는 체크섬과 같은 값이다. gob으로 인코딩할 수 없는 원소가 있으면 0을 돌려준다.
+// 값에 맵이 들어 있으면 gob의 맵 순회 순서 때문에 호출마다 결과가 달라질 수 있다.
+func (t *Tree[K, V]) Checksum() uint32 {
+	h := crc32.NewIEEE()
+	if err := t.encodeEntries(h); err != nil {
+		return 0
+	}
+	return h.Sum32()
+}
+
+// MarshalBinary는 encoding.BinaryMarshaler를 구현한다. 원소 수와 각 원소를 하나의 gob 스트림으로
+// 기록하고, 그 뒤에 스트림 전체의 CRC32를 빅엔디언 4바이트로 덧붙인다.
+func (t *Tree[K, V]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := t.encodeEntries(&buf); err != nil {
+		return nil, fmt.Errorf("rbtree: marshal: %w", err)
+	}
+	return binary.BigEndian.AppendUint32(buf.Bytes(), crc32.ChecksumIEEE(buf.Bytes())), nil
+}
+
+// UnmarshalBinary는 encoding.BinaryUnmarshaler를 구현한다. 체크섬을 먼저 확인해 데이터가
+// 손상되었으면 ErrChecksumMismatch를 돌려주고 트리는 건드리지 않는다. 성공하면 트리의 기존
+// 내용을 버리고 읽은 원소로 채운다(톰스톤 모드나 크기 제한 같은 설정은 유지된다).
+// 제로값 Tree로 읽을 때의 키 순서는 UnmarshalJSON과 같다.
+func (t *Tree[K, V]) UnmarshalBinary(data []byte) error {
+	if len(data) < checksumSize {
+		return fmt.Errorf("rbtree: unmarshal: data too short (%d bytes)", len(data))
+	}
+	payload, sum := data[:len(data)-checksumSize], data[len(data)-checksumSize:]
+	if stored, actual := binary.BigEndian.Uint32(sum), crc32.ChecksumIEEE(payload); stored != actual {
+		return fmt.Errorf("%w: stored %08x, computed %08x", ErrChecksumMismatch, stored, actual)
+	}
+
+	dec := gob.NewDecoder(bytes.NewReader(payload))
+	var count int
+	if err := dec.Decode(&count); err != nil {
+		return fmt.Errorf("rbtree: unmarshal: %w", err)
+	}
+	// 원소 하나는 적어도 1바이트를 차지하므로 남은 데이터보다 많은 원소 수는 손상된 것이다. count를
+	// 믿고 한꺼번에 할당하지 않고, 실제로 읽히는 만큼만 슬라이스를 키운다.
+	if count < 0 || count > len(payload) {
+		return fmt.Errorf("rbtree: unmarshal: invalid entry count %d for %d bytes", count, len(payload))
+	}
+	entries := make([]Pair[K, V], 0, min(count, 1024))
+	for i := 0; i < count; i++ {
+		var p Pair[K, V]
+		if err := dec.Decode(&p); err != nil {
+			return fmt.Errorf("rbtree: unmarshal entry %d: %w", i, err)
+		}
+		entries = append(entries, p)
+	}
+
+	if err := t.ensureCompare(); err != nil {
+		return err
+	}
+	t.Clear()
+	t.InsertMany(entries)
+	return nil
+}
+
+// encodeEntries는 원소 수와 원소들을 하나의 gob 인코더로 w에 기록한다.
+func (t *Tree[K, V]) encodeEntries(w io.Writer) error {
+	enc := gob.NewEncoder(w)
+	if err := enc.Encode(t.size); err != nil {
+		return err
+	}
+	var err error
+	t.InOrder(func(key K, value V) {
+		if err == nil {
+			err = enc.Encode(Pair[K, V]{Key: key, Value: value})
+		}
+	})
+	return err
+}
@@ -0,0 +1,108 @@
+package rbtree
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"hash/crc32"
+	"math"
+	"testing"
+)
+
+func TestMarshalBinaryRoundTrip(t *testing.T) {
+	tree := New[string, int]()
+	for i, k := range []string{"m", "c", "x", "a", "e", "z"} {
+		tree.Insert(k, i)
+	}
+	data, err := tree.MarshalBinary()
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+
+	restored := New[string, int]()
+	restored.Insert("stale", 99)
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if restored.Size() != tree.Size() || restored.Search("stale") != nil {
+		t.Fatalf("unmarshal should replace contents, got size %d", restored.Size())
+	}
+	tree.InOrder(func(key string, value int) {
+		if node := restored.Search(key); node == nil || node.Value != value {
+			t.Fatalf("key %q lost or changed after round trip", key)
+		}
+	})
+	if restored.Checksum() != tree.Checksum() {
+		t.Fatalf("checksum differs after round trip: %08x vs %08x", restored.Checksum(), tree.Checksum())
+	}
+	assertRBProperties(t, restored)
+}
+
+func TestUnmarshalBinaryDetectsCorruption(t *testing.T) {
+	tree := New[int, string]()
+	for i := 0; i < 50; i++ {
+		tree.Insert(i, "value")
+	}
+	data, err := tree.MarshalBinary()
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+
+	for i := range data {
+		corrupted := append([]byte(nil), data...)
+		corrupted[i] ^= 0x01
+		target := New[int, string]()
+		target.Insert(-1, "keep")
+		if err := target.UnmarshalBinary(corrupted); !errors.Is(err, ErrChecksumMismatch) {
+			t.Fatalf("flipping byte %d: expected ErrChecksumMismatch, got %v", i, err)
+		}
+		if target.Size() != 1 || target.Search(-1) == nil {
+			t.Fatalf("failed unmarshal must leave the tree untouched")
+		}
+	}
+
+	if err := New[int, string]().UnmarshalBinary(data[:2]); err == nil {
+		t.Fatalf("expected error for truncated data")
+	}
+}
+
+// 체크섬은 맞지만 원소 수가 엉터리인 입력은 panic하거나 크게 할당하지 않고 에러가 되어야 한다.
+func TestUnmarshalBinaryRejectsBadCount(t *testing.T) {
+	for _, count := range []int{-1, math.MaxInt, 1 << 40} {
+		var buf bytes.Buffer
+		enc := gob.NewEncoder(&buf)
+		if err := enc.Encode(count); err != nil {
+			t.Fatal(err)
+		}
+		if err := enc.Encode(Pair[int, string]{Key: 1, Value: "one"}); err != nil {
+			t.Fatal(err)
+		}
+		data := binary.BigEndian.AppendUint32(buf.Bytes(), crc32.ChecksumIEEE(buf.Bytes()))
+
+		target := New[int, string]()
+		target.Insert(-1, "keep")
+		if err := target.UnmarshalBinary(data); err == nil {
+			t.Fatalf("count %d: expected an error", count)
+		}
+		if target.Size() != 1 || target.Search(-1) == nil {
+			t.Fatalf("count %d: failed unmarshal must leave the tree untouched", count)
+		}
+	}
+}
+
+func TestChecksumTracksContents(t *testing.T) {
+	a := New[int, int]()
+	b := New[int, int]()
+	for i := 0; i < 10; i++ {
+		a.Insert(i, i)
+		b.Insert(9-i, 9-i) // 삽입 순서가 달라도 내용이 같으면 체크섬이 같아야 한다.
+	}
+	if a.Checksum() != b.Checksum() {
+		t.Fatalf("equal contents should have equal checksums")
+	}
+	b.Insert(3, 33)
+	if a.Checksum() == b.Checksum() {
+		t.Fatalf("different values should change the checksum")
+	}
+}
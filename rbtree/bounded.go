@@ -0,0 +1,101 @@
+package rbtree
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// NewBounded는 최대 maxSize개의 원소만 유지하는 빈 트리를 만든다. Insert로 크기가 maxSize를
+// 넘으면 가장 작은 키가 삭제되고, OnEvict로 등록한 콜백이 그 키와 값으로 호출된다.
+// 타임스탬프를 키로 최근 N개 이벤트만 남기는 슬라이딩 윈도처럼 쓸 수 있다.
+// maxSize가 0 이하이면 크기 제한이 없는 일반 트리와 같다. 다른 키를 내보내려면 WithMaxSize를 쓴다.
+func NewBounded[K cmp.Ordered, V any](maxSize int, opts ...Option) *Tree[K, V] {
+	return (&Tree[K, V]{compare: cmp.Compare[K], maxSize: maxSize}).apply(opts)
+}
+
+// EvictionPolicy는 크기 제한을 넘었을 때 어떤 원소를 내보낼지 정한다. WithMaxSize로 고른다.
+type EvictionPolicy int
+
+const (
+	// EvictMinKey는 가장 작은 키를 내보낸다(NewBounded의 방식). 타임스탬프를 키로 둔 슬라이딩 윈도에 맞다.
+	EvictMinKey EvictionPolicy = iota
+	// EvictMaxKey는 가장 큰 키를 내보낸다. 점수가 작은 상위 N개처럼 작은 키 쪽을 남길 때 쓴다.
+	EvictMaxKey
+	// EvictLRU는 가장 오래전에 쓴 키를 내보낸다. 키 삽입, 값 쓰기, Get을 사용으로 친다.
+	EvictLRU
+)
+
+// String은 "min-key", "max-key", "lru"를 돌려준다.
+func (p EvictionPolicy) String() string {
+	switch p {
+	case EvictMinKey:
+		return "min-key"
+	case EvictMaxKey:
+		return "max-key"
+	case EvictLRU:
+		return "lru"
+	}
+	return fmt.Sprintf("EvictionPolicy(%d)", int(p))
+}
+
+// WithMaxSize는 트리가 최대 n개의 원소만 유지하게 한다. 삽입으로 크기가 n을 넘으면 같은 쓰기 안에서
+// policy가 고른 원소를 지우고 OnEvict 콜백을 부르므로, 호출부가 Size를 보고 Delete하는 사이에 다른
+// 삽입이 끼어드는 경쟁이 없다. 새로 넣은 키가 곧바로 밀려날 수도 있다. 예를 들어 EvictMaxKey에서
+// 가장 큰 키를 넣으면 그 키가 나간다. n이 0 이하이면 크기 제한이 없다.
+//
+// EvictLRU는 키마다 최근 사용 순서 목록의 칸과 그 칸을 찾는 색인을 더 두므로 원소당 메모리가 늘고,
+// 삽입·삭제·Get마다 O(log n)이 더 든다. Get도 순서를 바꾸지만 내부에서 따로 잠그므로, syncrbtree처럼
+// 읽기 잠금 아래에서 Get을 함께 불러도 된다. Clone, Split, ReadFrom처럼 원소를 통째로 옮긴 트리는
+// 이전 사용 순서를 모르므로 옮겨 온 키를 모두 가장 오래된 것으로, 그중 작은 키를 더 오래된 것으로 본다.
+func WithMaxSize(n int, policy EvictionPolicy) Option {
+	return func(o *options) {
+		o.sized, o.maxSize, o.eviction = true, n, policy
+	}
+}
+
+// OnEvict는 크기 제한 때문에 원소가 밀려날 때 호출할 콜백을 등록한다. nil을 넘기면 해제한다.
+// 콜백은 원소가 이미 트리에서 빠진 뒤에 호출된다.
+func (t *Tree[K, V]) OnEvict(fn func(key K, value V)) {
+	t.onEvict = fn
+}
+
+// setEviction은 크기 제한과 내보내기 방식을 t에 적용한다.
+func (t *Tree[K, V]) setEviction(maxSize int, policy EvictionPolicy) {
+	switch policy {
+	case EvictMinKey, EvictMaxKey, EvictLRU:
+	default:
+		panic(fmt.Sprintf("rbtree: unknown eviction policy %v", policy))
+	}
+	t.maxSize, t.eviction, t.lru = maxSize, policy, nil
+	if policy == EvictLRU {
+		t.lru = newLRUList(t.plainCompare())
+	}
+}
+
+// evictOverflow는 크기 제한을 넘은 만큼 내보내기 방식에 따라 원소를 내보낸다.
+func (t *Tree[K, V]) evictOverflow() {
+	for t.maxSize > 0 && t.size > t.maxSize {
+		node := t.evictionVictim()
+		key, value := node.Key, node.Value
+		t.remove(node)
+		if t.onEvict != nil {
+			t.onEvict(key, value)
+		}
+	}
+}
+
+// evictionVictim은 다음에 내보낼 살아 있는 노드를 고른다.
+func (t *Tree[K, V]) evictionVictim() *Node[K, V] {
+	switch t.eviction {
+	case EvictMaxKey:
+		return t.last()
+	case EvictLRU:
+		t.syncLRU()
+		if key, ok := t.lru.oldest(); ok {
+			if node := t.Search(key); node != nil {
+				return node
+			}
+		}
+	}
+	return t.first()
+}
@@ -0,0 +1,187 @@
+package rbtree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestBoundedEvictsMinimum(t *testing.T) {
+	const maxSize = 16
+	tree := NewBounded[int, string](maxSize)
+	model := map[int]bool{}
+	evictions := 0
+	tree.OnEvict(func(key int, value string) {
+		evictions++
+		for k := range model {
+			if k < key {
+				t.Fatalf("evicted %d but smaller key %d remains", key, k)
+			}
+		}
+		if tree.Search(key) != nil {
+			t.Fatalf("evicted key %d still present", key)
+		}
+		delete(model, key)
+	})
+
+	for i := 0; i < 1000; i++ {
+		key := rand.Intn(500)
+		model[key] = true
+		tree.Insert(key, "v")
+		if tree.Size() > maxSize {
+			t.Fatalf("size %d exceeds bound %d", tree.Size(), maxSize)
+		}
+		if tree.Size() != len(model) {
+			t.Fatalf("tree size %d disagrees with model %d", tree.Size(), len(model))
+		}
+		assertRBProperties(t, tree)
+	}
+	if evictions == 0 {
+		t.Fatalf("expected at least one eviction")
+	}
+}
+
+func TestBoundedSlidingWindow(t *testing.T) {
+	tree := NewBounded[int, int](3)
+	var evicted []int
+	tree.OnEvict(func(key, value int) { evicted = append(evicted, key) })
+	for ts := 1; ts <= 5; ts++ {
+		tree.Insert(ts, ts)
+	}
+	// 가장 오래된(작은) 타임스탬프 두 개가 밀려나야 한다.
+	if len(evicted) != 2 || evicted[0] != 1 || evicted[1] != 2 {
+		t.Fatalf("expected evictions [1 2], got %v", evicted)
+	}
+	var keys []int
+	tree.InOrder(func(key, value int) { keys = append(keys, key) })
+	if len(keys) != 3 || keys[0] != 3 || keys[2] != 5 {
+		t.Fatalf("expected window [3 4 5], got %v", keys)
+	}
+}
+
+func TestWithMaxSizeMaxKey(t *testing.T) {
+	tree := New[int, int](WithMaxSize(3, EvictMaxKey))
+	var evicted []int
+	tree.OnEvict(func(key, value int) { evicted = append(evicted, key) })
+	for _, k := range []int{5, 1, 4, 2, 3} {
+		tree.Insert(k, k)
+	}
+	// 작은 키 셋이 남고, 넘칠 때마다 그 순간 가장 큰 키가 나간다.
+	if got := tree.Keys(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("keys after max-key eviction = %v", got)
+	}
+	if !slices.Equal(evicted, []int{5, 4}) {
+		t.Fatalf("evicted %v, want [5 4]", evicted)
+	}
+}
+
+func TestWithMaxSizeLRU(t *testing.T) {
+	for _, opts := range [][]Option{
+		{WithMaxSize(8, EvictLRU)},
+		{WithMaxSize(8, EvictLRU), WithBalancing(LeftLeaningRB)},
+	} {
+		tree := New[int, int](opts...)
+		// model은 가장 오래전에 쓴 키가 앞에 오는 참조 LRU다.
+		var model []int
+		use := func(key int) {
+			for i, k := range model {
+				if k == key {
+					model = append(model[:i], model[i+1:]...)
+					break
+				}
+			}
+			model = append(model, key)
+		}
+		drop := func(key int) {
+			for i, k := range model {
+				if k == key {
+					model = append(model[:i], model[i+1:]...)
+					return
+				}
+			}
+		}
+		tree.OnEvict(func(key, value int) {
+			if len(model) == 0 || model[0] != key {
+				t.Fatalf("evicted %d, want least recently used of %v", key, model)
+			}
+			model = model[1:]
+		})
+
+		rng := rand.New(rand.NewSource(3))
+		for i := 0; i < 5000; i++ {
+			key := rng.Intn(20)
+			switch rng.Intn(4) {
+			case 0:
+				if _, ok := tree.Get(key); ok {
+					use(key)
+				}
+			case 1:
+				if tree.Delete(key) {
+					drop(key)
+				}
+			default:
+				use(key)
+				tree.Put(key, i)
+			}
+			if tree.Size() != len(model) || tree.Size() > 8 {
+				t.Fatalf("size %d, model %v", tree.Size(), model)
+			}
+		}
+		assertRBProperties(t, tree)
+	}
+}
+
+func TestWithMaxSizeLRUBulk(t *testing.T) {
+	tree := New[int, int](WithMaxSize(4, EvictLRU))
+	for k := 1; k <= 4; k++ {
+		tree.Insert(k, k)
+	}
+	tree.Get(1)
+
+	// 복사본은 사용 순서를 모르므로 작은 키부터 내보낸다. 원본의 순서는 그대로다.
+	clone := tree.Clone()
+	clone.Insert(5, 5)
+	if clone.Contains(1) || !clone.Contains(2) {
+		t.Fatalf("clone should evict the smallest key first, has %v", clone.Keys())
+	}
+	tree.Insert(5, 5)
+	if !tree.Contains(1) || tree.Contains(2) {
+		t.Fatalf("original should evict 2 after Get(1), has %v", tree.Keys())
+	}
+
+	// DeleteRange와 Clear로 빠진 키도 사용 순서에서 빠진다.
+	tree.DeleteRange(3, 5)
+	tree.Insert(6, 6)
+	tree.Insert(7, 7)
+	tree.Get(1)
+	tree.Insert(8, 8)
+	if got := tree.Keys(); !slices.Equal(got, []int{1, 6, 7, 8}) {
+		t.Fatalf("keys after DeleteRange = %v", got)
+	}
+	tree.Clear()
+	for k := 10; k <= 15; k++ {
+		tree.Insert(k, k)
+	}
+	if got := tree.Keys(); !slices.Equal(got, []int{12, 13, 14, 15}) {
+		t.Fatalf("keys after Clear = %v", got)
+	}
+	if s := tree.Snapshot(); s.lru != nil {
+		t.Fatalf("a snapshot should not track recency")
+	}
+}
+
+func TestEvictionPolicyString(t *testing.T) {
+	for p, want := range map[EvictionPolicy]string{
+		EvictMinKey: "min-key", EvictMaxKey: "max-key", EvictLRU: "lru", EvictionPolicy(7): "EvictionPolicy(7)",
+	} {
+		if got := p.String(); got != want {
+			t.Fatalf("String() = %q, want %q", got, want)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("an unknown policy should panic")
+		}
+	}()
+	New[int, int](WithMaxSize(1, EvictionPolicy(7)))
+}
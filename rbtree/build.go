@@ -0,0 +1,40 @@
+package rbtree
+
+import "math/bits"
+
+// buildSorted는 키 오름차순으로 정렬되고 중복이 없는 pairs로 균형 잡힌 RB 서브트리를 O(n)에 만든다.
+// 회전이나 보정 없이 노드마다 할당 한 번으로 끝난다.
+func buildSorted[K any, V any](pairs []Pair[K, V]) *Node[K, V] {
+	nodes := make([]*Node[K, V], len(pairs))
+	for i, p := range pairs {
+		nodes[i] = &Node[K, V]{Key: p.Key, Value: p.Value}
+	}
+	return linkBalanced(nodes)
+}
+
+// linkBalanced는 키 오름차순으로 놓인 살아 있는 노드들을 다시 엮어 균형 잡힌 RB 서브트리를 만든다.
+// 가운데 원소를 루트로 삼아 재귀적으로 나누면 모든 잎의 깊이가 최대 1만큼 차이 나므로,
+// 완전히 채워지지 않은 맨 아래 층의 노드만 빨강으로 칠하면 모든 경로의 검정 수가 같아진다.
+// 노드의 키와 값은 그대로 두고 자식/부모 포인터, 색, count만 새로 정한다.
+func linkBalanced[K any, V any](nodes []*Node[K, V]) *Node[K, V] {
+	// 깊이 redDepth 미만은 꽉 찬 층이고, 그 아래에 남는 노드가 빨강이 된다.
+	redDepth := bits.Len(uint(len(nodes)+1)) - 1
+	return linkLevel(nodes, 0, redDepth, nil)
+}
+
+func linkLevel[K any, V any](nodes []*Node[K, V], depth, redDepth int, parent *Node[K, V]) *Node[K, V] {
+	if len(nodes) == 0 {
+		return nil
+	}
+	mid := len(nodes) / 2
+	node := nodes[mid]
+	node.Parent = parent
+	node.count = len(nodes)
+	node.Color = black
+	if depth == redDepth {
+		node.Color = red
+	}
+	node.Left = linkLevel(nodes[:mid], depth+1, redDepth, node)
+	node.Right = linkLevel(nodes[mid+1:], depth+1, redDepth, node)
+	return node
+}
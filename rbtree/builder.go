@@ -0,0 +1,33 @@
+package rbtree
+
+import "cmp"
+
+// TreeBuilder는 설정 단계에서 .Add(k, v)를 이어 붙여 트리를 만들 때 쓰는 빌더다.
+// 빌더는 추가된 키/값만 기억하고, Build가 만든 트리에 대한 참조는 갖지 않는다.
+type TreeBuilder[K cmp.Ordered, V any] struct {
+	keys   []K
+	values []V
+}
+
+// NewBuilder는 빈 빌더를 만든다.
+func NewBuilder[K cmp.Ordered, V any]() *TreeBuilder[K, V] {
+	return &TreeBuilder[K, V]{}
+}
+
+// Add는 키/값을 기록하고 같은 빌더를 돌려주어 호출을 이어 쓸 수 있게 한다.
+// 같은 키를 여러 번 추가하면 Insert와 마찬가지로 마지막 값이 남는다.
+func (b *TreeBuilder[K, V]) Add(key K, value V) *TreeBuilder[K, V] {
+	b.keys = append(b.keys, key)
+	b.values = append(b.values, value)
+	return b
+}
+
+// Build는 지금까지 추가된 원소로 새 트리를 만든다. 여러 번 호출하면 매번 독립된 트리가 나오며,
+// 이후 Add를 더 호출해도 이미 만든 트리에는 영향이 없다.
+func (b *TreeBuilder[K, V]) Build() *Tree[K, V] {
+	t := New[K, V]()
+	for i, key := range b.keys {
+		t.Insert(key, b.values[i])
+	}
+	return t
+}
@@ -0,0 +1,34 @@
+package rbtree
+
+import "testing"
+
+func TestTreeBuilder(t *testing.T) {
+	b := NewBuilder[string, int]().
+		Add("/users", 1).
+		Add("/orders", 2).
+		Add("/users", 3)
+
+	first := b.Build()
+	second := b.Build()
+	if first == second || first.Root() == second.Root() {
+		t.Fatalf("Build must return independent trees")
+	}
+	if first.Size() != 2 || first.Search("/users").Value != 3 {
+		t.Fatalf("expected last write to win for duplicate keys")
+	}
+
+	// 한쪽을 바꿔도 다른 트리나 빌더에 영향이 없어야 한다.
+	first.Insert("/admin", 9)
+	first.Search("/orders").Value = 20
+	if second.Search("/admin") != nil || second.Search("/orders").Value != 2 {
+		t.Fatalf("mutating one built tree leaked into another")
+	}
+	b.Add("/carts", 4)
+	if first.Search("/carts") != nil || second.Search("/carts") != nil {
+		t.Fatalf("builder must not retain references to built trees")
+	}
+	if third := b.Build(); third.Size() != 3 || third.Search("/admin") != nil {
+		t.Fatalf("expected builder contents only, got size %d", third.Size())
+	}
+	assertRBProperties(t, first)
+}
@@ -0,0 +1,55 @@
+package rbtree
+
+// Clear는 모든 원소를 버려 트리를 비운다. 비교 함수, 톰스톤 모드, 크기 제한, OnEvict 콜백,
+// expvar 등록 같은 설정은 그대로 남으므로 같은 트리를 바로 다시 채워 쓸 수 있다.
+// WithNodePool을 쓰면 노드를 비워 풀에 돌려주고(스냅숏과 공유 중인 노드는 빼고), 아니면 가비지
+// 컬렉터가 거두어 간다. OnEvict는 호출되지 않는다.
+func (t *Tree[K, V]) Clear() {
+	shared := t.shared
+	t.shared = false // 어차피 버릴 노드이므로 복사할 필요가 없다.
+	t.willWrite()
+	pairs, removed := t.pendingClear(), t.size
+	root := t.root
+	t.root, t.size, t.dead = nil, 0, 0
+	t.mods++
+	t.vars.resized(0)
+	t.afterClear(removed, pairs)
+	if !shared {
+		t.releaseAll(root)
+	}
+}
+
+// releaseAll은 떼어 낸 서브트리 root의 노드를 모두 풀에 돌려준다. release가 링크까지 지우므로,
+// 자식을 끊으며 내려가고 잎을 돌려준 뒤 Parent로 올라가 추가 메모리 없이 훑는다.
+func (t *Tree[K, V]) releaseAll(root *Node[K, V]) {
+	if t.nodes == nil {
+		return
+	}
+	for node := root; node != nil; {
+		switch {
+		case node.Left != nil:
+			child := node.Left
+			node.Left = nil
+			node = child
+		case node.Right != nil:
+			child := node.Right
+			node.Right = nil
+			node = child
+		default:
+			parent := node.Parent
+			if node == root {
+				parent = nil
+			}
+			t.release(node)
+			node = parent
+		}
+	}
+}
+
+// Reset은 Clear에 더해 생성 이후 등록한 OnEvict 콜백과 expvar 연결을 끊어, 생성자가 막
+// 돌려준 것과 같은 상태로 되돌린다. 생성 시 정한 비교 함수와 모드는 유지된다.
+func (t *Tree[K, V]) Reset() {
+	t.Clear()
+	t.onEvict = nil
+	t.vars = nil
+}
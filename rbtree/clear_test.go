@@ -0,0 +1,51 @@
+package rbtree
+
+import (
+	"expvar"
+	"testing"
+)
+
+func TestClear(t *testing.T) {
+	tree := NewBounded[int, int](10)
+	evicted := 0
+	tree.OnEvict(func(int, int) { evicted++ })
+	tree.RegisterExpvar("rbtree_test_clear")
+	for i := 0; i < 5; i++ {
+		tree.Insert(i, i)
+	}
+
+	tree.Clear()
+	if tree.Size() != 0 || tree.Root() != nil || tree.Min() != nil {
+		t.Fatalf("Clear should empty the tree")
+	}
+	if v := expvar.Get("rbtree_test_clear.size").(*expvar.Int).Value(); v != 0 {
+		t.Fatalf("expvar size should follow Clear, got %d", v)
+	}
+
+	// 설정은 남아 있으므로 크기 제한과 콜백이 계속 동작한다.
+	for i := 0; i < 12; i++ {
+		tree.Insert(i, i)
+	}
+	if tree.Size() != 10 || evicted != 2 {
+		t.Fatalf("bounded settings should survive Clear, size %d evicted %d", tree.Size(), evicted)
+	}
+	assertRBProperties(t, tree)
+}
+
+func TestReset(t *testing.T) {
+	tree := NewBounded[int, int](2)
+	evicted := 0
+	tree.OnEvict(func(int, int) { evicted++ })
+	tree.Insert(1, 1)
+
+	tree.Reset()
+	for i := 0; i < 5; i++ {
+		tree.Insert(i, i)
+	}
+	if evicted != 0 {
+		t.Fatalf("Reset should drop the OnEvict callback")
+	}
+	if tree.Size() != 2 {
+		t.Fatalf("Reset should keep the constructor's size bound, got size %d", tree.Size())
+	}
+}
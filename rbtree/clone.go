@@ -0,0 +1,37 @@
+package rbtree
+
+// Clone은 트리를 O(n)에 복사한다. 모양과 색(톰스톤 노드 포함)까지 그대로 옮기므로 회전이나
+// 보정이 일어나지 않는다. 값은 대입으로 복사되므로 포인터나 슬라이스 값은 원본과 공유된다.
+// 추측성 변경을 하기 전에 싸게 스냅숏을 떠 둘 때 쓴다.
+func (t *Tree[K, V]) Clone() *Tree[K, V] {
+	return t.CloneFunc(nil)
+}
+
+// CloneFunc는 Clone과 같지만 각 값을 copyValue로 복사해 깊은 복사를 할 수 있게 한다.
+// copyValue가 nil이면 Clone과 같다. 톰스톤 노드의 값은 복사하지 않는다.
+func (t *Tree[K, V]) CloneFunc(copyValue func(V) V) *Tree[K, V] {
+	out := t.newLike()
+	out.root = cloneNode(t.root, nil, copyValue)
+	out.size, out.dead = t.size, t.dead
+	return out
+}
+
+func cloneNode[K any, V any](node, parent *Node[K, V], copyValue func(V) V) *Node[K, V] {
+	if node == nil {
+		return nil
+	}
+	c := &Node[K, V]{
+		Key:     node.Key,
+		Value:   node.Value,
+		Color:   node.Color,
+		Parent:  parent,
+		deleted: node.deleted,
+		count:   node.count,
+	}
+	if copyValue != nil && !node.deleted {
+		c.Value = copyValue(node.Value)
+	}
+	c.Left = cloneNode(node.Left, c, copyValue)
+	c.Right = cloneNode(node.Right, c, copyValue)
+	return c
+}
@@ -0,0 +1,55 @@
+package rbtree
+
+import "testing"
+
+func TestClone(t *testing.T) {
+	tree := New[int, []int]()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, []int{i})
+	}
+
+	shallow := tree.Clone()
+	deep := tree.CloneFunc(func(v []int) []int { return append([]int(nil), v...) })
+	for _, c := range []*Tree[int, []int]{shallow, deep} {
+		assertRBProperties(t, c)
+		if c.Size() != tree.Size() || c.Root() == tree.Root() {
+			t.Fatalf("clone should have the same size and its own nodes")
+		}
+		if !c.StructurallyEqual(tree) {
+			t.Fatalf("clone should preserve shape and colors")
+		}
+	}
+
+	tree.Search(7).Value[0] = 700
+	if shallow.Search(7).Value[0] != 700 {
+		t.Fatalf("Clone should share value contents")
+	}
+	if deep.Search(7).Value[0] != 7 {
+		t.Fatalf("CloneFunc should deep-copy values")
+	}
+
+	// 복제본을 바꿔도 원본에는 영향이 없다.
+	shallow.Delete(50)
+	shallow.Insert(1000, nil)
+	if tree.Search(50) == nil || tree.Search(1000) != nil {
+		t.Fatalf("mutating a clone leaked into the original")
+	}
+	assertRBProperties(t, shallow)
+	assertRBProperties(t, tree)
+}
+
+func TestCloneTombstones(t *testing.T) {
+	tree := NewWithTombstones[int, int]()
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, i)
+	}
+	tree.Delete(3)
+	c := tree.Clone()
+	if c.Search(3) != nil || c.Size() != 9 {
+		t.Fatalf("clone should keep tombstones")
+	}
+	if removed := c.Compact(nil); removed != 1 {
+		t.Fatalf("clone should carry its tombstone count, compacted %d", removed)
+	}
+	assertRBProperties(t, c)
+}
@@ -0,0 +1,66 @@
+package rbtree
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// csvHeader는 ExportCSV가 첫 줄에 쓰는 머리글이다.
+var csvHeader = []string{"key", "value"}
+
+// ExportCSV는 원소를 키 오름차순으로 "key,value" 머리글이 붙은 두 열짜리 CSV로 쓴다.
+// 키와 값은 fmt.Sprint로 문자열로 바꾼다. 다른 표기가 필요하면 ExportCSVFunc를 쓴다.
+func (t *Tree[K, V]) ExportCSV(w io.Writer) error {
+	return t.ExportCSVFunc(w, func(k K) string { return fmt.Sprint(k) }, func(v V) string { return fmt.Sprint(v) })
+}
+
+// ExportCSVFunc는 ExportCSV와 같지만 키와 값을 formatKey, formatValue로 문자열로 바꾼다.
+func (t *Tree[K, V]) ExportCSVFunc(w io.Writer, formatKey func(K) string, formatValue func(V) string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for k, v := range t.All() {
+		if err := cw.Write([]string{formatKey(k), formatValue(v)}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ImportCSV는 두 열짜리 CSV를 읽어 각 줄을 parseKey, parseValue로 해석해 트리에 넣고 넣은 줄 수를
+// 돌려준다. 첫 줄이 ExportCSV의 머리글("key,value")이면 건너뛴다. 같은 키가 여러 번 나오면
+// 마지막 값이 이긴다. 한 줄이라도 읽거나 해석하지 못하면 줄 번호를 담은 에러를 돌려주고
+// 트리는 건드리지 않는다.
+func (t *Tree[K, V]) ImportCSV(r io.Reader, parseKey func(string) (K, error), parseValue func(string) (V, error)) (int, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 2
+	var pairs []Pair[K, V]
+	for first := true; ; first = false {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("rbtree: import csv: %w", err)
+		}
+		if first && record[0] == csvHeader[0] && record[1] == csvHeader[1] {
+			continue
+		}
+		line, _ := cr.FieldPos(0)
+		key, err := parseKey(record[0])
+		if err != nil {
+			return 0, fmt.Errorf("rbtree: import csv line %d: key: %w", line, err)
+		}
+		value, err := parseValue(record[1])
+		if err != nil {
+			return 0, fmt.Errorf("rbtree: import csv line %d: value: %w", line, err)
+		}
+		pairs = append(pairs, Pair[K, V]{key, value})
+	}
+	t.InsertMany(pairs)
+	return len(pairs), nil
+}
@@ -0,0 +1,46 @@
+package rbtree
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	tree := New[int, string]()
+	tree.Insert(2, "two, with comma")
+	tree.Insert(1, `say "hi"`)
+	var buf bytes.Buffer
+	if err := tree.ExportCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "key,value\n1,\"say \"\"hi\"\"\"\n2,\"two, with comma\"\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected CSV:\n%s", buf.String())
+	}
+
+	restored := New[int, string]()
+	n, err := restored.ImportCSV(&buf, strconv.Atoi, func(s string) (string, error) { return s, nil })
+	if err != nil || n != 2 {
+		t.Fatalf("ImportCSV returned (%d, %v)", n, err)
+	}
+	if !restored.Equal(tree, func(a, b string) bool { return a == b }) {
+		t.Fatalf("CSV round trip changed contents")
+	}
+}
+
+func TestImportCSVErrors(t *testing.T) {
+	tree := New[int, int]()
+	tree.Insert(100, 100)
+	_, err := tree.ImportCSV(strings.NewReader("1,1\n2,x\n"), strconv.Atoi, strconv.Atoi)
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("expected a line 2 value error, got %v", err)
+	}
+	if tree.Size() != 1 {
+		t.Fatalf("failed import must not modify the tree")
+	}
+	if _, err := tree.ImportCSV(strings.NewReader("1,1,1\n"), strconv.Atoi, strconv.Atoi); err == nil {
+		t.Fatalf("rows with the wrong number of fields should fail")
+	}
+}
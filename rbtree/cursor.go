@@ -0,0 +1,118 @@
+package rbtree
+
+import "fmt"
+
+// MutableCursor는 트리를 순회하면서 현재 원소를 고치거나 지우고, 그 바로 앞뒤에 새 원소를
+// 끼워 넣을 수 있는 커서다. 이동(Next, Prev, Seek)과 조회(Key, Value)는 Iterator와 같다.
+// 커서는 원소 "위"에 있거나, 원소를 지운 직후처럼 두 원소 "사이"에 있다.
+// 새로 만든 커서는 첫 원소 앞에 있으므로 Next는 최소 키로, Prev는 최대 키로 이동한다.
+//
+// 커서를 쓰는 동안 다른 경로(Insert, Delete 등)로 트리 구조를 바꾸면 다음 이동이
+// ErrModifiedDuringIteration으로 panic한다. 커서 자신의 메서드로 바꾸는 것은 괜찮다.
+// 크기 제한이 있는 트리(NewBounded)에서 InsertBefore/InsertAfter가 현재 원소를 밀어내면
+// 커서는 더 이상 유효하지 않다.
+type MutableCursor[K any, V any] struct {
+	Iterator[K, V]
+}
+
+// MutableCursor는 첫 원소 앞에 놓인 수정 가능한 커서를 돌려준다.
+func (t *Tree[K, V]) MutableCursor() *MutableCursor[K, V] {
+	return &MutableCursor[K, V]{Iterator[K, V]{t: t, fresh: true}}
+}
+
+// SetValue는 현재 원소의 값을 바꾼다. 커서가 원소 위에 있지 않으면 아무 일도 하지 않는다.
+func (c *MutableCursor[K, V]) SetValue(value V) {
+	c.willWrite()
+	if c.node != nil {
+		old := c.node.Value
+		c.node.Value = value
+		c.t.augmentPath(c.node)
+		c.t.afterUpdate(c.node.Key, old, value)
+	}
+}
+
+// DeleteCurrent는 현재 원소를 삭제하고 true를 돌려준다. 삭제 뒤 커서는 지운 원소의 앞뒤 원소
+// 사이에 놓이므로, 다음 Next는 지운 원소의 후속 원소로, Prev는 선행 원소로 이동한다.
+// 그래서 "for c.Next() { if 조건 { c.DeleteCurrent() } }" 형태로 안전하게 걸러 낼 수 있다.
+// 커서가 원소 위에 있지 않으면 false를 돌려준다.
+func (c *MutableCursor[K, V]) DeleteCurrent() bool {
+	c.willWrite()
+	if c.node == nil {
+		return false
+	}
+	// 삭제는 다른 노드를 옮기기만 할 뿐 없애지 않으므로, 미리 잡아 둔 이웃 포인터는 그대로 유효하다.
+	c.prev, c.next = prevLive(c.node), nextLive(c.node)
+	c.t.remove(c.node)
+	c.node = nil
+	c.synced()
+	return true
+}
+
+// InsertBefore는 현재 원소 바로 앞에 새 원소를 넣는다. key는 현재 키보다 작고 이전 원소의
+// 키보다 커야 하며, 그렇지 않으면 panic한다. 루트부터 다시 찾지 않고 현재 노드 근처에 바로 붙인다.
+// 커서는 현재 원소에 그대로 머문다.
+func (c *MutableCursor[K, V]) InsertBefore(key K, value V) {
+	c.willWrite()
+	cur := c.mustCurrent("InsertBefore")
+	prev := prevLive(cur)
+	if c.t.compare(key, cur.Key) >= 0 || (prev != nil && c.t.compare(prev.Key, key) >= 0) {
+		panic(fmt.Sprintf("rbtree: InsertBefore key %v does not fit before %v", key, cur.Key))
+	}
+	defer c.synced()
+	if c.t.tombstones {
+		// 사이에 같은 키의 톰스톤이 숨어 있을 수 있으므로 일반 Insert로 되살리게 한다.
+		c.t.Insert(key, value)
+		return
+	}
+	if cur.Left == nil {
+		c.t.link(cur, true, key, value)
+	} else {
+		c.t.link(maximum(cur.Left), false, key, value)
+	}
+}
+
+// InsertAfter는 InsertBefore의 대칭으로, 현재 원소 바로 뒤에 새 원소를 넣는다.
+func (c *MutableCursor[K, V]) InsertAfter(key K, value V) {
+	c.willWrite()
+	cur := c.mustCurrent("InsertAfter")
+	next := nextLive(cur)
+	if c.t.compare(key, cur.Key) <= 0 || (next != nil && c.t.compare(next.Key, key) <= 0) {
+		panic(fmt.Sprintf("rbtree: InsertAfter key %v does not fit after %v", key, cur.Key))
+	}
+	defer c.synced()
+	if c.t.tombstones {
+		c.t.Insert(key, value)
+		return
+	}
+	if cur.Right == nil {
+		c.t.link(cur, false, key, value)
+	} else {
+		c.t.link(minimum(cur.Right), true, key, value)
+	}
+}
+
+func (c *MutableCursor[K, V]) mustCurrent(op string) *Node[K, V] {
+	if c.node == nil {
+		panic("rbtree: " + op + " called on a cursor that is not positioned on an element")
+	}
+	return c.node
+}
+
+// willWrite는 트리의 willWrite를 부르고, 스냅숏과 공유하던 노드가 복사되었으면 커서가 잡고 있던
+// 노드들을 같은 키를 가진 새 노드로 옮긴다.
+func (c *MutableCursor[K, V]) willWrite() {
+	if !c.t.willWrite() {
+		return
+	}
+	for _, n := range []**Node[K, V]{&c.node, &c.prev, &c.next} {
+		if *n != nil {
+			*n = c.t.find((*n).Key)
+		}
+	}
+	c.synced()
+}
+
+// synced는 커서 자신이 일으킨 구조 변경을 순회 도중 변경으로 보지 않도록 기준을 맞춘다.
+func (c *MutableCursor[K, V]) synced() {
+	c.mods = c.t.mods
+}
@@ -0,0 +1,106 @@
+package rbtree
+
+import "testing"
+
+func TestMutableCursorFilteredDeletion(t *testing.T) {
+	for _, tree := range []*Tree[int, int]{New[int, int](), NewWithTombstones[int, int]()} {
+		for i := 0; i < 200; i++ {
+			tree.Insert(i, i)
+		}
+
+		c := tree.MutableCursor()
+		visited := 0
+		for c.Next() {
+			visited++
+			if c.Key()%3 != 0 {
+				if !c.DeleteCurrent() {
+					t.Fatalf("DeleteCurrent failed on key %d", c.Key())
+				}
+				if c.DeleteCurrent() {
+					t.Fatalf("DeleteCurrent twice should fail")
+				}
+				continue
+			}
+			c.SetValue(c.Value() * 10)
+		}
+		if visited != 200 {
+			t.Fatalf("expected to visit 200 keys while deleting, visited %d", visited)
+		}
+		assertRBProperties(t, tree)
+
+		var keys []int
+		tree.InOrder(func(key, value int) {
+			if key%3 != 0 || value != key*10 {
+				t.Fatalf("unexpected entry %d => %d after filtering", key, value)
+			}
+			keys = append(keys, key)
+		})
+		if len(keys) != 67 || tree.Size() != 67 {
+			t.Fatalf("expected 67 keys after filtering, got %d (size %d)", len(keys), tree.Size())
+		}
+	}
+}
+
+func TestMutableCursorNavigation(t *testing.T) {
+	tree := New[int, string]()
+	for _, k := range []int{10, 20, 30} {
+		tree.Insert(k, "v")
+	}
+
+	c := tree.MutableCursor()
+	if !c.Prev() || c.Key() != 30 {
+		t.Fatalf("Prev on a fresh cursor should move to the last key, got %d", c.Key())
+	}
+	if c.Next() {
+		t.Fatalf("Next past the last key should fail")
+	}
+	if !c.Prev() || c.Key() != 30 {
+		t.Fatalf("Prev after running off the end should return to the last key")
+	}
+	c.Prev() // 20
+	c.DeleteCurrent()
+	if !c.Prev() || c.Key() != 10 {
+		t.Fatalf("Prev after delete should move to the predecessor, got %d", c.Key())
+	}
+	if !c.Next() || c.Key() != 30 {
+		t.Fatalf("Next should skip the deleted key, got %d", c.Key())
+	}
+}
+
+func TestMutableCursorInsertNeighbours(t *testing.T) {
+	tree := New[int, int]()
+	for i := 0; i <= 100; i += 10 {
+		tree.Insert(i, i)
+	}
+
+	c := tree.MutableCursor()
+	for c.Next() {
+		key := c.Key()
+		if key > 0 {
+			c.InsertBefore(key-5, key-5)
+		}
+		c.InsertAfter(key+1, key+1)
+		if c.Key() != key {
+			t.Fatalf("cursor moved after insert: expected %d got %d", key, c.Key())
+		}
+		c.Next() // 방금 뒤에 넣은 key+1은 건너뛴다.
+	}
+	assertRBProperties(t, tree)
+	if tree.Size() != 11+10+11 {
+		t.Fatalf("expected 32 entries, got %d", tree.Size())
+	}
+	for i := 0; i <= 100; i += 10 {
+		if tree.Search(i+1) == nil || (i > 0 && tree.Search(i-5) == nil) {
+			t.Fatalf("missing neighbour inserted around %d", i)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("InsertBefore with an out-of-order key should panic")
+		}
+	}()
+	c = tree.MutableCursor()
+	c.Next()
+	c.InsertBefore(1000, 0)
+}
@@ -0,0 +1,22 @@
+package rbtree
+
+import "fmt"
+
+// WithDebugChecks는 삽입·삭제 한 건마다 Validate와 같은 확인을 다시 돌리고, 규칙이 깨졌으면 그 연산과
+// 키, 깨진 노드까지의 경로를 담아 panic하게 한다. 매번 트리 전체를 훑으므로 O(n)이 들며, 보정 코드를
+// 고쳐 보며 실험할 때만 쓴다. 프로그램 전체의 트리에 켜려면 rbtree_debug 빌드 태그를 쓴다.
+//
+//	go test -tags rbtree_debug ./...
+func WithDebugChecks() Option {
+	return func(o *options) { o.debug = true }
+}
+
+// debugCheck는 디버그 확인이 켜져 있으면 op(key) 직후의 트리를 확인한다.
+func (t *Tree[K, V]) debugCheck(op string, key K) {
+	if !debugChecks && !t.debug {
+		return
+	}
+	if err := t.check(); err != nil {
+		panic(fmt.Sprintf("rbtree: after %s %v: %v", op, key, err))
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !rbtree_debug
+
+package rbtree
+
+// debugChecks는 rbtree_debug 빌드 태그가 없으면 거짓이라, 변경마다의 확인은 컴파일 단계에서 빠진다.
+const debugChecks = false
@@ -0,0 +1,7 @@
+//go:build rbtree_debug
+
+package rbtree
+
+// debugChecks는 rbtree_debug 빌드 태그로 빌드했을 때 참이다. 그러면 모든 트리가
+// WithDebugChecks를 준 것처럼 변경마다 규칙을 다시 확인한다.
+const debugChecks = true
@@ -0,0 +1,31 @@
+package rbtree
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestWithDebugChecks(t *testing.T) {
+	tree := New[int, int](WithDebugChecks())
+	rng := rand.New(rand.NewSource(7))
+	for i := 0; i < 500; i++ {
+		k := rng.Intn(200)
+		if rng.Intn(3) == 0 {
+			tree.Delete(k)
+		} else {
+			tree.Insert(k, k)
+		}
+	}
+
+	// 보정 코드가 규칙을 깨뜨린 상황을 흉내 내면 다음 변경에서 키와 경로를 담아 panic해야 한다.
+	tree.Max().count = 42
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.HasPrefix(msg, "rbtree: after insert 1000: ") || !strings.Contains(msg, "at path R") {
+			t.Fatalf("unexpected panic %q", msg)
+		}
+	}()
+	tree.Insert(1000, 1000)
+}
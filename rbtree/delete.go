@@ -0,0 +1,19 @@
+package rbtree
+
+// DeleteIf는 pred가 true를 돌려주는 원소를 한 번의 중위 순회로 모두 지우고 지운 개수를 돌려준다.
+// 지울 노드의 다음 노드를 먼저 잡아 두고 지우는데, 삭제는 노드를 옮길 뿐 다른 노드 객체를
+// 버리지 않으므로 잡아 둔 노드는 계속 유효하다. 키를 슬라이스에 모아 두지 않으므로 추가 메모리가 들지 않는다.
+// pred 안에서 트리를 바꾸면 안 된다.
+func (t *Tree[K, V]) DeleteIf(pred func(key K, value V) bool) int {
+	t.willWrite()
+	removed := 0
+	for node := t.first(); node != nil; {
+		next := nextLive(node)
+		if pred(node.Key, node.Value) {
+			t.remove(node)
+			removed++
+		}
+		node = next
+	}
+	return removed
+}
@@ -0,0 +1,26 @@
+package rbtree
+
+import "testing"
+
+func TestDeleteIf(t *testing.T) {
+	for _, tree := range []*Tree[int, int]{New[int, int](), NewWithTombstones[int, int]()} {
+		for i := 0; i < 1000; i++ {
+			tree.Insert(i, i*i)
+		}
+		if got := tree.DeleteIf(func(key, value int) bool { return key%3 == 0 }); got != 334 {
+			t.Fatalf("expected 334 removals, got %d", got)
+		}
+		assertRBProperties(t, tree)
+		if tree.Size() != 666 {
+			t.Fatalf("expected 666 keys left, got %d", tree.Size())
+		}
+		for i := 0; i < 1000; i++ {
+			if (tree.Search(i) == nil) != (i%3 == 0) {
+				t.Fatalf("key %d in wrong state after DeleteIf", i)
+			}
+		}
+		if got := tree.DeleteIf(func(key, value int) bool { return true }); got != 666 || tree.Size() != 0 {
+			t.Fatalf("deleting everything removed %d, size %d", got, tree.Size())
+		}
+	}
+}
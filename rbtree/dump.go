@@ -0,0 +1,189 @@
+package rbtree
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// WriteTo/ReadFrom 형식은 모양과 색까지 그대로 기록해, 읽을 때 회전이나 보정 없이 O(n)에
+// 똑같은 트리를 되살린다. 전위 순회 순서로 다음을 쓴다.
+//
+//	"RBT\x01"                 매직과 형식 버전
+//	uvarint                   노드 수 n (톰스톤 노드 포함)
+//	n바이트                   노드마다 모양 바이트 (dumpRed, dumpLeft, dumpRight, dumpDeleted 비트)
+//	gob 스트림                노드마다 Pair{Key, Value}
+//
+// 키와 값은 gob으로 인코딩하므로 gob이 다룰 수 있는 타입이어야 한다.
+const dumpMagic = "RBT\x01"
+
+const (
+	dumpRed byte = 1 << iota
+	dumpLeft
+	dumpRight
+	dumpDeleted
+)
+
+// ErrCorruptDump는 ReadFrom이 읽은 데이터가 올바른 레드블랙 트리를 이루지 않을 때 돌려주는 에러다.
+var ErrCorruptDump = errors.New("rbtree: corrupt tree dump")
+
+// WriteTo는 io.WriterTo를 구현한다. 키, 값, 색, 모양을 모두 기록하므로 ReadFrom으로 읽은 트리는
+// 검정 높이까지 원본과 같다(StructurallyEqual). 쓴 바이트 수를 돌려준다.
+func (t *Tree[K, V]) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+
+	var nodes []*Node[K, V]
+	preOrder(t.root, func(n *Node[K, V]) { nodes = append(nodes, n) })
+	bw.WriteString(dumpMagic)
+	bw.Write(binary.AppendUvarint(nil, uint64(len(nodes))))
+	for _, n := range nodes {
+		var shape byte
+		if n.Color == red {
+			shape |= dumpRed
+		}
+		if n.Left != nil {
+			shape |= dumpLeft
+		}
+		if n.Right != nil {
+			shape |= dumpRight
+		}
+		if n.deleted {
+			shape |= dumpDeleted
+		}
+		bw.WriteByte(shape)
+	}
+
+	enc := gob.NewEncoder(bw)
+	for _, n := range nodes {
+		if err := enc.Encode(Pair[K, V]{n.Key, n.Value}); err != nil {
+			return cw.n, fmt.Errorf("rbtree: write key %v: %w", n.Key, err)
+		}
+	}
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// ReadFrom은 io.ReaderFrom을 구현한다. WriteTo가 쓴 트리를 회전 없이 O(n)에 되살려 기존 내용을
+// 바꾸고, r에서 읽은 바이트 수를 돌려준다. 읽는 김에 키 순서와 레드블랙 규칙을 확인해 어긋나면
+// ErrCorruptDump를 감싼 에러를 돌려주며, 이때 트리는 바뀌지 않는다. 버퍼링 때문에 r에서 트리 뒤의
+// 데이터까지 읽을 수 있으므로 r은 트리 하나만 담고 있어야 한다.
+//
+// 설정(비교 함수, 모드, 크기 제한)은 트리 쪽 것을 유지한다. 톰스톤 모드가 아닌 트리로 톰스톤이 든
+// 덤프를 읽으면 읽은 뒤 Compact하므로 모양이 달라질 수 있다. 제로값 Tree로 읽을 때의 키 순서는
+// UnmarshalJSON과 같다.
+func (t *Tree[K, V]) ReadFrom(r io.Reader) (int64, error) {
+	cr := &countingReader{r: r}
+	br := bufio.NewReader(cr)
+
+	magic := make([]byte, len(dumpMagic))
+	if _, err := io.ReadFull(br, magic); err != nil {
+		return cr.n, fmt.Errorf("rbtree: read header: %w", err)
+	}
+	if string(magic) != dumpMagic {
+		return cr.n, fmt.Errorf("%w: bad magic %q", ErrCorruptDump, magic)
+	}
+	count, err := binary.ReadUvarint(br)
+	if err != nil {
+		return cr.n, fmt.Errorf("rbtree: read node count: %w", err)
+	}
+	// count를 믿고 한꺼번에 할당하지 않고, 실제로 읽히는 만큼만 버퍼를 키운다.
+	var shapes bytes.Buffer
+	if _, err := io.CopyN(&shapes, br, int64(count)); err != nil {
+		return cr.n, fmt.Errorf("rbtree: read shapes: %w", err)
+	}
+	dec := gob.NewDecoder(br)
+	pairs := make([]Pair[K, V], 0, shapes.Len())
+	for i := 0; i < shapes.Len(); i++ {
+		var p Pair[K, V]
+		if err := dec.Decode(&p); err != nil {
+			return cr.n, fmt.Errorf("rbtree: read node %d: %w", i, err)
+		}
+		pairs = append(pairs, p)
+	}
+
+	if err := t.ensureCompare(); err != nil {
+		return cr.n, err
+	}
+	b := dumpBuilder[K, V]{shapes: shapes.Bytes(), pairs: pairs}
+	var root *Node[K, V] // 빈 트리의 덤프는 노드가 없으므로 엮을 것도 없다.
+	if len(pairs) > 0 {
+		root = b.build(nil)
+		if b.short || b.next != len(pairs) {
+			return cr.n, fmt.Errorf("%w: shape does not describe exactly %d nodes", ErrCorruptDump, len(pairs))
+		}
+	}
+	if err := t.validate(root); err != nil {
+		return cr.n, fmt.Errorf("%w: %v", ErrCorruptDump, err)
+	}
+
+	t.Clear()
+	t.root, t.size, t.dead = root, countOf(root), b.dead
+	t.balancer().normalize(t)
+	t.augmentAll()
+	if t.dead > 0 && !t.tombstones {
+		t.Compact(nil)
+	}
+	t.vars.resized(t.size)
+	t.evictOverflow()
+	return cr.n, nil
+}
+
+// dumpBuilder는 전위 순서의 모양 바이트와 원소로 노드를 다시 엮는다.
+type dumpBuilder[K any, V any] struct {
+	shapes []byte
+	pairs  []Pair[K, V]
+	next   int
+	dead   int
+	short  bool // 모양이 남은 노드보다 많은 자식을 요구했다
+}
+
+func (b *dumpBuilder[K, V]) build(parent *Node[K, V]) *Node[K, V] {
+	if b.next >= len(b.pairs) {
+		b.short = true
+		return nil
+	}
+	shape, p := b.shapes[b.next], b.pairs[b.next]
+	b.next++
+	n := &Node[K, V]{Key: p.Key, Value: p.Value, Color: black, Parent: parent, deleted: shape&dumpDeleted != 0}
+	if shape&dumpRed != 0 {
+		n.Color = red
+	}
+	if n.deleted {
+		b.dead++
+	}
+	if shape&dumpLeft != 0 {
+		n.Left = b.build(n)
+	}
+	if shape&dumpRight != 0 {
+		n.Right = b.build(n)
+	}
+	updateCount(n)
+	return n
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,103 @@
+package rbtree
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestWriteToReadFrom(t *testing.T) {
+	for _, tree := range []*Tree[int, string]{New[int, string](), NewWithTombstones[int, string]()} {
+		for _, k := range rand.Perm(500) {
+			tree.Insert(k, "v")
+		}
+		for i := 0; i < 100; i++ {
+			tree.Delete(rand.Intn(500))
+		}
+
+		var buf bytes.Buffer
+		written, err := tree.WriteTo(&buf)
+		if err != nil || written != int64(buf.Len()) {
+			t.Fatalf("WriteTo returned (%d, %v) for %d bytes", written, err, buf.Len())
+		}
+		restored := NewWithTombstones[int, string]()
+		restored.Insert(-1, "stale")
+		read, err := restored.ReadFrom(bytes.NewReader(buf.Bytes()))
+		if err != nil || read != written {
+			t.Fatalf("ReadFrom returned (%d, %v), wrote %d", read, err, written)
+		}
+		if !tree.StructurallyEqual(restored) || restored.Size() != tree.Size() || restored.dead != tree.dead {
+			t.Fatalf("restored tree should have the same shape, colors and tombstones")
+		}
+		assertRBProperties(t, restored)
+	}
+}
+
+func TestWriteToReadFromEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := New[int, string]().WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo on an empty tree: %v", err)
+	}
+	restored := New[int, string]()
+	restored.Insert(1, "stale")
+	if _, err := restored.ReadFrom(&buf); err != nil {
+		t.Fatalf("ReadFrom of an empty dump: %v", err)
+	}
+	if restored.Size() != 0 || restored.Root() != nil {
+		t.Fatalf("restored tree should be empty, size %d", restored.Size())
+	}
+}
+
+func TestReadFromCompactsIntoPlainTree(t *testing.T) {
+	tree := NewWithTombstones[int, int]()
+	for i := 0; i < 50; i++ {
+		tree.Insert(i, i)
+	}
+	tree.Delete(10)
+	var buf bytes.Buffer
+	if _, err := tree.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var plain Tree[int, int] // 제로값 트리도 기본 순서로 읽을 수 있다.
+	if _, err := plain.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if plain.Size() != 49 || plain.dead != 0 || plain.Contains(10) {
+		t.Fatalf("tombstones should be compacted away, size %d dead %d", plain.Size(), plain.dead)
+	}
+	assertRBProperties(t, &plain)
+}
+
+func TestReadFromRejectsCorruptDumps(t *testing.T) {
+	tree := New[int, int]()
+	for i := 0; i < 20; i++ {
+		tree.Insert(i, i)
+	}
+	var buf bytes.Buffer
+	if _, err := tree.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	good := buf.Bytes()
+
+	shapeStart := len(dumpMagic) + 1 // 노드 수 20은 uvarint 1바이트다.
+	recolored := bytes.Clone(good)
+	recolored[shapeStart] |= dumpRed // 루트를 빨강으로
+	reshaped := bytes.Clone(good)
+	reshaped[shapeStart] &^= dumpLeft // 왼쪽 서브트리를 떼어 낸 모양
+
+	for name, data := range map[string][]byte{"recolored": recolored, "reshaped": reshaped} {
+		target := New[int, int]()
+		target.Insert(99, 99)
+		_, err := target.ReadFrom(bytes.NewReader(data))
+		if !errors.Is(err, ErrCorruptDump) {
+			t.Fatalf("%s: expected ErrCorruptDump, got %v", name, err)
+		}
+		if target.Size() != 1 || !target.Contains(99) {
+			t.Fatalf("%s: failed ReadFrom must leave the tree untouched", name)
+		}
+	}
+	if _, err := New[int, int]().ReadFrom(bytes.NewReader(good[:len(good)-3])); err == nil {
+		t.Fatalf("truncated dump should fail")
+	}
+}
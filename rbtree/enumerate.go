@@ -0,0 +1,84 @@
+package rbtree
+
+// Pair는 키와 값 한 쌍이다. 여러 원소를 슬라이스로 주고받는 API에서 쓴다.
+type Pair[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// EnumerateFrom은 key부터 한 방향으로 최대 limit개의 원소를 돌려준다. ascending이면 key 이상인
+// 첫 원소부터 오름차순으로, 아니면 key 이하인 첫 원소부터 내림차순으로 모은다.
+// 커서 상태를 들고 다니지 않고 페이지를 넘길 때 쓴다. 시작 키가 결과에 포함되므로, 다음 페이지는
+// 이전 페이지의 마지막 키로 limit+1개를 요청해 첫 원소를 버리면 겹치지 않고 이어진다.
+// limit이 0 이하이면 빈 슬라이스를 돌려준다.
+func (t *Tree[K, V]) EnumerateFrom(key K, ascending bool, limit int) []Pair[K, V] {
+	if limit <= 0 {
+		return []Pair[K, V]{}
+	}
+	out := make([]Pair[K, V], 0, min(limit, t.size))
+	var node *Node[K, V]
+	if ascending {
+		node = t.ceiling(key)
+	} else {
+		node = t.floor(key)
+	}
+	for node != nil && len(out) < limit {
+		out = append(out, Pair[K, V]{Key: node.Key, Value: node.Value})
+		if ascending {
+			node = nextLive(node)
+		} else {
+			node = prevLive(node)
+		}
+	}
+	return out
+}
+
+// ForEachChunk는 원소를 키 순서대로 chunkSize개씩 묶어 fn을 한 번씩 호출한다. 원소마다 콜백을
+// 부르는 InOrder보다 호출 횟수가 chunkSize분의 1로 줄어 큰 트리를 일괄 처리할 때 유리하다.
+// 마지막 묶음은 chunkSize보다 작을 수 있다. 모든 호출에 같은 버퍼를 다시 쓰므로 fn은 넘겨받은
+// 슬라이스를 호출이 끝난 뒤까지 붙잡아 두면 안 된다(필요하면 복사한다). chunkSize가 0 이하이면 panic한다.
+func (t *Tree[K, V]) ForEachChunk(chunkSize int, fn func([]Pair[K, V])) {
+	if chunkSize <= 0 {
+		panic("rbtree: ForEachChunk chunkSize must be positive")
+	}
+	buf := make([]Pair[K, V], 0, min(chunkSize, t.size))
+	mods := t.mods
+	for node := t.first(); node != nil; node = nextLive(node) {
+		buf = append(buf, Pair[K, V]{Key: node.Key, Value: node.Value})
+		if len(buf) == chunkSize {
+			fn(buf)
+			t.checkMods(mods)
+			buf = buf[:0]
+		}
+	}
+	if len(buf) > 0 {
+		fn(buf)
+	}
+}
+
+// Keys는 모든 키를 오름차순으로 담은 새 슬라이스를 돌려준다.
+func (t *Tree[K, V]) Keys() []K {
+	return t.AppendKeys(make([]K, 0, t.size))
+}
+
+// Values는 모든 값을 키 오름차순으로 담은 새 슬라이스를 돌려준다.
+func (t *Tree[K, V]) Values() []V {
+	return t.AppendValues(make([]V, 0, t.size))
+}
+
+// AppendKeys는 모든 키를 오름차순으로 dst 뒤에 덧붙여 돌려준다. 미리 용량을 잡아 둔 버퍼를
+// 넘기거나 dst[:0]으로 버퍼를 재사용하면 추가 할당을 피할 수 있다.
+func (t *Tree[K, V]) AppendKeys(dst []K) []K {
+	for node := t.first(); node != nil; node = nextLive(node) {
+		dst = append(dst, node.Key)
+	}
+	return dst
+}
+
+// AppendValues는 AppendKeys와 같은 방식으로 값을 dst 뒤에 덧붙인다.
+func (t *Tree[K, V]) AppendValues(dst []V) []V {
+	for node := t.first(); node != nil; node = nextLive(node) {
+		dst = append(dst, node.Value)
+	}
+	return dst
+}
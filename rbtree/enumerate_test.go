@@ -0,0 +1,130 @@
+package rbtree
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEnumerateFromPaging(t *testing.T) {
+	tree := New[int, int]()
+	for i := 0; i < 95; i++ {
+		tree.Insert(i*2, i)
+	}
+
+	for _, ascending := range []bool{true, false} {
+		const pageSize = 10
+		var all []int
+		start := -1
+		if !ascending {
+			start = 1000
+		}
+		page := tree.EnumerateFrom(start, ascending, pageSize)
+		for len(page) > 0 {
+			for _, p := range page {
+				all = append(all, p.Key)
+			}
+			if len(page) < pageSize {
+				break
+			}
+			// 마지막 키로 다시 요청하면 그 키가 맨 앞에 다시 오므로 버린다.
+			next := tree.EnumerateFrom(page[len(page)-1].Key, ascending, pageSize+1)
+			if next[0].Key != page[len(page)-1].Key {
+				t.Fatalf("page should restart at the last returned key")
+			}
+			page = next[1:]
+		}
+
+		if len(all) != tree.Size() {
+			t.Fatalf("ascending=%v: expected %d keys across pages, got %d", ascending, tree.Size(), len(all))
+		}
+		for i := 1; i < len(all); i++ {
+			if ascending && all[i] != all[i-1]+2 || !ascending && all[i] != all[i-1]-2 {
+				t.Fatalf("ascending=%v: pages overlap or leave a gap at %v", ascending, all[i-1:i+1])
+			}
+		}
+	}
+}
+
+func TestEnumerateFromBounds(t *testing.T) {
+	tree := New[int, string]()
+	for _, k := range []int{10, 20, 30} {
+		tree.Insert(k, "v")
+	}
+	if got := tree.EnumerateFrom(15, true, 5); len(got) != 2 || got[0].Key != 20 {
+		t.Fatalf("ascending from 15 should start at 20, got %v", got)
+	}
+	if got := tree.EnumerateFrom(15, false, 5); len(got) != 1 || got[0].Key != 10 {
+		t.Fatalf("descending from 15 should start at 10, got %v", got)
+	}
+	if got := tree.EnumerateFrom(31, true, 5); len(got) != 0 {
+		t.Fatalf("nothing is above 31, got %v", got)
+	}
+	if got := tree.EnumerateFrom(10, true, 0); len(got) != 0 {
+		t.Fatalf("limit 0 should return nothing, got %v", got)
+	}
+}
+
+func TestForEachChunk(t *testing.T) {
+	tree := New[int, int]()
+	for i := 0; i < 103; i++ {
+		tree.Insert(i, i*i)
+	}
+
+	var sizes []int
+	next := 0
+	tree.ForEachChunk(10, func(chunk []Pair[int, int]) {
+		sizes = append(sizes, len(chunk))
+		for _, p := range chunk {
+			if p.Key != next || p.Value != next*next {
+				t.Fatalf("expected key %d in order, got %d => %d", next, p.Key, p.Value)
+			}
+			next++
+		}
+	})
+	if next != 103 {
+		t.Fatalf("expected to visit 103 entries, visited %d", next)
+	}
+	if len(sizes) != 11 || sizes[len(sizes)-1] != 3 {
+		t.Fatalf("expected ten full chunks and a final chunk of 3, got %v", sizes)
+	}
+	for _, size := range sizes[:len(sizes)-1] {
+		if size != 10 {
+			t.Fatalf("non-final chunk has size %d", size)
+		}
+	}
+
+	calls := 0
+	New[int, int]().ForEachChunk(4, func([]Pair[int, int]) { calls++ })
+	if calls != 0 {
+		t.Fatalf("empty tree should not invoke the callback")
+	}
+}
+
+func TestKeysAndValues(t *testing.T) {
+	tree := New[string, int]()
+	for i, k := range []string{"c", "a", "b"} {
+		tree.Insert(k, i)
+	}
+
+	keys := tree.Keys()
+	if len(keys) != 3 || keys[0] != "a" || keys[1] != "b" || keys[2] != "c" {
+		t.Fatalf("expected sorted keys, got %v", keys)
+	}
+	values := tree.Values()
+	if !slices.Equal(values, []int{1, 2, 0}) {
+		t.Fatalf("expected values in key order, got %v", values)
+	}
+	if len(New[int, int]().Keys()) != 0 {
+		t.Fatalf("empty tree should have no keys")
+	}
+
+	buf := make([]int, 0, 8)
+	out := tree.AppendValues(buf)
+	if &out[0] != &buf[:1][0] {
+		t.Fatalf("AppendValues should reuse a buffer with enough capacity")
+	}
+	prefixed := tree.AppendKeys([]string{"start"})
+	if len(prefixed) != 4 || prefixed[0] != "start" || prefixed[1] != "a" {
+		t.Fatalf("AppendKeys should append after existing elements, got %v", prefixed)
+	}
+}
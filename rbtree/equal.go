@@ -0,0 +1,36 @@
+package rbtree
+
+// Equal은 두 트리가 같은 키/값 순서열을 담고 있는지 비교한다. 내부 모양은 달라도 된다.
+// 키는 t의 비교 함수로, 값은 eq로 비교하며 eq가 nil이면 키만 비교한다.
+func (t *Tree[K, V]) Equal(other *Tree[K, V], eq func(a, b V) bool) bool {
+	if t.size != other.size {
+		return false
+	}
+	for a, b := t.first(), other.first(); a != nil && b != nil; a, b = nextLive(a), nextLive(b) {
+		if t.compare(a.Key, b.Key) != 0 {
+			return false
+		}
+		if eq != nil && !eq(a.Value, b.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// StructurallyEqual은 두 트리의 모양, 노드 색, 키가 모두 같은지 비교한다. 값은 보지 않는다.
+// 같은 연산 순서를 거친 두 트리가 같은 모양이 되는지처럼 구현을 시험할 때 쓴다.
+// 톰스톤 노드도 구조의 일부로 비교한다.
+func (t *Tree[K, V]) StructurallyEqual(other *Tree[K, V]) bool {
+	return t.size == other.size && sameStructure(t.root, other.root, t.compare)
+}
+
+func sameStructure[K any, V any](a, b *Node[K, V], compare func(a, b K) int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Color == b.Color &&
+		a.deleted == b.deleted &&
+		compare(a.Key, b.Key) == 0 &&
+		sameStructure(a.Left, b.Left, compare) &&
+		sameStructure(a.Right, b.Right, compare)
+}
@@ -0,0 +1,55 @@
+package rbtree
+
+import "testing"
+
+func TestEqual(t *testing.T) {
+	a, b := New[int, string](), New[int, string]()
+	for i := 0; i < 50; i++ {
+		a.Insert(i, "v")
+		b.Insert(49-i, "v") // 삽입 순서가 달라 모양은 다를 수 있다.
+	}
+	eq := func(x, y string) bool { return x == y }
+
+	if !a.Equal(b, eq) {
+		t.Fatalf("trees with the same contents should be Equal")
+	}
+	b.Search(10).Value = "changed"
+	if a.Equal(b, eq) {
+		t.Fatalf("different values should not be Equal")
+	}
+	if !a.Equal(b, nil) {
+		t.Fatalf("Equal with nil eq should compare keys only")
+	}
+	b.Delete(10)
+	b.Insert(100, "v")
+	if a.Equal(b, nil) {
+		t.Fatalf("different key sets should not be Equal")
+	}
+}
+
+func TestStructurallyEqual(t *testing.T) {
+	build := func(keys ...int) *Tree[int, int] {
+		tree := New[int, int]()
+		for _, k := range keys {
+			tree.Insert(k, k)
+		}
+		return tree
+	}
+
+	if !build(2, 1, 3).StructurallyEqual(build(2, 3, 1)) {
+		t.Fatalf("same shape from different insertion orders should be equal")
+	}
+	// 1,2,3 순서는 회전 뒤 같은 모양이 되지만, 값은 비교하지 않는다.
+	x, y := build(1, 2, 3), build(2, 1, 3)
+	y.Search(1).Value = 100
+	if !x.StructurallyEqual(y) {
+		t.Fatalf("StructurallyEqual should ignore values")
+	}
+	// 같은 키라도 모양이 다르면 다르다.
+	if build(1, 2, 3, 4).StructurallyEqual(build(4, 3, 2, 1)) {
+		t.Fatalf("different shapes should not be structurally equal")
+	}
+	if !New[int, int]().StructurallyEqual(New[int, int]()) {
+		t.Fatalf("empty trees should be structurally equal")
+	}
+}
@@ -0,0 +1,227 @@
+package rbtree
+
+import (
+	"cmp"
+	"iter"
+	"time"
+)
+
+// Expiring은 원소마다 만료 시각을 둘 수 있는 정렬 맵이다. 세션 ID를 키로 둔 세션 색인처럼 키 순서와
+// 만료 순서가 무관한 데이터에서 시간 기준으로 원소를 내보낼 때 쓴다.
+//
+// 원소는 키 순서 트리에, 만료 시각이 있는 원소는 (만료 시각, 키) 순서의 색인 트리에도 들어간다.
+// 만료는 두 가지로 일어난다. Get처럼 키 하나를 읽을 때 그 원소가 지났으면 그 자리에서 지우고(지연
+// 정리), ExpireBefore는 색인 트리에서 만료된 구간을 DeleteRange로 한 번에 잘라 낸 뒤 그 키들을 지운다.
+// 원소는 만료 시각까지 살아 있고 그보다 뒤의 시각부터 만료된 것으로 본다.
+//
+// 지연 정리는 읽은 키만 치우므로 Size에는 아직 쓸려 나가지 않은 만료 원소도 들어간다. 주기적으로
+// ExpireBefore(time.Now())를 부르면 메모리와 Size가 실제 살아 있는 원소 수에 가깝게 유지된다.
+// 여러 고루틴이 함께 쓰려면 호출하는 쪽에서 잠가야 한다. Get도 원소를 지울 수 있으므로 쓰기로 잠근다.
+type Expiring[K any, V any] struct {
+	entries   *Tree[K, expiringEntry[V]]
+	deadlines *Tree[deadlineKey[K], struct{}]
+	now       func() time.Time
+	onExpire  func(key K, value V)
+}
+
+// expiringEntry는 값과 만료 시각이다. deadline이 제로값이면 만료되지 않는다.
+type expiringEntry[V any] struct {
+	value    V
+	deadline time.Time
+}
+
+// deadlineKey는 색인 트리의 키다. bound는 같은 시각의 어떤 키보다도 앞서는 구간 경계를 만든다.
+type deadlineKey[K any] struct {
+	at    time.Time
+	key   K
+	bound bool
+}
+
+// NewExpiring은 빈 Expiring을 만든다. 현재 시각은 time.Now로 읽는다.
+func NewExpiring[K cmp.Ordered, V any]() *Expiring[K, V] {
+	return newExpiring[K, V](cmp.Compare[K])
+}
+
+// NewExpiringFunc는 less로 키 순서를 정하는 빈 Expiring을 만든다. less의 조건은 NewFunc와 같다.
+func NewExpiringFunc[K any, V any](less func(a, b K) bool) *Expiring[K, V] {
+	return newExpiring[K, V](compareFromLess(less))
+}
+
+func newExpiring[K any, V any](compare func(a, b K) int) *Expiring[K, V] {
+	return &Expiring[K, V]{
+		entries: &Tree[K, expiringEntry[V]]{compare: compare},
+		deadlines: &Tree[deadlineKey[K], struct{}]{compare: func(a, b deadlineKey[K]) int {
+			if c := a.at.Compare(b.at); c != 0 {
+				return c
+			}
+			switch {
+			case a.bound && b.bound:
+				return 0
+			case a.bound:
+				return -1
+			case b.bound:
+				return 1
+			}
+			return compare(a.key, b.key)
+		}},
+		now: time.Now,
+	}
+}
+
+// SetClock은 지연 정리가 현재 시각을 읽는 함수를 바꾼다. 테스트에서 시각을 고정하거나 단조 시계를
+// 쓸 때 쓴다. nil을 넘기면 time.Now로 돌아간다.
+func (e *Expiring[K, V]) SetClock(now func() time.Time) {
+	if now == nil {
+		now = time.Now
+	}
+	e.now = now
+}
+
+// OnExpire는 원소가 만료되어 지워질 때 호출할 콜백을 등록한다. 지연 정리와 ExpireBefore 모두에서
+// 불리며, Delete로 지운 원소에는 불리지 않는다. nil을 넘기면 해제한다. 콜백은 원소가 이미 빠진 뒤에
+// 호출된다.
+func (e *Expiring[K, V]) OnExpire(fn func(key K, value V)) {
+	e.onExpire = fn
+}
+
+// Set은 key에 value를 넣고 만료 시각을 deadline으로 정한다. deadline이 제로값이면 만료되지 않는다.
+// 키가 이미 있으면 값과 만료 시각을 모두 바꾼다.
+func (e *Expiring[K, V]) Set(key K, value V, deadline time.Time) {
+	if old, replaced := e.entries.Put(key, expiringEntry[V]{value: value, deadline: deadline}); replaced {
+		e.unindex(key, old.deadline)
+	}
+	e.index(key, deadline)
+}
+
+// SetTTL은 지금부터 ttl 뒤를 만료 시각으로 Set한다.
+func (e *Expiring[K, V]) SetTTL(key K, value V, ttl time.Duration) {
+	e.Set(key, value, e.now().Add(ttl))
+}
+
+// Extend는 살아 있는 key의 만료 시각만 deadline으로 바꾸고, 바꿨으면 true를 돌려준다. 세션을 쓸
+// 때마다 수명을 늘리는 식으로 쓴다. 이미 만료된 키는 지우고 false를 돌려준다.
+func (e *Expiring[K, V]) Extend(key K, deadline time.Time) bool {
+	entry, ok := e.live(key)
+	if !ok {
+		return false
+	}
+	e.unindex(key, entry.deadline)
+	entry.deadline = deadline
+	e.entries.Insert(key, entry)
+	e.index(key, deadline)
+	return true
+}
+
+// Get은 key의 값을 돌려준다. 키가 없거나 만료되었으면 false이며, 만료된 원소는 이때 지운다.
+func (e *Expiring[K, V]) Get(key K) (V, bool) {
+	entry, ok := e.live(key)
+	return entry.value, ok
+}
+
+// Deadline은 살아 있는 key의 만료 시각을 돌려준다. 만료되지 않는 원소면 제로값이다.
+func (e *Expiring[K, V]) Deadline(key K) (time.Time, bool) {
+	entry, ok := e.live(key)
+	return entry.deadline, ok
+}
+
+// Contains는 key가 있고 만료되지 않았는지 알려 준다. 만료된 원소는 이때 지운다.
+func (e *Expiring[K, V]) Contains(key K) bool {
+	_, ok := e.live(key)
+	return ok
+}
+
+// Delete는 key를 지우고, 있었으면 true를 돌려준다. 만료 여부와 상관없이 지우며 OnExpire는 부르지 않는다.
+func (e *Expiring[K, V]) Delete(key K) bool {
+	entry, ok := e.entries.Pop(key)
+	if ok {
+		e.unindex(key, entry.deadline)
+	}
+	return ok
+}
+
+// Size는 원소 수를 돌려준다. 아직 쓸려 나가지 않은 만료 원소도 센다.
+func (e *Expiring[K, V]) Size() int {
+	return e.entries.Size()
+}
+
+// NextDeadline은 가장 먼저 다가오는 만료 시각을 돌려준다. 다음 ExpireBefore를 언제 부를지 정할 때
+// 쓴다. 만료 시각이 있는 원소가 없으면 ok가 false다.
+func (e *Expiring[K, V]) NextDeadline() (time.Time, bool) {
+	if node := e.deadlines.first(); node != nil {
+		return node.Key.at, true
+	}
+	return time.Time{}, false
+}
+
+// ExpireBefore는 만료 시각이 now보다 앞선 원소를 모두 지우고 그 수를 돌려준다. 색인 트리에서
+// 만료된 구간을 DeleteRange로 잘라 내므로 지울 원소가 m개면 O(m log n)이다.
+func (e *Expiring[K, V]) ExpireBefore(now time.Time) int {
+	first := e.deadlines.first()
+	if first == nil {
+		return 0
+	}
+	lo, hi := first.Key, deadlineKey[K]{at: now, bound: true}
+	var keys []K
+	e.deadlines.AscendRange(lo, hi, func(d deadlineKey[K], _ struct{}) bool {
+		keys = append(keys, d.key)
+		return true
+	})
+	e.deadlines.DeleteRange(lo, hi)
+	for _, key := range keys {
+		entry, _ := e.entries.Pop(key)
+		if e.onExpire != nil {
+			e.onExpire(key, entry.value)
+		}
+	}
+	return len(keys)
+}
+
+// All은 만료되지 않은 원소를 키 오름차순으로 내놓는다. 순회 중에는 지우지 않으므로 만료된 원소는
+// 건너뛰기만 한다.
+func (e *Expiring[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		now := e.now()
+		for key, entry := range e.entries.All() {
+			if expired(entry.deadline, now) {
+				continue
+			}
+			if !yield(key, entry.value) {
+				return
+			}
+		}
+	}
+}
+
+// live는 key의 원소를 찾고, 만료되었으면 지운 뒤 false를 돌려준다.
+func (e *Expiring[K, V]) live(key K) (expiringEntry[V], bool) {
+	entry, ok := e.entries.Get(key)
+	if !ok {
+		return entry, false
+	}
+	if expired(entry.deadline, e.now()) {
+		e.entries.Delete(key)
+		e.unindex(key, entry.deadline)
+		if e.onExpire != nil {
+			e.onExpire(key, entry.value)
+		}
+		return expiringEntry[V]{}, false
+	}
+	return entry, true
+}
+
+func (e *Expiring[K, V]) index(key K, deadline time.Time) {
+	if !deadline.IsZero() {
+		e.deadlines.Insert(deadlineKey[K]{at: deadline, key: key}, struct{}{})
+	}
+}
+
+func (e *Expiring[K, V]) unindex(key K, deadline time.Time) {
+	if !deadline.IsZero() {
+		e.deadlines.Delete(deadlineKey[K]{at: deadline, key: key})
+	}
+}
+
+// expired는 deadline이 있고 now가 그 뒤인지 알려 준다.
+func expired(deadline, now time.Time) bool {
+	return !deadline.IsZero() && now.After(deadline)
+}
@@ -0,0 +1,122 @@
+package rbtree
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// fakeClock은 테스트가 직접 움직이는 시계다.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func TestExpiringLazy(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1000, 0)}
+	e := NewExpiring[string, int]()
+	e.SetClock(clock.now)
+	var expiredKeys []string
+	e.OnExpire(func(key string, _ int) { expiredKeys = append(expiredKeys, key) })
+
+	e.SetTTL("a", 1, time.Minute)
+	e.SetTTL("b", 2, 2*time.Minute)
+	e.Set("forever", 3, time.Time{})
+
+	clock.advance(time.Minute) // 만료 시각 그 순간까지는 살아 있다.
+	if v, ok := e.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get(a) at its deadline = %d, %v", v, ok)
+	}
+	clock.advance(time.Second)
+	if _, ok := e.Get("a"); ok {
+		t.Fatalf("Get(a) after its deadline should miss")
+	}
+	if e.Size() != 2 || len(expiredKeys) != 1 || expiredKeys[0] != "a" {
+		t.Fatalf("lazy expiry left size %d, callbacks %v", e.Size(), expiredKeys)
+	}
+	if _, ok := e.NextDeadline(); !ok {
+		t.Fatalf("b should still be indexed")
+	}
+
+	// Extend로 수명을 늘리면 원래 만료 시각이 지나도 살아 있다.
+	if !e.Extend("b", clock.t.Add(time.Hour)) {
+		t.Fatalf("Extend(b) failed")
+	}
+	clock.advance(10 * time.Minute)
+	if d, ok := e.Deadline("b"); !ok || !d.Equal(time.Unix(1000, 0).Add(time.Hour+time.Minute+time.Second)) {
+		t.Fatalf("Deadline(b) = %v, %v", d, ok)
+	}
+	if got := e.ExpireBefore(clock.t); got != 0 {
+		t.Fatalf("ExpireBefore removed %d extended entries", got)
+	}
+	if !e.Contains("forever") {
+		t.Fatalf("an entry without a deadline should never expire")
+	}
+	if e.Extend("missing", clock.t) {
+		t.Fatalf("Extend on a missing key should fail")
+	}
+}
+
+func TestExpiringExpireBefore(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	e := NewExpiring[int, string]()
+	e.SetClock(clock.now)
+	base := clock.t
+	// 키 순서와 만료 순서가 어긋나도록 키 i는 (i*7)%20초에 만료된다. 음수 키는 만료 시각이 같은 구간
+	// 경계보다 앞서 잘못 잘리지 않는지 본다.
+	for i := -10; i < 10; i++ {
+		e.Set(i, fmt.Sprint(i), base.Add(time.Duration((i*7+200)%20)*time.Second))
+	}
+	e.Set(100, "forever", time.Time{})
+
+	removed := map[int]bool{}
+	e.OnExpire(func(key int, value string) {
+		if value != fmt.Sprint(key) {
+			t.Fatalf("OnExpire(%d, %q) got the wrong value", key, value)
+		}
+		removed[key] = true
+	})
+	cutoff := base.Add(10 * time.Second)
+	if got := e.ExpireBefore(cutoff); got != 10 {
+		t.Fatalf("ExpireBefore removed %d entries, want 10", got)
+	}
+	for i := -10; i < 10; i++ {
+		deadline := base.Add(time.Duration((i*7+200)%20) * time.Second)
+		_, ok := e.entries.Get(i)
+		if want := !deadline.Before(cutoff); ok != want {
+			t.Fatalf("key %d with deadline %v present=%v after the sweep", i, deadline.Sub(base), ok)
+		}
+		if removed[i] == ok {
+			t.Fatalf("key %d: OnExpire called=%v but present=%v", i, removed[i], ok)
+		}
+	}
+	if next, _ := e.NextDeadline(); !next.Equal(cutoff) {
+		t.Fatalf("NextDeadline = %v, want %v", next.Sub(base), cutoff.Sub(base))
+	}
+
+	// 지연 정리는 All에서도 만료된 원소를 숨긴다.
+	clock.t = base.Add(15 * time.Second)
+	count := 0
+	for key := range e.All() {
+		if d, _ := e.entries.Get(key); expired(d.deadline, clock.t) {
+			t.Fatalf("All yielded expired key %d", key)
+		}
+		count++
+	}
+	if count != 6 {
+		t.Fatalf("All yielded %d live entries, want 6", count)
+	}
+
+	// Delete는 색인에서도 빠지며 OnExpire를 부르지 않는다.
+	clear(removed)
+	if !e.Delete(100) || !e.Delete(-10) || e.Delete(-10) {
+		t.Fatalf("Delete returned the wrong result")
+	}
+	e.ExpireBefore(base.Add(time.Hour))
+	if e.Size() != 0 || e.deadlines.Size() != 0 || removed[-10] || removed[100] {
+		t.Fatalf("after the final sweep size=%d index=%d removed=%v", e.Size(), e.deadlines.Size(), removed)
+	}
+	if _, ok := e.NextDeadline(); ok {
+		t.Fatalf("NextDeadline on an empty index should fail")
+	}
+}
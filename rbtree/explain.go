@@ -0,0 +1,86 @@
+package rbtree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FixupCase는 재균형 중 적용된 CLRS 케이스 하나를 설명한다. Op는 "insert" 또는 "delete",
+// Case는 CLRS 교재의 케이스 번호(삽입 1–3, 삭제 1–4)이며 Action은 그 케이스가 하는 일이다.
+// Keys에는 케이스에 관여한 노드의 역할과 키가 담긴다. 삽입은 node, parent, uncle, grandparent,
+// 삭제는 node(double black 자리), parent, sibling 순서이고 nil인 노드는 빠진다.
+type FixupCase[K any] struct {
+	Op     string
+	Case   int
+	Action string
+	Keys   []KeyRole[K]
+}
+
+// KeyRole은 FixupCase에 관여한 노드 하나의 역할과 키다.
+type KeyRole[K any] struct {
+	Role string
+	Key  K
+}
+
+// String은 "insert case 1: uncle red, recolor (node=5 parent=10 uncle=30 grandparent=20)" 꼴로 돌려준다.
+func (c FixupCase[K]) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s case %d: %s", c.Op, c.Case, c.Action)
+	for i, k := range c.Keys {
+		if i == 0 {
+			b.WriteString(" (")
+		} else {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%s=%v", k.Role, k.Key)
+	}
+	if len(c.Keys) > 0 {
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+// fixupCase는 insertFixup/deleteFixup의 케이스 하나를 나타낸다. roles는 explainCase에 넘기는 노드 순서다.
+type fixupCase struct {
+	op     string
+	n      int
+	action string
+	roles  []string
+}
+
+var (
+	insertRoles = []string{"node", "parent", "uncle", "grandparent"}
+	deleteRoles = []string{"node", "parent", "sibling"}
+
+	insertCase1 = &fixupCase{"insert", 1, "uncle red, recolor", insertRoles}
+	insertCase2 = &fixupCase{"insert", 2, "uncle black, inner child, rotate parent", insertRoles}
+	insertCase3 = &fixupCase{"insert", 3, "uncle black, outer child, recolor and rotate grandparent", insertRoles}
+
+	deleteCase1 = &fixupCase{"delete", 1, "sibling red, recolor and rotate parent", deleteRoles}
+	deleteCase2 = &fixupCase{"delete", 2, "sibling black with black children, recolor sibling", deleteRoles}
+	deleteCase3 = &fixupCase{"delete", 3, "far nephew black, rotate sibling", deleteRoles}
+	deleteCase4 = &fixupCase{"delete", 4, "far nephew red, recolor and rotate parent", deleteRoles}
+)
+
+// Explain은 재균형 케이스가 적용될 때마다 fn을 부르게 한다. 교재의 케이스 설명과 실제 데이터에서의
+// 동작을 맞춰 볼 때 쓰며, fn은 케이스를 적용하기 직전에 불린다. fn이 nil이면 설명을 멈춘다.
+// fn 안에서 트리를 고치면 안 된다.
+//
+//	tree.Explain(func(c rbtree.FixupCase[int]) { log.Println(c) })
+func (t *Tree[K, V]) Explain(fn func(FixupCase[K])) {
+	t.explain = fn
+}
+
+// explainCase는 Explain 콜백이 있을 때만 케이스 설명을 만들어 넘긴다.
+func (t *Tree[K, V]) explainCase(c *fixupCase, nodes ...*Node[K, V]) {
+	if t.explain == nil {
+		return
+	}
+	keys := make([]KeyRole[K], 0, len(nodes))
+	for i, node := range nodes {
+		if node != nil {
+			keys = append(keys, KeyRole[K]{Role: c.roles[i], Key: node.Key})
+		}
+	}
+	t.explain(FixupCase[K]{Op: c.op, Case: c.n, Action: c.action, Keys: keys})
+}
@@ -0,0 +1,70 @@
+package rbtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestExplainInsert(t *testing.T) {
+	tree := New[int, int]()
+	var got []string
+	tree.Explain(func(c FixupCase[int]) { got = append(got, c.String()) })
+	for _, k := range []int{10, 5, 7, 20} {
+		tree.Insert(k, k)
+	}
+	want := []string{
+		"insert case 2: uncle black, inner child, rotate parent (node=7 parent=5 grandparent=10)",
+		"insert case 3: uncle black, outer child, recolor and rotate grandparent (node=5 parent=7 grandparent=10)",
+		"insert case 1: uncle red, recolor (node=20 parent=10 uncle=5 grandparent=7)",
+	}
+	if !equalStrings(got, want) {
+		t.Fatalf("cases =\n%q\nwant\n%q", got, want)
+	}
+
+	tree.Explain(nil)
+	got = nil
+	tree.Insert(30, 30)
+	if len(got) != 0 {
+		t.Fatalf("detached callback should not be called, got %q", got)
+	}
+}
+
+func TestExplainDeleteCoversAllCases(t *testing.T) {
+	tree := New[int, int]()
+	rng := rand.New(rand.NewSource(1))
+	keys := rng.Perm(500)
+	for _, k := range keys {
+		tree.Insert(k, k)
+	}
+	seen := map[int]bool{}
+	tree.Explain(func(c FixupCase[int]) {
+		if c.Op != "delete" {
+			t.Fatalf("unexpected %s case during Delete", c.Op)
+		}
+		if len(c.Keys) == 0 || c.Keys[len(c.Keys)-1].Role != "sibling" {
+			t.Fatalf("delete case should name the sibling: %v", c)
+		}
+		seen[c.Case] = true
+	})
+	rng.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+	for _, k := range keys {
+		tree.Delete(k)
+	}
+	for n := 1; n <= 4; n++ {
+		if !seen[n] {
+			t.Fatalf("delete case %d never reported", n)
+		}
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+package rbtree
+
+import (
+	"expvar"
+	"fmt"
+)
+
+// expvarStats는 RegisterExpvar로 공개한 카운터 묶음이다. expvar.Int는 내부적으로 원자적
+// 연산을 쓰므로 /debug/vars 핸들러가 다른 고루틴에서 읽어도 안전하다.
+type expvarStats struct {
+	size    *expvar.Int
+	inserts *expvar.Int
+	deletes *expvar.Int
+}
+
+// RegisterExpvar는 트리 통계를 expvar에 name+".size", name+".inserts", name+".deletes"
+// 세 개의 Int 변수로 등록한다. 이후 Insert(새 키 삽입)와 Delete(실제 삭제)가 일어날 때마다
+// 값이 갱신된다. 같은 이름이 이미 expvar.Int로 등록되어 있다면 그 변수를 재사용하고,
+// 다른 타입으로 등록되어 있으면 expvar.Publish와 마찬가지로 panic한다.
+func (t *Tree[K, V]) RegisterExpvar(name string) {
+	t.vars = &expvarStats{
+		size:    expvarInt(name + ".size"),
+		inserts: expvarInt(name + ".inserts"),
+		deletes: expvarInt(name + ".deletes"),
+	}
+	t.vars.size.Set(int64(t.size))
+}
+
+// expvarInt는 name으로 등록된 expvar.Int를 찾고, 없으면 새로 만든다.
+func expvarInt(name string) *expvar.Int {
+	switch v := expvar.Get(name).(type) {
+	case nil:
+		return expvar.NewInt(name)
+	case *expvar.Int:
+		v.Set(0)
+		return v
+	default:
+		panic(fmt.Sprintf("rbtree: expvar %q is already registered as %T", name, v))
+	}
+}
+
+// inserted와 deleted는 s가 nil이면 아무 일도 하지 않으므로 호출부에서 등록 여부를 따지지 않아도 된다.
+func (s *expvarStats) inserted(size int) {
+	if s == nil {
+		return
+	}
+	s.inserts.Add(1)
+	s.size.Set(int64(size))
+}
+
+func (s *expvarStats) deleted(size int) {
+	if s == nil {
+		return
+	}
+	s.deletes.Add(1)
+	s.size.Set(int64(size))
+}
+
+// resized는 개별 삽입/삭제 없이 크기만 바뀌었을 때(Clear 등) size 변수를 맞춘다.
+func (s *expvarStats) resized(size int) {
+	if s == nil {
+		return
+	}
+	s.size.Set(int64(size))
+}
+
+// insertedMany는 일괄 삽입으로 새 키가 n개 늘었을 때 쓴다.
+func (s *expvarStats) insertedMany(n, size int) {
+	if s == nil {
+		return
+	}
+	s.inserts.Add(int64(n))
+	s.size.Set(int64(size))
+}
+
+// deletedMany는 구간 삭제처럼 키 n개가 한꺼번에 빠졌을 때 쓴다.
+func (s *expvarStats) deletedMany(n, size int) {
+	if s == nil {
+		return
+	}
+	s.deletes.Add(int64(n))
+	s.size.Set(int64(size))
+}
@@ -0,0 +1,35 @@
+package rbtree
+
+import (
+	"expvar"
+	"testing"
+)
+
+func TestRegisterExpvar(t *testing.T) {
+	tree := New[int, string]()
+	tree.Insert(1, "one")
+	tree.RegisterExpvar("rbtree_test_tree")
+
+	for i := 2; i <= 5; i++ {
+		tree.Insert(i, "v")
+	}
+	tree.Insert(3, "updated") // 기존 키 갱신은 삽입으로 세지 않는다.
+	tree.Delete(2)
+	tree.Delete(4)
+	tree.Delete(42) // 없는 키는 삭제로 세지 않는다.
+
+	want := map[string]int64{
+		"rbtree_test_tree.size":    3,
+		"rbtree_test_tree.inserts": 4,
+		"rbtree_test_tree.deletes": 2,
+	}
+	for name, expected := range want {
+		v, ok := expvar.Get(name).(*expvar.Int)
+		if !ok {
+			t.Fatalf("expvar %q not registered", name)
+		}
+		if got := v.Value(); got != expected {
+			t.Fatalf("expvar %q expected %d got %d", name, expected, got)
+		}
+	}
+}
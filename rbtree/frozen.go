@@ -0,0 +1,135 @@
+package rbtree
+
+import (
+	"errors"
+	"iter"
+)
+
+// ErrFrozen은 Frozen을 고치려 할 때 돌려준다.
+var ErrFrozen = errors.New("rbtree: write to a frozen tree")
+
+// Frozen은 다시는 바뀌지 않는 트리의 읽기 전용 뷰다. 고치는 메서드는 아무것도 하지 않고 ErrFrozen을
+// 돌려주며, 읽기 메서드는 노드 포인터 대신 키와 값의 복사본만 돌려주므로 받은 쪽이 무엇을 하든 내용이
+// 바뀌지 않는다. 그래서 다른 패키지나 플러그인에 색인을 넘길 때, 여러 고루틴이 잠금 없이 함께 읽게
+// 할 때 쓴다.
+//
+// 값이 포인터나 슬라이스, 맵이면 그 값이 가리키는 내용까지 얼리지는 않는다. 필요하면 CloneFunc로
+// 깊게 복사한 트리를 얼린다.
+type Frozen[K any, V any] struct {
+	t *Tree[K, V]
+}
+
+// Freeze는 현재 내용을 담은 Frozen을 O(1)에 돌려준다. 내부적으로 Snapshot과 노드를 공유하므로
+// 원본은 Freeze 뒤 첫 쓰기에서 한 번 O(n) 복사를 치른다. 원본을 여러 고루틴이 함께 쓰고 있다면
+// Freeze는 쓰기와 같은 잠금 아래에서 부른다. 돌려받은 Frozen은 그 뒤로 잠금 없이 읽어도 된다.
+func (t *Tree[K, V]) Freeze() *Frozen[K, V] {
+	return &Frozen[K, V]{t: t.Snapshot()}
+}
+
+// Thaw는 얼린 내용을 고칠 수 있는 새 트리로 O(n)에 복사한다.
+func (f *Frozen[K, V]) Thaw() *Tree[K, V] {
+	return f.t.Clone()
+}
+
+// Size는 원소 수를 돌려준다.
+func (f *Frozen[K, V]) Size() int {
+	return f.t.Size()
+}
+
+// Get은 키에 대응하는 값을 돌려준다. 없으면 V의 제로값과 false다.
+func (f *Frozen[K, V]) Get(key K) (V, bool) {
+	return f.t.Get(key)
+}
+
+// Contains는 키가 있는지 알려 준다.
+func (f *Frozen[K, V]) Contains(key K) bool {
+	return f.t.Contains(key)
+}
+
+// Min은 가장 작은 원소를 돌려준다. 비었으면 ok가 false다.
+func (f *Frozen[K, V]) Min() (key K, value V, ok bool) {
+	return entryOf(f.t.Min())
+}
+
+// Max는 가장 큰 원소를 돌려준다. 비었으면 ok가 false다.
+func (f *Frozen[K, V]) Max() (key K, value V, ok bool) {
+	return entryOf(f.t.Max())
+}
+
+// Floor는 key 이하인 가장 큰 원소를 돌려준다. 없으면 ok가 false다.
+func (f *Frozen[K, V]) Floor(key K) (K, V, bool) {
+	return entryOf(f.t.Floor(key))
+}
+
+// Ceiling은 key 이상인 가장 작은 원소를 돌려준다. 없으면 ok가 false다.
+func (f *Frozen[K, V]) Ceiling(key K) (K, V, bool) {
+	return entryOf(f.t.Ceiling(key))
+}
+
+// Rank는 key보다 작은 원소 수를 돌려준다.
+func (f *Frozen[K, V]) Rank(key K) int {
+	return f.t.Rank(key)
+}
+
+// Select는 0부터 센 i번째 원소를 돌려준다. 범위를 벗어나면 ok가 false다.
+func (f *Frozen[K, V]) Select(i int) (key K, value V, ok bool) {
+	return f.t.Select(i)
+}
+
+// Keys는 모든 키를 오름차순으로 담은 새 슬라이스를 돌려준다.
+func (f *Frozen[K, V]) Keys() []K {
+	return f.t.Keys()
+}
+
+// Values는 모든 값을 키 오름차순으로 담은 새 슬라이스를 돌려준다.
+func (f *Frozen[K, V]) Values() []V {
+	return f.t.Values()
+}
+
+// All은 모든 원소를 키 오름차순으로 내놓는다.
+func (f *Frozen[K, V]) All() iter.Seq2[K, V] {
+	return f.t.All()
+}
+
+// Backward는 모든 원소를 키 내림차순으로 내놓는다.
+func (f *Frozen[K, V]) Backward() iter.Seq2[K, V] {
+	return f.t.Backward()
+}
+
+// AscendRange는 Tree.AscendRange와 같다.
+func (f *Frozen[K, V]) AscendRange(lo, hi K, fn func(key K, value V) bool) {
+	f.t.AscendRange(lo, hi, fn)
+}
+
+// DescendRange는 Tree.DescendRange와 같다.
+func (f *Frozen[K, V]) DescendRange(hi, lo K, fn func(key K, value V) bool) {
+	f.t.DescendRange(hi, lo, fn)
+}
+
+// Insert는 아무것도 하지 않고 ErrFrozen을 돌려준다.
+func (f *Frozen[K, V]) Insert(key K, value V) error {
+	return ErrFrozen
+}
+
+// Put은 아무것도 하지 않고 ErrFrozen을 돌려준다.
+func (f *Frozen[K, V]) Put(key K, value V) error {
+	return ErrFrozen
+}
+
+// Delete는 아무것도 하지 않고 ErrFrozen을 돌려준다.
+func (f *Frozen[K, V]) Delete(key K) error {
+	return ErrFrozen
+}
+
+// Clear는 아무것도 하지 않고 ErrFrozen을 돌려준다.
+func (f *Frozen[K, V]) Clear() error {
+	return ErrFrozen
+}
+
+// entryOf는 노드의 키와 값을 꺼낸다. node가 nil이면 ok가 false다.
+func entryOf[K any, V any](node *Node[K, V]) (key K, value V, ok bool) {
+	if node == nil {
+		return key, value, false
+	}
+	return node.Key, node.Value, true
+}
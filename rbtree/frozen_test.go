@@ -0,0 +1,63 @@
+package rbtree
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	tree := New[int, string]()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, "v")
+	}
+	frozen := tree.Freeze()
+
+	// 원본을 고쳐도 얼린 내용은 그대로다.
+	tree.Delete(5)
+	tree.Put(6, "changed")
+	tree.Insert(1000, "new")
+	if frozen.Size() != 100 || !frozen.Contains(5) || frozen.Contains(1000) {
+		t.Fatalf("frozen view changed with the original: size %d", frozen.Size())
+	}
+	if v, _ := frozen.Get(6); v != "v" {
+		t.Fatalf("Get(6) = %q, want the frozen value", v)
+	}
+	if k, _, ok := frozen.Max(); !ok || k != 99 {
+		t.Fatalf("Max = %d, %v", k, ok)
+	}
+	if k, _, ok := frozen.Floor(-1); ok {
+		t.Fatalf("Floor(-1) = %d, want none", k)
+	}
+
+	// 여러 고루틴이 잠금 없이 함께 읽는다. go test -race로 돌리면 겹치는 쓰기가 없음을 확인한다.
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n := 0
+			for range frozen.All() {
+				n++
+			}
+			if n != 100 {
+				t.Errorf("walked %d elements, want 100", n)
+			}
+		}()
+	}
+	tree.Insert(2000, "concurrent write to the original")
+	wg.Wait()
+
+	if err := frozen.Insert(3000, "x"); !errors.Is(err, ErrFrozen) {
+		t.Fatalf("Insert = %v, want ErrFrozen", err)
+	}
+	if err := frozen.Delete(1); !errors.Is(err, ErrFrozen) || !frozen.Contains(1) {
+		t.Fatalf("Delete = %v, want ErrFrozen and no change", err)
+	}
+
+	thawed := frozen.Thaw()
+	thawed.Delete(0)
+	if frozen.Size() != 100 || thawed.Size() != 99 {
+		t.Fatalf("Thaw should return an independent copy")
+	}
+}
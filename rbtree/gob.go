@@ -0,0 +1,30 @@
+package rbtree
+
+// GobEncode는 gob.GobEncoder를 구현해 트리를 net/rpc나 gob 캐시로 보낼 수 있게 한다.
+// 형식은 MarshalBinary와 같다(체크섬이 붙은 정렬된 원소 목록). 노드 구조는 보내지 않고
+// 받는 쪽에서 회전 없이 균형 트리를 다시 만든다.
+func (t *Tree[K, V]) GobEncode() ([]byte, error) {
+	return t.MarshalBinary()
+}
+
+// GobDecode는 gob.GobDecoder를 구현한다. 동작은 UnmarshalBinary와 같으므로 gob이 만든
+// 제로값 Tree에도 디코딩할 수 있다.
+func (t *Tree[K, V]) GobDecode(data []byte) error {
+	return t.UnmarshalBinary(data)
+}
+
+// GobEncode는 노드의 키와 값만 인코딩한다. Node는 Parent 포인터로 부모와 서로를 가리키므로
+// 필드를 그대로 gob에 넘기면 끝없이 재귀한다. 디코딩한 노드는 어느 트리에도 속하지 않는다.
+func (n *Node[K, V]) GobEncode() ([]byte, error) {
+	return encodeGob(Pair[K, V]{n.Key, n.Value})
+}
+
+// GobDecode는 GobEncode가 만든 키와 값을 n에 채운다. 색과 포인터는 비워 둔다.
+func (n *Node[K, V]) GobDecode(data []byte) error {
+	p, err := DecodeGob[Pair[K, V]](data)
+	if err != nil {
+		return err
+	}
+	*n = Node[K, V]{Key: p.Key, Value: p.Value}
+	return nil
+}
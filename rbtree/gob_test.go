@@ -0,0 +1,51 @@
+package rbtree
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+func TestGobRoundTrip(t *testing.T) {
+	type cache struct {
+		Name  string
+		Index *Tree[string, int]
+	}
+	tree := New[string, int]()
+	for i, k := range []string{"m", "c", "x", "a"} {
+		tree.Insert(k, i)
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(cache{Name: "idx", Index: tree}); err != nil {
+		t.Fatalf("encode failed: %v", err)
+	}
+	var out cache
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if out.Name != "idx" || !out.Index.Equal(tree, func(a, b int) bool { return a == b }) {
+		t.Fatalf("gob round trip changed contents")
+	}
+	out.Index.Insert("b", 9)
+	assertRBProperties(t, out.Index)
+}
+
+func TestGobNode(t *testing.T) {
+	tree := New[int, string]()
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, "v")
+	}
+	var buf bytes.Buffer
+	// Parent 포인터를 따라 재귀하지 않고 키와 값만 보내야 한다.
+	if err := gob.NewEncoder(&buf).Encode(tree.Search(5)); err != nil {
+		t.Fatalf("encode failed: %v", err)
+	}
+	var n Node[int, string]
+	if err := gob.NewDecoder(&buf).Decode(&n); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if n.Key != 5 || n.Value != "v" || n.Parent != nil {
+		t.Fatalf("decoded node should carry only key and value, got %+v", n)
+	}
+}
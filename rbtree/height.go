@@ -0,0 +1,24 @@
+package rbtree
+
+// Height는 루트에서 가장 깊은 잎까지 지나는 노드 수를 돌려준다. 빈 트리는 0, 원소 하나면 1이다.
+// 톰스톤 노드도 구조의 일부이므로 센다. 레드블랙 규칙 덕분에 노드 n개인 트리는 2·log2(n+1)을
+// 넘지 않으므로, 운영 중 헬스 체크에서 이 상한을 확인하는 데 쓸 수 있다. 모든 노드를 한 번씩
+// 보므로 O(n)이다.
+func (t *Tree[K, V]) Height() int {
+	return heightOf(t.root)
+}
+
+// BlackHeight는 루트에서 잎까지 지나는 검정 노드 수(루트 포함, nil 잎 제외)를 돌려준다.
+// 레드블랙 규칙 (4) 덕분에 어느 경로로 세도 같으므로 왼쪽 척추만 따라가 O(log n)에 센다.
+// 빈 트리는 0이다.
+func (t *Tree[K, V]) BlackHeight() int {
+	return blackHeightOf(t.root)
+}
+
+// heightOf는 node 서브트리의 높이다. 재귀 깊이는 트리 높이와 같으므로 O(log n)이다.
+func heightOf[K any, V any](node *Node[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return 1 + max(heightOf(node.Left), heightOf(node.Right))
+}
@@ -0,0 +1,29 @@
+package rbtree
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHeight(t *testing.T) {
+	tree := New[int, int]()
+	if tree.Height() != 0 || tree.BlackHeight() != 0 {
+		t.Fatalf("empty tree: height %d, black height %d", tree.Height(), tree.BlackHeight())
+	}
+	tree.Insert(1, 1)
+	if tree.Height() != 1 || tree.BlackHeight() != 1 {
+		t.Fatalf("single node: height %d, black height %d", tree.Height(), tree.BlackHeight())
+	}
+
+	for i := 2; i <= 10000; i++ {
+		tree.Insert(i, i)
+	}
+	n := tree.Size()
+	bound := 2 * math.Log2(float64(n+1))
+	if h := tree.Height(); float64(h) > bound || h < int(math.Ceil(math.Log2(float64(n+1)))) {
+		t.Fatalf("height %d outside [log2(n+1), %.1f] for %d nodes", h, bound, n)
+	}
+	if bh := tree.BlackHeight(); bh > tree.Height() || 1<<bh-1 > n {
+		t.Fatalf("black height %d inconsistent with height %d and size %d", bh, tree.Height(), n)
+	}
+}
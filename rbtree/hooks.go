@@ -0,0 +1,116 @@
+package rbtree
+
+// OnInsert는 새 키가 들어온 직후 호출할 콜백을 등록한다. old는 늘 V의 제로값이고 new가 들어간 값이다.
+// 톰스톤을 되살린 삽입도 새 키로 본다. nil을 넘기면 해제한다.
+//
+// OnInsert, OnUpdate, OnDelete는 값을 기준으로 한 역인덱스처럼 트리와 함께 유지해야 하는 보조
+// 구조를 호출부마다 감싸지 않고 맞춰 두는 데 쓴다. 콜백은 변경이 끝난 뒤 불리며, 콜백 안에서
+// 트리를 바꾸면 안 된다. Clone, Snapshot, Split 결과에는 콜백이 따라가지 않는다.
+func (t *Tree[K, V]) OnInsert(fn func(key K, old, new V)) {
+	t.onInsert = fn
+}
+
+// OnUpdate는 이미 있던 키의 값이 바뀐 직후 호출할 콜백을 등록한다. nil을 넘기면 해제한다.
+// Insert·Put·Update·CompareAndSwap·MutableCursor.SetValue·InsertMany가 기존 키에 값을 쓰면
+// 새 값이 이전 값과 같아도 호출된다.
+func (t *Tree[K, V]) OnUpdate(fn func(key K, old, new V)) {
+	t.onUpdate = fn
+}
+
+// OnDelete는 키가 빠진 직후 호출할 콜백을 등록한다. old는 지워진 값이고 new는 V의 제로값이다.
+// nil을 넘기면 해제한다. Delete·Pop·DeleteIf·DeleteRange·크기 제한에 따른 내보내기처럼 키가
+// 빠지는 모든 경로에서 불리며, Clear·Split·Join처럼 트리를 통째로 비우는 연산은 남아 있던 원소마다
+// 키 순서로 부른다. 값이 제로값인 원소를 정리하는 Compact도 지운 원소마다 부른다.
+func (t *Tree[K, V]) OnDelete(fn func(key K, old, new V)) {
+	t.onDelete = fn
+}
+
+// afterInsert, afterUpdate, afterDelete는 변경이 끝난 뒤 횟수를 세고 저널과 콜백에 알린다.
+// 변경 경로는 모두 이 셋 중 하나를 거치므로, 변경을 지켜보는 기능은 여기에만 붙이면 된다.
+func (t *Tree[K, V]) afterInsert(key K, value V) {
+	t.metrics.Inserts++
+	t.logMutation("insert", key)
+	t.journalInsert(key, value)
+	if t.lru != nil {
+		t.lru.push(key)
+	}
+	if t.onInsert != nil {
+		var zero V
+		t.onInsert(key, zero, value)
+	}
+}
+
+func (t *Tree[K, V]) afterUpdate(key K, old, new V) {
+	t.metrics.Updates++
+	t.logMutation("update", key)
+	t.journalInsert(key, new)
+	if t.lru != nil {
+		t.lru.touch(key)
+	}
+	if t.onUpdate != nil {
+		t.onUpdate(key, old, new)
+	}
+}
+
+func (t *Tree[K, V]) afterDelete(key K, old V) {
+	t.metrics.Deletes++
+	t.notifyDelete(key, old)
+}
+
+func (t *Tree[K, V]) notifyDelete(key K, old V) {
+	t.logMutation("delete", key)
+	t.journalDelete(key)
+	if t.lru != nil {
+		t.lru.remove(key)
+	}
+	if t.onDelete != nil {
+		var zero V
+		t.onDelete(key, old, zero)
+	}
+}
+
+// watchesDeletes는 원소를 뭉텅이로 버리는 연산이 버린 원소를 하나씩 훑어야 하는지 알려 준다.
+func (t *Tree[K, V]) watchesDeletes() bool {
+	return t.journal != nil || t.onDelete != nil || t.lru != nil || t.log.mutations()
+}
+
+// afterDeleteAll은 살아 있는 원소 n개를 담은 채 떼어 낸 서브트리 root에 대해 afterDelete와 같은
+// 일을 한다. 지켜보는 쪽이 없으면 서브트리를 훑지 않는다. root의 Parent는 nil이어야 한다.
+func (t *Tree[K, V]) afterDeleteAll(root *Node[K, V], n int) {
+	t.metrics.Deletes += uint64(n)
+	if root == nil || !t.watchesDeletes() {
+		return
+	}
+	for node := minimum(root); node != nil; node = successor(node) {
+		if !node.deleted {
+			t.notifyDelete(node.Key, node.Value)
+		}
+	}
+}
+
+// pendingClear는 트리를 통째로 비우기 전에 OnDelete에 넘길 원소를 키 순서로 모은다. 노드를 다른
+// 트리로 옮기는 Split·Join도 콜백은 옮긴 뒤에 불러야 하므로 미리 값을 잡아 둔다. 콜백이 없으면 nil이다.
+func (t *Tree[K, V]) pendingClear() []Pair[K, V] {
+	if t.onDelete == nil {
+		return nil
+	}
+	pairs := make([]Pair[K, V], 0, t.size)
+	for node := t.first(); node != nil; node = nextLive(node) {
+		pairs = append(pairs, Pair[K, V]{node.Key, node.Value})
+	}
+	return pairs
+}
+
+// afterClear는 살아 있는 원소 removed개를 버리며 트리를 통째로 비운 뒤에 부른다. 저널에는 원소별 삭제 대신 clear 한 줄을 남기고,
+// OnDelete 콜백은 pendingClear로 모아 둔 원소마다 부른다.
+func (t *Tree[K, V]) afterClear(removed int, pairs []Pair[K, V]) {
+	t.logClear(removed)
+	t.journalClear()
+	if t.lru != nil {
+		t.lru.reset()
+	}
+	var zero V
+	for _, p := range pairs {
+		t.onDelete(p.Key, p.Value, zero)
+	}
+}
@@ -0,0 +1,120 @@
+package rbtree
+
+import (
+	"fmt"
+	"testing"
+)
+
+// reverseIndex는 값 → 키 역인덱스로, 훅만으로 트리와 맞춰 두는지 확인하는 데 쓴다.
+type reverseIndex map[string]int
+
+func (idx reverseIndex) attach(t *testing.T, tree *Tree[int, string]) {
+	tree.OnInsert(func(key int, old, new string) {
+		if old != "" {
+			t.Fatalf("OnInsert old = %q, want zero value", old)
+		}
+		idx[new] = key
+	})
+	tree.OnUpdate(func(key int, old, new string) {
+		if idx[old] != key {
+			t.Fatalf("OnUpdate old value %q was not indexed for key %d", old, key)
+		}
+		delete(idx, old)
+		idx[new] = key
+	})
+	tree.OnDelete(func(key int, old, new string) {
+		if new != "" {
+			t.Fatalf("OnDelete new = %q, want zero value", new)
+		}
+		delete(idx, old)
+	})
+}
+
+func (idx reverseIndex) check(t *testing.T, tree *Tree[int, string]) {
+	t.Helper()
+	if len(idx) != tree.Size() {
+		t.Fatalf("index has %d entries, tree has %d", len(idx), tree.Size())
+	}
+	tree.InOrder(func(key int, value string) {
+		if idx[value] != key {
+			t.Fatalf("index[%q] = %d, want %d", value, idx[value], key)
+		}
+	})
+}
+
+func TestHooksKeepReverseIndex(t *testing.T) {
+	tree := NewBounded[int, string](20)
+	idx := reverseIndex{}
+	idx.attach(t, tree)
+
+	for i := 0; i < 30; i++ {
+		tree.Insert(i, fmt.Sprint("v", i)) // 20개를 넘으면 작은 키가 밀려난다.
+	}
+	idx.check(t, tree)
+
+	tree.Put(15, "fifteen")
+	tree.Update(16, func(old string, _ bool) (string, bool) { return old + "!", true })
+	CompareAndSwap(tree, 17, "v17", "seventeen")
+	tree.Pop(18)
+	tree.DeleteIf(func(key int, _ string) bool { return key%5 == 0 })
+	tree.DeleteRange(20, 23)
+	tree.InsertMany([]Pair[int, string]{{Key: 24, Value: "x24"}, {Key: 40, Value: "x40"}})
+	c := tree.MutableCursor()
+	c.Next()
+	c.SetValue("first")
+	idx.check(t, tree)
+
+	left, right := tree.Split(25)
+	if len(idx) != 0 {
+		t.Fatalf("Split should report every element as deleted from the source tree, %d left", len(idx))
+	}
+	idx.attach(t, left)
+	left.InOrder(func(key int, value string) { idx[value] = key })
+	joined := Join(left, right)
+	if len(idx) != 0 || joined.Size() == 0 {
+		t.Fatalf("Join should report the left tree's elements as deleted, %d left", len(idx))
+	}
+
+	idx.attach(t, joined)
+	joined.InOrder(func(key int, value string) { idx[value] = key })
+	joined.Clear()
+	idx.check(t, joined)
+}
+
+func TestHooksTombstones(t *testing.T) {
+	tree := NewWithTombstones[int, string]()
+	idx := reverseIndex{}
+	idx.attach(t, tree)
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, fmt.Sprint("v", i))
+	}
+	tree.Delete(3)
+	tree.Insert(3, "again") // 톰스톤을 되살리는 삽입도 OnInsert다.
+	tree.Delete(4)
+	tree.Compact(func(v string) bool { return v == "v5" })
+	idx.check(t, tree)
+}
+
+// InsertMany가 트리를 다시 엮는 경로에서도 콜백은 바뀐 트리를 봐야 한다.
+func TestHooksSeeInsertManyResult(t *testing.T) {
+	tree := New[int, string]()
+	tree.Insert(1, "one")
+	checked := 0
+	tree.OnInsert(func(key int, _, new string) {
+		if v, ok := tree.Get(key); !ok || v != new {
+			t.Fatalf("OnInsert(%d): Get = %q, %v", key, v, ok)
+		}
+		checked++
+	})
+	tree.OnUpdate(func(key int, _, new string) {
+		if v, ok := tree.Get(key); !ok || v != new {
+			t.Fatalf("OnUpdate(%d): Get = %q, %v", key, v, ok)
+		}
+		checked++
+	})
+	// 묶음이 트리보다 크므로 다시 엮는 경로를 탄다.
+	tree.InsertMany([]Pair[int, string]{{1, "uno"}, {2, "two"}, {3, "three"}})
+	if checked != 3 {
+		t.Fatalf("expected 3 hook calls, got %d", checked)
+	}
+}
@@ -0,0 +1,403 @@
+package rbtree
+
+import "iter"
+
+// Hook은 침습형(intrusive) 트리에 들어갈 원소 구조체에 임베드하는 링크다. 트리가 노드를 따로
+// 할당하지 않고 원소 안의 Hook으로 원소끼리 직접 이으므로, 원소 하나에 할당이 한 번뿐이다.
+// 타이머 휠이나 스케줄러처럼 원소를 이미 힙에 만들어 두고 넣었다 뺐다 하는 곳에 쓴다.
+//
+//	type Timer struct {
+//		rbtree.Hook[*Timer]
+//		When time.Time
+//	}
+//
+//	timers := rbtree.NewIntrusive(func(a, b *Timer) int { return a.When.Compare(b.When) })
+//	timers.Insert(t)
+//
+// Hook의 필드는 트리만 고친다. 원소가 트리에 들어 있는 동안 비교에 쓰는 필드를 바꾸면 안 된다.
+type Hook[T any] struct {
+	parent, left, right T
+	red                 bool
+	head                *intrusiveHead[T] // 원소가 들어 있는 트리. 트리 밖이면 nil이다.
+}
+
+// RBHook은 h 자신을 돌려준다. Hook을 임베드한 구조체의 포인터는 이 메서드를 물려받아 Intrusive를 만족한다.
+func (h *Hook[T]) RBHook() *Hook[T] {
+	return h
+}
+
+// Intrusive는 침습형 트리에 넣을 수 있는 원소 타입이다. 보통 Hook[T]를 임베드한 구조체의 포인터다.
+type Intrusive[T any] interface {
+	comparable
+	RBHook() *Hook[T]
+}
+
+// intrusiveHead는 트리의 루트와 원소 수다. Hook이 자신이 속한 트리를 이 주소로 가리킨다.
+type intrusiveHead[T any] struct {
+	root T
+	size int
+}
+
+// IntrusiveTree는 원소 안의 Hook으로 엮는 레드블랙 트리다. 원소 순서는 NewIntrusive에 넘긴 비교 함수가
+// 정하며, 같다고 비교되는 원소는 하나만 들어간다. 원소를 알고 있으면 찾지 않고 바로 Remove할 수 있다.
+// 제로값은 쓸 수 없으므로 NewIntrusive로 만든다.
+type IntrusiveTree[T Intrusive[T]] struct {
+	head    intrusiveHead[T]
+	compare func(a, b T) int
+}
+
+// NewIntrusive는 compare로 원소 순서를 정하는 빈 침습형 트리를 만든다. compare는 a < b이면 음수,
+// a == b이면 0, a > b이면 양수를 돌려줘야 한다.
+func NewIntrusive[T Intrusive[T]](compare func(a, b T) int) *IntrusiveTree[T] {
+	return &IntrusiveTree[T]{compare: compare}
+}
+
+// Len은 원소 수를 돌려준다.
+func (t *IntrusiveTree[T]) Len() int {
+	return t.head.size
+}
+
+// Contains는 x가 이 트리에 들어 있는지 O(1)에 알려 준다.
+func (t *IntrusiveTree[T]) Contains(x T) bool {
+	return !isZero(x) && x.RBHook().head == &t.head
+}
+
+// Insert는 x를 트리에 넣고 true를 돌려준다. 같다고 비교되는 원소가 이미 있으면 넣지 않고 false를
+// 돌려준다. x가 이미 어떤 트리에 들어 있으면 panic한다.
+func (t *IntrusiveTree[T]) Insert(x T) bool {
+	h := x.RBHook()
+	if h.head != nil {
+		panic("rbtree: Insert of an element that is already in a tree")
+	}
+	var parent T
+	cur, c := t.head.root, 0
+	for !isZero(cur) {
+		parent = cur
+		c = t.compare(x, cur)
+		switch {
+		case c < 0:
+			cur = cur.RBHook().left
+		case c > 0:
+			cur = cur.RBHook().right
+		default:
+			return false
+		}
+	}
+	*h = Hook[T]{parent: parent, red: true, head: &t.head}
+	switch {
+	case isZero(parent):
+		t.head.root = x
+	case c < 0:
+		parent.RBHook().left = x
+	default:
+		parent.RBHook().right = x
+	}
+	t.head.size++
+	t.insertFixup(x)
+	return true
+}
+
+// insertFixup은 Tree.insertFixup과 같은 CLRS 보정이다.
+func (t *IntrusiveTree[T]) insertFixup(x T) {
+	for {
+		p := x.RBHook().parent
+		if !isRed(p) {
+			break
+		}
+		g := p.RBHook().parent // 부모가 빨강이므로 루트가 아니고, 조부모가 있다.
+		if p == g.RBHook().left {
+			if u := g.RBHook().right; isRed(u) {
+				// Case 1: 삼촌이 빨강이면 색만 바꾸고 조부모로 올라간다.
+				p.RBHook().red, u.RBHook().red, g.RBHook().red = false, false, true
+				x = g
+				continue
+			}
+			if x == p.RBHook().right {
+				// Case 2: 꺾인 모양이면 부모에서 돌려 Case 3으로 만든다.
+				x = p
+				t.rotateLeft(x)
+				p = x.RBHook().parent
+			}
+			// Case 3: 부모와 조부모 색을 바꾸고 조부모에서 돈다.
+			p.RBHook().red, g.RBHook().red = false, true
+			t.rotateRight(g)
+		} else {
+			if u := g.RBHook().left; isRed(u) {
+				p.RBHook().red, u.RBHook().red, g.RBHook().red = false, false, true
+				x = g
+				continue
+			}
+			if x == p.RBHook().left {
+				x = p
+				t.rotateRight(x)
+				p = x.RBHook().parent
+			}
+			p.RBHook().red, g.RBHook().red = false, true
+			t.rotateLeft(g)
+		}
+	}
+	t.head.root.RBHook().red = false
+}
+
+// Remove는 x를 트리에서 떼어 내고 true를 돌려준다. 비교 함수로 찾지 않고 x의 Hook에서 바로 시작하므로
+// 같다고 비교되는 다른 원소와 헷갈리지 않는다. x가 이 트리에 없으면 false를 돌려준다.
+func (t *IntrusiveTree[T]) Remove(x T) bool {
+	if !t.Contains(x) {
+		return false
+	}
+	z := x.RBHook()
+	var child, parent T
+	removedRed := z.red
+	switch {
+	case isZero(z.left):
+		child, parent = z.right, z.parent
+		t.transplant(x, z.right)
+	case isZero(z.right):
+		child, parent = z.left, z.parent
+		t.transplant(x, z.left)
+	default:
+		// 자식이 둘이면 후속 원소 y를 x 자리로 옮긴다.
+		y := z.right
+		for !isZero(y.RBHook().left) {
+			y = y.RBHook().left
+		}
+		yh := y.RBHook()
+		removedRed = yh.red
+		child = yh.right
+		if yh.parent == x {
+			parent = y
+		} else {
+			parent = yh.parent
+			t.transplant(y, yh.right)
+			yh.right = z.right
+			yh.right.RBHook().parent = y
+		}
+		t.transplant(x, y)
+		yh.left = z.left
+		yh.left.RBHook().parent = y
+		yh.red = z.red
+	}
+	if !removedRed {
+		t.deleteFixup(child, parent)
+	}
+	*z = Hook[T]{}
+	t.head.size--
+	return true
+}
+
+// deleteFixup은 Tree.deleteFixup과 같은 CLRS 보정이다. x는 비어 있을 수 있으므로 부모를 따로 받는다.
+func (t *IntrusiveTree[T]) deleteFixup(x, parent T) {
+	for x != t.head.root && !isRed(x) {
+		ph := parent.RBHook()
+		if x == ph.left {
+			w := ph.right
+			if isRed(w) {
+				// Case 1: 형제가 빨강이면 돌려서 검정 형제를 만든다.
+				w.RBHook().red, ph.red = false, true
+				t.rotateLeft(parent)
+				w = ph.right
+			}
+			wh := w.RBHook()
+			if !isRed(wh.left) && !isRed(wh.right) {
+				// Case 2: 조카가 모두 검정이면 형제를 빨강으로 바꾸고 부모로 올라간다.
+				wh.red = true
+				x, parent = parent, ph.parent
+				continue
+			}
+			if !isRed(wh.right) {
+				// Case 3: 가까운 조카만 빨강이면 형제에서 돌려 Case 4로 만든다.
+				wh.left.RBHook().red, wh.red = false, true
+				t.rotateRight(w)
+				w = ph.right
+				wh = w.RBHook()
+			}
+			// Case 4: 먼 조카가 빨강이면 부모에서 돌리고 끝낸다.
+			wh.red, ph.red = ph.red, false
+			wh.right.RBHook().red = false
+			t.rotateLeft(parent)
+			x = t.head.root
+		} else {
+			w := ph.left
+			if isRed(w) {
+				w.RBHook().red, ph.red = false, true
+				t.rotateRight(parent)
+				w = ph.left
+			}
+			wh := w.RBHook()
+			if !isRed(wh.left) && !isRed(wh.right) {
+				wh.red = true
+				x, parent = parent, ph.parent
+				continue
+			}
+			if !isRed(wh.left) {
+				wh.right.RBHook().red, wh.red = false, true
+				t.rotateLeft(w)
+				w = ph.left
+				wh = w.RBHook()
+			}
+			wh.red, ph.red = ph.red, false
+			wh.left.RBHook().red = false
+			t.rotateRight(parent)
+			x = t.head.root
+		}
+	}
+	if !isZero(x) {
+		x.RBHook().red = false
+	}
+}
+
+func (t *IntrusiveTree[T]) rotateLeft(x T) {
+	xh := x.RBHook()
+	y := xh.right
+	yh := y.RBHook()
+	xh.right = yh.left
+	if !isZero(yh.left) {
+		yh.left.RBHook().parent = x
+	}
+	t.transplant(x, y)
+	yh.left = x
+	xh.parent = y
+}
+
+func (t *IntrusiveTree[T]) rotateRight(x T) {
+	xh := x.RBHook()
+	y := xh.left
+	yh := y.RBHook()
+	xh.left = yh.right
+	if !isZero(yh.right) {
+		yh.right.RBHook().parent = x
+	}
+	t.transplant(x, y)
+	yh.right = x
+	xh.parent = y
+}
+
+// transplant는 u 자리에 v를 붙인다. u의 링크는 건드리지 않는다.
+func (t *IntrusiveTree[T]) transplant(u, v T) {
+	p := u.RBHook().parent
+	switch {
+	case isZero(p):
+		t.head.root = v
+	case u == p.RBHook().left:
+		p.RBHook().left = v
+	default:
+		p.RBHook().right = v
+	}
+	if !isZero(v) {
+		v.RBHook().parent = p
+	}
+}
+
+// Find는 probe가 0을 돌려주는 원소를 찾는다. probe(e)는 찾는 대상이 e보다 작으면 음수, 크면 양수를
+// 돌려줘야 한다. 원소를 새로 만들지 않고 키만으로 찾을 때 쓴다.
+//
+//	t, ok := timers.Find(func(e *Timer) int { return when.Compare(e.When) })
+func (t *IntrusiveTree[T]) Find(probe func(e T) int) (T, bool) {
+	cur := t.head.root
+	for !isZero(cur) {
+		switch c := probe(cur); {
+		case c < 0:
+			cur = cur.RBHook().left
+		case c > 0:
+			cur = cur.RBHook().right
+		default:
+			return cur, true
+		}
+	}
+	return cur, false
+}
+
+// Min은 가장 작은 원소를 돌려준다. 비었으면 T의 제로값이다.
+func (t *IntrusiveTree[T]) Min() T {
+	x := t.head.root
+	if isZero(x) {
+		return x
+	}
+	return intrusiveMin(x)
+}
+
+// Max는 가장 큰 원소를 돌려준다. 비었으면 T의 제로값이다.
+func (t *IntrusiveTree[T]) Max() T {
+	x := t.head.root
+	if isZero(x) {
+		return x
+	}
+	for !isZero(x.RBHook().right) {
+		x = x.RBHook().right
+	}
+	return x
+}
+
+// PopMin은 가장 작은 원소를 떼어 내 돌려준다. 비었으면 ok가 false다. 가장 이른 타이머를 꺼내는 데 쓴다.
+func (t *IntrusiveTree[T]) PopMin() (x T, ok bool) {
+	x = t.Min()
+	if isZero(x) {
+		return x, false
+	}
+	t.Remove(x)
+	return x, true
+}
+
+// Next는 x 바로 다음 원소를 돌려준다. 없거나 x가 이 트리에 없으면 T의 제로값이다.
+func (t *IntrusiveTree[T]) Next(x T) T {
+	var zero T
+	if !t.Contains(x) {
+		return zero
+	}
+	if r := x.RBHook().right; !isZero(r) {
+		return intrusiveMin(r)
+	}
+	p := x.RBHook().parent
+	for !isZero(p) && x == p.RBHook().right {
+		x, p = p, p.RBHook().parent
+	}
+	return p
+}
+
+// Prev는 Next의 대칭이다.
+func (t *IntrusiveTree[T]) Prev(x T) T {
+	var zero T
+	if !t.Contains(x) {
+		return zero
+	}
+	if l := x.RBHook().left; !isZero(l) {
+		for !isZero(l.RBHook().right) {
+			l = l.RBHook().right
+		}
+		return l
+	}
+	p := x.RBHook().parent
+	for !isZero(p) && x == p.RBHook().left {
+		x, p = p, p.RBHook().parent
+	}
+	return p
+}
+
+// All은 모든 원소를 오름차순으로 내놓는다. 순회 도중에는 방금 받은 원소만 Remove해도 된다.
+func (t *IntrusiveTree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for x := t.Min(); !isZero(x); {
+			next := t.Next(x)
+			if !yield(x) {
+				return
+			}
+			x = next
+		}
+	}
+}
+
+func intrusiveMin[T Intrusive[T]](x T) T {
+	for !isZero(x.RBHook().left) {
+		x = x.RBHook().left
+	}
+	return x
+}
+
+func isRed[T Intrusive[T]](x T) bool {
+	return !isZero(x) && x.RBHook().red
+}
+
+func isZero[T comparable](x T) bool {
+	var zero T
+	return x == zero
+}
@@ -0,0 +1,153 @@
+package rbtree
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+type timer struct {
+	Hook[*timer]
+	when int
+}
+
+func compareTimers(a, b *timer) int { return cmp.Compare(a.when, b.when) }
+
+// checkIntrusive는 레드블랙 규칙, 부모 링크, 순서, 원소 수를 확인한다.
+func checkIntrusive(t *testing.T, tree *IntrusiveTree[*timer]) {
+	t.Helper()
+	root := tree.head.root
+	if isRed(root) {
+		t.Fatalf("root must be black")
+	}
+	var walk func(x, parent *timer) (count, black int)
+	walk = func(x, parent *timer) (int, int) {
+		if x == nil {
+			return 0, 1
+		}
+		h := x.RBHook()
+		if h.parent != parent || h.head != &tree.head {
+			t.Fatalf("timer %d has wrong parent or owner", x.when)
+		}
+		if h.red && (isRed(h.left) || isRed(h.right)) {
+			t.Fatalf("two reds in a row at %d", x.when)
+		}
+		if (h.left != nil && h.left.when >= x.when) || (h.right != nil && h.right.when <= x.when) {
+			t.Fatalf("timer %d out of order", x.when)
+		}
+		lc, lb := walk(h.left, x)
+		rc, rb := walk(h.right, x)
+		if lb != rb {
+			t.Fatalf("black height mismatch at %d", x.when)
+		}
+		if !h.red {
+			lb++
+		}
+		return lc + rc + 1, lb
+	}
+	if count, _ := walk(root, nil); count != tree.Len() {
+		t.Fatalf("Len %d disagrees with element count %d", tree.Len(), count)
+	}
+}
+
+func TestIntrusiveTree(t *testing.T) {
+	tree := NewIntrusive(compareTimers)
+	timers := make([]*timer, 500)
+	for i := range timers {
+		timers[i] = &timer{when: i}
+	}
+	for _, i := range rand.Perm(len(timers)) {
+		if !tree.Insert(timers[i]) {
+			t.Fatalf("Insert(%d) failed", i)
+		}
+		checkIntrusive(t, tree)
+	}
+	if tree.Insert(&timer{when: 7}) {
+		t.Fatalf("Insert of an equal element should be rejected")
+	}
+	if got, ok := tree.Find(func(e *timer) int { return cmp.Compare(42, e.when) }); !ok || got != timers[42] {
+		t.Fatalf("Find(42) = %v, %v", got, ok)
+	}
+
+	// 원소를 알고 있으면 찾지 않고 바로 뗀다.
+	live := map[int]bool{}
+	for i := range timers {
+		live[i] = true
+	}
+	for _, i := range rand.Perm(len(timers))[:300] {
+		if !tree.Remove(timers[i]) {
+			t.Fatalf("Remove(%d) failed", i)
+		}
+		delete(live, i)
+		if tree.Contains(timers[i]) || tree.Remove(timers[i]) {
+			t.Fatalf("timer %d still reported as linked", i)
+		}
+		checkIntrusive(t, tree)
+	}
+
+	var got, want []int
+	for x := range tree.All() {
+		got = append(got, x.when)
+	}
+	for i := range live {
+		want = append(want, i)
+	}
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Fatalf("All = %v, want %v", got, want)
+	}
+	if tree.Max().when != want[len(want)-1] || tree.Prev(tree.Min()) != nil || tree.Next(tree.Max()) != nil {
+		t.Fatalf("Min/Max/Next/Prev disagree with the contents")
+	}
+
+	// 떼어 낸 원소는 다른 트리에 넣을 수 있다.
+	other := NewIntrusive(compareTimers)
+	for x, ok := tree.PopMin(); ok; x, ok = tree.PopMin() {
+		other.Insert(x)
+	}
+	checkIntrusive(t, tree)
+	checkIntrusive(t, other)
+	if tree.Len() != 0 || other.Len() != len(want) || tree.Contains(other.Min()) {
+		t.Fatalf("PopMin should move every element: %d left, %d moved", tree.Len(), other.Len())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("inserting an element that is already linked should panic")
+		}
+	}()
+	tree.Insert(other.Min())
+}
+
+func TestIntrusiveRemoveWhileIterating(t *testing.T) {
+	tree := NewIntrusive(compareTimers)
+	for i := 0; i < 100; i++ {
+		tree.Insert(&timer{when: i})
+	}
+	for x := range tree.All() {
+		if x.when%2 == 0 {
+			tree.Remove(x)
+		}
+	}
+	checkIntrusive(t, tree)
+	if tree.Len() != 50 || tree.Min().when != 1 {
+		t.Fatalf("Len = %d, Min = %d after removing evens", tree.Len(), tree.Min().when)
+	}
+}
+
+func TestIntrusiveAllocs(t *testing.T) {
+	tree := NewIntrusive(compareTimers)
+	timers := make([]*timer, 1000)
+	for i := range timers {
+		timers[i] = &timer{when: i}
+		tree.Insert(timers[i])
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		tree.Remove(timers[500])
+		tree.Insert(timers[500])
+	})
+	if allocs != 0 {
+		t.Fatalf("Remove+Insert allocates %.1f times", allocs)
+	}
+}
@@ -0,0 +1,169 @@
+package rbtree
+
+import (
+	"errors"
+	"iter"
+)
+
+// Iterator는 트리를 양방향으로 걸을 수 있는 읽기 전용 커서다. InOrder 콜백과 달리 원하는 만큼
+// 멈췄다가 이어 가거나 거꾸로 돌아갈 수 있어 merge-join 같은 알고리즘에 쓸 수 있다.
+// 반복자는 원소 "위"에 있거나 두 원소 "사이"(처음 앞, 끝 뒤 포함)에 있다. 새 반복자는 첫 원소
+// 앞에 있으므로 Next는 최소 키로, Prev는 최대 키로 이동한다.
+//
+//	it := tree.Iter()
+//	for it.Next() {
+//		fmt.Println(it.Key(), it.Value())
+//	}
+//
+// 반복 도중 원소를 넣거나 지워 트리 구조를 바꾸면 다음 Next/Prev가 ErrModifiedDuringIteration으로
+// panic한다(값만 바꾸는 것은 괜찮다). Seek은 루트부터 다시 찾으므로 그 뒤로는 다시 쓸 수 있다.
+// 순회하며 바꿔야 하면 MutableCursor를 쓴다.
+type Iterator[K any, V any] struct {
+	t    *Tree[K, V]
+	node *Node[K, V]
+	mods uint64 // 반복자가 마지막으로 자리를 잡을 때의 t.mods
+
+	// node가 nil일 때 반복자는 prev와 next 사이에 있다. fresh면 아직 한 번도 움직이지 않은 상태다.
+	prev, next *Node[K, V]
+	fresh      bool
+}
+
+// Iter는 첫 원소 앞에 놓인 반복자를 돌려준다.
+func (t *Tree[K, V]) Iter() *Iterator[K, V] {
+	return &Iterator[K, V]{t: t, fresh: true}
+}
+
+// ErrModifiedDuringIteration은 순회 도중 트리 구조가 바뀌었을 때 순회 함수와 반복자가 panic하는 값이다.
+// 지워진 노드를 따라가 원소를 건너뛰거나 되풀이하는 대신 바로 알린다. recover한 값을 errors.Is로 구분할 수 있다.
+var ErrModifiedDuringIteration = errors.New("rbtree: tree structure modified during iteration")
+
+// checkMods는 mods 이후로 트리 구조가 바뀌었으면 panic한다.
+func (t *Tree[K, V]) checkMods(mods uint64) {
+	if t.mods != mods {
+		panic(ErrModifiedDuringIteration)
+	}
+}
+
+// Seek은 key 이상인 첫 원소로 이동하고 그런 원소가 있으면 true를 돌려준다.
+// 없으면 false를 돌려주고 마지막 원소 뒤에 놓이므로, 이어서 Prev를 부르면 최대 키로 간다.
+func (it *Iterator[K, V]) Seek(key K) bool {
+	it.fresh = false
+	it.mods = it.t.mods
+	if n := it.t.ceiling(key); n != nil {
+		it.node = n
+		return true
+	}
+	it.node, it.prev, it.next = nil, it.t.last(), nil
+	return false
+}
+
+// Next는 다음 원소로 이동한다. 더 이상 원소가 없으면 false를 돌려주고 마지막 원소 뒤에 머문다.
+func (it *Iterator[K, V]) Next() bool {
+	it.checkMods()
+	var n *Node[K, V]
+	switch {
+	case it.node != nil:
+		n = nextLive(it.node)
+	case it.fresh:
+		n = it.t.first()
+	default:
+		n = it.next
+	}
+	if n == nil {
+		if it.node != nil {
+			it.prev = it.node
+		} else if it.fresh {
+			it.prev = nil
+		}
+		it.node, it.next, it.fresh = nil, nil, false
+		return false
+	}
+	it.node, it.fresh = n, false
+	return true
+}
+
+// Prev는 이전 원소로 이동한다. 더 이상 원소가 없으면 false를 돌려주고 첫 원소 앞에 머문다.
+func (it *Iterator[K, V]) Prev() bool {
+	it.checkMods()
+	var n *Node[K, V]
+	switch {
+	case it.node != nil:
+		n = prevLive(it.node)
+	case it.fresh:
+		n = it.t.last()
+	default:
+		n = it.prev
+	}
+	if n == nil {
+		if it.node != nil {
+			it.next = it.node
+		} else if it.fresh {
+			it.next = nil
+		}
+		it.node, it.prev, it.fresh = nil, nil, false
+		return false
+	}
+	it.node, it.fresh = n, false
+	return true
+}
+
+// checkMods는 반복자가 자리를 잡은 뒤 트리 구조가 바뀌었으면 panic한다. 아직 움직이지 않은
+// 반복자는 붙잡은 노드가 없으므로 처음 움직이는 시점의 상태를 기준으로 삼는다.
+func (it *Iterator[K, V]) checkMods() {
+	if it.fresh {
+		it.mods = it.t.mods
+		return
+	}
+	it.t.checkMods(it.mods)
+}
+
+// Key는 현재 원소의 키를 돌려준다. 반복자가 원소 위에 있지 않으면 제로 값이다.
+func (it *Iterator[K, V]) Key() K {
+	if it.node == nil {
+		var zero K
+		return zero
+	}
+	return it.node.Key
+}
+
+// Value는 현재 원소의 값을 돌려준다. 반복자가 원소 위에 있지 않으면 제로 값이다.
+func (it *Iterator[K, V]) Value() V {
+	if it.node == nil {
+		var zero V
+		return zero
+	}
+	return it.node.Value
+}
+
+// All은 모든 원소를 키 오름차순으로 내놓는 range-over-func 시퀀스를 돌려준다.
+//
+//	for k, v := range tree.All() {
+//		...
+//	}
+//
+// 루프에서 break하면 순회가 바로 멈춘다. 루프 안에서 원소를 넣거나 지우면
+// ErrModifiedDuringIteration으로 panic한다. 값만 바꾸는 Put은 괜찮다.
+func (t *Tree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		mods := t.mods
+		for node := t.first(); node != nil; node = nextLive(node) {
+			if !yield(node.Key, node.Value) {
+				return
+			}
+			t.checkMods(mods)
+		}
+	}
+}
+
+// Backward는 All과 같지만 키 내림차순으로 원소를 내놓는다.
+func (t *Tree[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		mods := t.mods
+		for node := t.last(); node != nil; node = prevLive(node) {
+			if !yield(node.Key, node.Value) {
+				return
+			}
+			t.checkMods(mods)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package rbtree
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestIteratorWalk(t *testing.T) {
+	tree := New[int, string]()
+	for _, k := range []int{50, 10, 40, 20, 30} {
+		tree.Insert(k, "v")
+	}
+
+	var forward []int
+	for it := tree.Iter(); it.Next(); {
+		forward = append(forward, it.Key())
+	}
+	if !slices.Equal(forward, []int{10, 20, 30, 40, 50}) {
+		t.Fatalf("forward walk expected sorted keys, got %v", forward)
+	}
+
+	var backward []int
+	for it := tree.Iter(); it.Prev(); {
+		backward = append(backward, it.Key())
+	}
+	if !slices.Equal(backward, []int{50, 40, 30, 20, 10}) {
+		t.Fatalf("backward walk expected reversed keys, got %v", backward)
+	}
+
+	it := tree.Iter()
+	it.Next()
+	it.Next()
+	if !it.Prev() || it.Key() != 10 {
+		t.Fatalf("Prev after two Next calls should return to 10, got %d", it.Key())
+	}
+	if it.Prev() || it.Key() != 0 {
+		t.Fatalf("Prev before the first key should fail and clear the position")
+	}
+	if !it.Next() || it.Key() != 10 {
+		t.Fatalf("Next from before-first should move to 10, got %d", it.Key())
+	}
+}
+
+func TestIteratorSeek(t *testing.T) {
+	tree := New[int, int]()
+	for i := 0; i < 10; i++ {
+		tree.Insert(i*10, i)
+	}
+
+	it := tree.Iter()
+	if !it.Seek(35) || it.Key() != 40 || it.Value() != 4 {
+		t.Fatalf("Seek(35) should land on 40, got %d", it.Key())
+	}
+	if !it.Prev() || it.Key() != 30 {
+		t.Fatalf("Prev after Seek should move to 30, got %d", it.Key())
+	}
+	if !it.Seek(90) || it.Next() {
+		t.Fatalf("Seek(90) should land on the last key with nothing after it")
+	}
+	if it.Seek(91) {
+		t.Fatalf("Seek past the end should fail")
+	}
+	if !it.Prev() || it.Key() != 90 {
+		t.Fatalf("Prev after a failed Seek should move to the last key, got %d", it.Key())
+	}
+}
+
+// merge-join: 두 트리에 공통으로 있는 키를 반복자 두 개로 한 번에 찾는다.
+func TestIteratorMergeJoin(t *testing.T) {
+	a, b := New[int, int](), New[int, int]()
+	for i := 0; i < 30; i += 2 {
+		a.Insert(i, i)
+	}
+	for i := 0; i < 30; i += 3 {
+		b.Insert(i, i)
+	}
+
+	var common []int
+	ia, ib := a.Iter(), b.Iter()
+	okA, okB := ia.Next(), ib.Next()
+	for okA && okB {
+		switch {
+		case ia.Key() < ib.Key():
+			okA = ia.Seek(ib.Key())
+		case ia.Key() > ib.Key():
+			okB = ib.Seek(ia.Key())
+		default:
+			common = append(common, ia.Key())
+			okA, okB = ia.Next(), ib.Next()
+		}
+	}
+	if !slices.Equal(common, []int{0, 6, 12, 18, 24}) {
+		t.Fatalf("expected multiples of 6, got %v", common)
+	}
+}
+
+func TestAllAndBackward(t *testing.T) {
+	tree := New[string, int]()
+	for i, k := range []string{"d", "b", "a", "c", "e"} {
+		tree.Insert(k, i)
+	}
+
+	var keys []string
+	for k, v := range tree.All() {
+		if tree.Search(k).Value != v {
+			t.Fatalf("value mismatch for %q", k)
+		}
+		keys = append(keys, k)
+	}
+	if len(keys) != 5 || keys[0] != "a" || keys[4] != "e" {
+		t.Fatalf("All should yield keys in ascending order, got %v", keys)
+	}
+
+	keys = keys[:0]
+	for k := range tree.Backward() {
+		keys = append(keys, k)
+		if k == "c" {
+			break
+		}
+	}
+	if len(keys) != 3 || keys[0] != "e" || keys[2] != "c" {
+		t.Fatalf("Backward should yield descending keys and stop on break, got %v", keys)
+	}
+
+	for range New[int, int]().All() {
+		t.Fatalf("empty tree should yield nothing")
+	}
+}
+
+func TestModifiedDuringIteration(t *testing.T) {
+	newTree := func() *Tree[int, int] {
+		tree := New[int, int]()
+		for i := 0; i < 10; i++ {
+			tree.Insert(i, i)
+		}
+		return tree
+	}
+	expectPanic := func(name string, fn func()) {
+		t.Helper()
+		defer func() {
+			err, _ := recover().(error)
+			if !errors.Is(err, ErrModifiedDuringIteration) {
+				t.Fatalf("%s: expected ErrModifiedDuringIteration panic, got %v", name, err)
+			}
+		}()
+		fn()
+	}
+
+	tree := newTree()
+	expectPanic("All", func() {
+		for k := range tree.All() {
+			tree.Delete(k + 1)
+		}
+	})
+	tree = newTree()
+	expectPanic("InOrder", func() {
+		tree.InOrder(func(k, v int) { tree.Insert(k+100, v) })
+	})
+	tree = newTree()
+	expectPanic("AscendRange", func() {
+		tree.AscendRange(0, 5, func(k, v int) bool { tree.DeleteMin(); return true })
+	})
+
+	tree = newTree()
+	it := tree.Iter()
+	it.Next()
+	tree.Delete(5)
+	expectPanic("Iterator.Next", func() { it.Next() })
+	if !it.Seek(3) || !it.Next() || it.Key() != 4 {
+		t.Fatalf("Seek should make the iterator usable again")
+	}
+
+	// 값만 바꾸는 것은 구조 변경이 아니다.
+	for k, v := range tree.All() {
+		tree.Put(k, v*2)
+	}
+	if v, _ := tree.Get(9); v != 18 {
+		t.Fatalf("expected values doubled in place, got %d", v)
+	}
+}
@@ -0,0 +1,172 @@
+package rbtree
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Journal은 트리에 일어난 변경을 한 줄에 하나씩 JSON 객체로 덧붙여 쓰는 추가 전용 기록이다.
+// 메모리 인덱스로 쓰는 트리의 감사 기록이나, 마지막 스냅숏 이후의 변경을 되살리는 데 쓴다.
+// 각 줄은 다음 셋 중 하나다.
+//
+//	{"op":"insert","key":K,"value":V,"time":"2024-05-01T12:00:00.000000001Z"}
+//	{"op":"delete","key":K,"time":"..."}
+//	{"op":"clear","time":"..."}
+//
+// insert는 새 키 삽입과 기존 값 덮어쓰기를 모두 뜻하고, delete는 실제로 지워진 키만 남긴다.
+// Delete·Pop·DeleteIf·DeleteRange·크기 제한에 따른 내보내기 등 키가 빠지는 모든 경로가 delete를,
+// Clear와 Split(호출 뒤 트리가 비므로)이 clear를 남긴다. 키와 값은 encoding/json으로 인코딩된다.
+//
+// 쓰기 오류가 한 번 나면 이후 기록은 모두 버려지며 Err로 확인할 수 있다. w가 버퍼를 쓰면
+// 내용을 내보내는 것은 호출자 몫이다.
+type Journal[K any, V any] struct {
+	w   io.Writer
+	err error
+}
+
+// NewJournal은 w에 기록하는 Journal을 만든다. 트리에 붙이려면 Tree.Journal을 쓴다.
+func NewJournal[K any, V any](w io.Writer) *Journal[K, V] {
+	return &Journal[K, V]{w: w}
+}
+
+// Err는 기록 중 처음 난 오류를 돌려준다. 오류가 없었으면 nil이다.
+func (j *Journal[K, V]) Err() error {
+	return j.err
+}
+
+// 저널 한 줄의 op 값.
+const (
+	journalInsert = "insert"
+	journalDelete = "delete"
+	journalClear  = "clear"
+)
+
+// journalRecord는 저널 한 줄의 JSON 모양이다. op에 따라 없는 필드는 nil로 두어 생략한다.
+type journalRecord[K any, V any] struct {
+	Op    string    `json:"op"`
+	Key   *K        `json:"key,omitempty"`
+	Value *V        `json:"value,omitempty"`
+	Time  time.Time `json:"time"`
+}
+
+func (j *Journal[K, V]) write(rec journalRecord[K, V]) {
+	if j.err != nil {
+		return
+	}
+	rec.Time = time.Now()
+	line, err := json.Marshal(rec)
+	if err != nil {
+		j.err = err
+		return
+	}
+	_, j.err = j.w.Write(append(line, '\n'))
+}
+
+// Journal은 j를 트리에 붙여 이후의 변경을 기록하게 한다. 붙이기 전의 내용은 기록하지 않으므로,
+// 저널만으로 트리를 되살리려면 빈 트리에 붙이거나 붙일 때의 상태를 따로 저장해 둔다.
+// j가 nil이면 기록을 멈춘다. Clone, Snapshot, Split 결과에는 저널이 따라가지 않는다.
+func (t *Tree[K, V]) Journal(j *Journal[K, V]) {
+	t.journal = j
+}
+
+// journalInsert, journalDelete, journalClear는 저널이 붙어 있을 때만 기록한다. 키와 값을
+// 힙으로 옮기는 일은 Journal 쪽 메서드에서 일어나므로, 저널이 없으면 할당이 생기지 않는다.
+func (t *Tree[K, V]) journalInsert(key K, value V) {
+	if t.journal != nil {
+		t.journal.insert(key, value)
+	}
+}
+
+func (t *Tree[K, V]) journalDelete(key K) {
+	if t.journal != nil {
+		t.journal.delete(key)
+	}
+}
+
+func (t *Tree[K, V]) journalClear() {
+	if t.journal != nil {
+		t.journal.write(journalRecord[K, V]{Op: journalClear})
+	}
+}
+
+func (j *Journal[K, V]) insert(key K, value V) {
+	j.write(journalRecord[K, V]{Op: journalInsert, Key: &key, Value: &value})
+}
+
+func (j *Journal[K, V]) delete(key K) {
+	j.write(journalRecord[K, V]{Op: journalDelete, Key: &key})
+}
+
+// Replay는 Journal이 쓴 기록을 읽어 순서대로 다시 적용하고 적용한 줄 수를 돌려준다.
+// 저널을 붙였을 때와 같은 상태(보통 빈 트리나 그때 저장해 둔 트리)에 적용하면 기록이 끝난
+// 시점의 논리적 상태가 그대로 되살아난다. 크기 제한이 있는 트리라면 같은 제한을 두어야 한다.
+//
+// 기록을 모두 읽고 해석한 뒤에 적용하므로, 중간에 읽을 수 없는 줄이 있으면 줄 번호를 담은 에러를
+// 돌려주고 트리는 건드리지 않는다. 다만 마지막 줄이 줄바꿈 없이 끊겨 있고 해석도 되지 않으면
+// 기록 도중 멈춘 흔적으로 보고 버린다. t에 저널이 붙어 있으면 다시 적용한 변경도 기록된다.
+// 제로값 Tree에도 쓸 수 있으며, 이때 K는 정수, 실수, 문자열 종류여야 한다.
+func (t *Tree[K, V]) Replay(r io.Reader) (int, error) {
+	var recs []journalRecord[K, V]
+	br := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		b, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return 0, fmt.Errorf("rbtree: replay journal: %w", err)
+		}
+		torn := errors.Is(err, io.EOF)
+		if b = bytes.TrimSpace(b); len(b) > 0 {
+			var rec journalRecord[K, V]
+			if decodeErr := decodeJournalRecord(b, &rec); decodeErr != nil {
+				if torn {
+					break
+				}
+				return 0, fmt.Errorf("rbtree: replay journal line %d: %w", line, decodeErr)
+			}
+			recs = append(recs, rec)
+		}
+		if torn {
+			break
+		}
+	}
+
+	if err := t.ensureCompare(); err != nil {
+		return 0, err
+	}
+	for _, rec := range recs {
+		switch rec.Op {
+		case journalInsert:
+			var value V
+			if rec.Value != nil {
+				value = *rec.Value
+			}
+			t.Insert(*rec.Key, value)
+		case journalDelete:
+			t.Delete(*rec.Key)
+		case journalClear:
+			t.Clear()
+		}
+	}
+	return len(recs), nil
+}
+
+// decodeJournalRecord는 저널 한 줄을 해석하고 op에 필요한 필드가 있는지 확인한다.
+func decodeJournalRecord[K any, V any](b []byte, rec *journalRecord[K, V]) error {
+	if err := json.Unmarshal(b, rec); err != nil {
+		return err
+	}
+	switch rec.Op {
+	case journalInsert, journalDelete:
+		if rec.Key == nil {
+			return fmt.Errorf("%s without key", rec.Op)
+		}
+	case journalClear:
+	default:
+		return fmt.Errorf("unknown op %q", rec.Op)
+	}
+	return nil
+}
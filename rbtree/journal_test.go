@@ -0,0 +1,195 @@
+package rbtree
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// journalLines는 저널을 "op key value" 꼴 문자열로 풀어 비교하기 쉽게 만든다.
+func journalLines(t *testing.T, buf *bytes.Buffer) []string {
+	t.Helper()
+	var out []string
+	sc := bufio.NewScanner(buf)
+	for sc.Scan() {
+		var rec journalRecord[int, string]
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			t.Fatalf("bad journal line %q: %v", sc.Text(), err)
+		}
+		if rec.Time.IsZero() {
+			t.Fatalf("journal line without time: %q", sc.Text())
+		}
+		line := rec.Op
+		if rec.Key != nil {
+			line += fmt.Sprintf(" %d", *rec.Key)
+		}
+		if rec.Value != nil {
+			line += " " + *rec.Value
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+func TestJournal(t *testing.T) {
+	tree := New[int, string]()
+	tree.Insert(0, "before")
+
+	var buf bytes.Buffer
+	j := NewJournal[int, string](&buf)
+	tree.Journal(j)
+
+	tree.Insert(1, "a")
+	tree.Insert(2, "b")
+	tree.Put(1, "a2")
+	tree.Delete(2)
+	tree.Delete(42) // 없는 키는 기록하지 않는다.
+	tree.Update(3, func(string, bool) (string, bool) { return "c", true })
+	CompareAndSwap(tree, 3, "c", "c2")
+	tree.InsertMany([]Pair[int, string]{{Key: 5, Value: "e"}, {Key: 4, Value: "d"}})
+	tree.DeleteRange(3, 5)
+	tree.Clear()
+
+	want := []string{
+		"insert 1 a",
+		"insert 2 b",
+		"insert 1 a2",
+		"delete 2",
+		"insert 3 c",
+		"insert 3 c2",
+		"insert 4 d",
+		"insert 5 e",
+		"delete 3",
+		"delete 4",
+		"clear",
+	}
+	if got := journalLines(t, &buf); !equalStrings(got, want) {
+		t.Fatalf("journal =\n%q\nwant\n%q", got, want)
+	}
+	if j.Err() != nil {
+		t.Fatal(j.Err())
+	}
+
+	tree.Journal(nil)
+	tree.Insert(9, "z")
+	if buf.Len() != 0 {
+		t.Fatalf("detached journal should not be written: %q", buf.String())
+	}
+}
+
+func TestJournalEviction(t *testing.T) {
+	tree := NewBounded[int, string](2)
+	var buf bytes.Buffer
+	tree.Journal(NewJournal[int, string](&buf))
+	for i := 1; i <= 3; i++ {
+		tree.Insert(i, "v")
+	}
+	want := []string{"insert 1 v", "insert 2 v", "insert 3 v", "delete 1"}
+	if got := journalLines(t, &buf); !equalStrings(got, want) {
+		t.Fatalf("journal = %q, want %q", got, want)
+	}
+}
+
+type failingWriter struct{ n int }
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.n++
+	return 0, errors.New("disk full")
+}
+
+func TestJournalWriteError(t *testing.T) {
+	tree := New[int, string]()
+	w := &failingWriter{}
+	j := NewJournal[int, string](w)
+	tree.Journal(j)
+	tree.Insert(1, "a")
+	tree.Insert(2, "b")
+	if j.Err() == nil || !strings.Contains(j.Err().Error(), "disk full") {
+		t.Fatalf("Err() = %v", j.Err())
+	}
+	if w.n != 1 {
+		t.Fatalf("journal kept writing after an error: %d writes", w.n)
+	}
+	if tree.Size() != 2 {
+		t.Fatalf("journal errors must not affect the tree")
+	}
+}
+
+func TestJournalNoAllocWhenDetached(t *testing.T) {
+	tree := New[int, string]()
+	tree.Insert(1, "a")
+	allocs := testing.AllocsPerRun(100, func() { tree.Put(1, "b") })
+	if allocs != 0 {
+		t.Fatalf("Put without a journal allocated %v times", allocs)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	tree := NewBounded[int, string](3)
+	var buf bytes.Buffer
+	tree.Journal(NewJournal[int, string](&buf))
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, fmt.Sprint("v", i))
+	}
+	tree.Delete(8)
+	tree.Put(9, "nine")
+	tree.Clear()
+	tree.InsertMany([]Pair[int, string]{{Key: 2, Value: "b"}, {Key: 1, Value: "a"}})
+	tree.Update(1, func(string, bool) (string, bool) { return "", false })
+	tree.Insert(3, "c")
+	journal := buf.String()
+
+	got := NewBounded[int, string](3)
+	n, err := got.Replay(strings.NewReader(journal))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != strings.Count(journal, "\n") {
+		t.Fatalf("Replay applied %d entries, journal has %d lines", n, strings.Count(journal, "\n"))
+	}
+	eq := func(a, b string) bool { return a == b }
+	if !got.Equal(tree, eq) {
+		t.Fatalf("replayed tree %v, want %v", got.Keys(), tree.Keys())
+	}
+
+	// 제로값 트리에도 되살릴 수 있다.
+	var zero Tree[int, string]
+	if _, err := zero.Replay(strings.NewReader(journal)); err != nil {
+		t.Fatal(err)
+	}
+	if !zero.Equal(tree, eq) {
+		t.Fatalf("replayed zero tree %v, want %v", zero.Keys(), tree.Keys())
+	}
+}
+
+func TestReplayTornTail(t *testing.T) {
+	journal := `{"op":"insert","key":1,"value":"a","time":"2024-05-01T12:00:00Z"}
+{"op":"insert","key":2,"value":"b","time":"2024-05-01T12:00:01Z"}
+{"op":"insert","key":3,"val`
+	tree := New[int, string]()
+	n, err := tree.Replay(strings.NewReader(journal))
+	if err != nil || n != 2 || tree.Size() != 2 {
+		t.Fatalf("Replay = %d, %v; size %d", n, err, tree.Size())
+	}
+}
+
+func TestReplayErrors(t *testing.T) {
+	for _, journal := range []string{
+		"{\"op\":\"insert\",\"key\":1,\"value\":\"a\"}\nnot json\n{\"op\":\"clear\"}\n",
+		"{\"op\":\"insert\",\"key\":1,\"value\":\"a\"}\n{\"op\":\"rename\",\"key\":1}\n",
+		"{\"op\":\"insert\",\"key\":1,\"value\":\"a\"}\n{\"op\":\"delete\"}\n",
+	} {
+		tree := New[int, string]()
+		_, err := tree.Replay(strings.NewReader(journal))
+		if err == nil || !strings.Contains(err.Error(), "line 2") {
+			t.Fatalf("Replay(%q) error = %v, want a line 2 error", journal, err)
+		}
+		if tree.Size() != 0 {
+			t.Fatalf("failed Replay must leave the tree untouched")
+		}
+	}
+}
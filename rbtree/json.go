@@ -0,0 +1,90 @@
+package rbtree
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// jsonPair는 문자열이 아닌 키를 가진 트리의 JSON 배열 원소다.
+type jsonPair[K any, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+// MarshalJSON은 json.Marshaler를 구현한다. 키가 문자열 계열이면 {"키": 값, ...} 객체로,
+// 그 밖에는 [{"key": 키, "value": 값}, ...] 배열로 쓴다. 어느 쪽이든 원소는 키 오름차순이다.
+func (t *Tree[K, V]) MarshalJSON() ([]byte, error) {
+	if !isStringKind[K]() {
+		pairs := make([]jsonPair[K, V], 0, t.size)
+		for k, v := range t.All() {
+			pairs = append(pairs, jsonPair[K, V]{k, v})
+		}
+		return json.Marshal(pairs)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for k, v := range t.All() {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(reflect.ValueOf(k).String())
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("rbtree: marshal value for key %v: %w", k, err)
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON은 json.Unmarshaler를 구현한다. MarshalJSON이 쓰는 형식을 읽으며(문자열 계열 키는
+// 배열 형식도 받는다), 트리의 기존 내용을 버린 뒤 읽은 원소로 회전 없이 균형 트리를 만든다.
+// 배열에 같은 키가 여러 번 나오면 마지막 값이 이긴다. 설정(비교 함수, 톰스톤 모드, 크기 제한)은 그대로 유지된다.
+//
+// 구조체 필드처럼 제로값 Tree로 디코딩하면 K의 기본 순서를 쓴다. 이때 K가 정수, 실수, 문자열
+// 계열이 아니면 에러를 돌려준다. 다른 순서가 필요하면 NewFunc로 만든 트리에 디코딩한다.
+func (t *Tree[K, V]) UnmarshalJSON(data []byte) error {
+	if err := t.ensureCompare(); err != nil {
+		return err
+	}
+
+	var pairs []Pair[K, V]
+	if trimmed := bytes.TrimSpace(data); isStringKind[K]() && len(trimmed) > 0 && trimmed[0] == '{' {
+		var m map[string]V
+		if err := json.Unmarshal(data, &m); err != nil {
+			return fmt.Errorf("rbtree: unmarshal: %w", err)
+		}
+		pairs = make([]Pair[K, V], 0, len(m))
+		for k, v := range m {
+			key := reflect.New(reflect.TypeFor[K]()).Elem()
+			key.SetString(k)
+			pairs = append(pairs, Pair[K, V]{key.Interface().(K), v})
+		}
+	} else {
+		var raw []jsonPair[K, V]
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("rbtree: unmarshal: %w", err)
+		}
+		pairs = make([]Pair[K, V], len(raw))
+		for i, p := range raw {
+			pairs[i] = Pair[K, V]{p.Key, p.Value}
+		}
+	}
+
+	t.Clear()
+	t.InsertMany(pairs)
+	return nil
+}
+
+func isStringKind[K any]() bool {
+	return reflect.TypeFor[K]().Kind() == reflect.String
+}
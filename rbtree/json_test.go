@@ -0,0 +1,64 @@
+package rbtree
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONStringKeys(t *testing.T) {
+	tree := New[string, int]()
+	for i, k := range []string{"b", "a", "c"} {
+		tree.Insert(k, i)
+	}
+	data, err := json.Marshal(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"a":1,"b":0,"c":2}` {
+		t.Fatalf("string-keyed trees should encode as an ordered object, got %s", data)
+	}
+
+	decoded := New[string, int]()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equal(tree, func(a, b int) bool { return a == b }) {
+		t.Fatalf("round trip changed contents")
+	}
+	assertRBProperties(t, decoded)
+}
+
+func TestJSONArrayAndEmbedding(t *testing.T) {
+	type response struct {
+		Scores *Tree[int, string] `json:"scores"`
+	}
+	tree := New[int, string]()
+	tree.Insert(2, "two")
+	tree.Insert(1, "one")
+	data, err := json.Marshal(response{Scores: tree})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"scores":[{"key":1,"value":"one"},{"key":2,"value":"two"}]}` {
+		t.Fatalf("unexpected encoding %s", data)
+	}
+
+	// 구조체 필드에는 제로값 트리가 만들어지므로 기본 순서로 디코딩되어야 한다.
+	var out response
+	if err := json.Unmarshal([]byte(`{"scores":[{"key":3,"value":"x"},{"key":1,"value":"y"},{"key":3,"value":"z"}]}`), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Scores.Size() != 2 || out.Scores.Min().Key != 1 {
+		t.Fatalf("decoded tree has wrong contents, size %d", out.Scores.Size())
+	}
+	if v, _ := out.Scores.Get(3); v != "z" {
+		t.Fatalf("duplicate keys should keep the last value, got %q", v)
+	}
+	out.Scores.Insert(2, "w")
+	assertRBProperties(t, out.Scores)
+
+	var bad struct{ T *Tree[struct{ A int }, int] }
+	if err := json.Unmarshal([]byte(`{"T":[]}`), &bad); err == nil {
+		t.Fatalf("decoding a zero tree with an unordered key type should fail")
+	}
+}
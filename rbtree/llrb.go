@@ -0,0 +1,210 @@
+package rbtree
+
+import "fmt"
+
+// llrbBalancer는 Sedgewick의 좌편향 레드블랙 트리다. 원래 알고리즘은 부모 포인터 없이 재귀로 새
+// 서브트리 루트를 돌려주지만, 여기서는 부모 포인터와 서브트리 원소 수를 유지하는 Tree의 회전을 그대로
+// 쓰고, 회전 뒤 서브트리의 새 루트는 원래 노드의 Parent에서 얻는다.
+//
+// 삭제는 원래 알고리즘처럼 후속 노드의 키와 값을 복사하지 않고 후속 노드 자체를 지울 노드 자리로
+// 옮긴다. 커서와 DeleteIf가 붙잡아 둔 다른 노드가 계속 유효하도록 CLRS 쪽과 같은 약속을 지키기 위해서다.
+type llrbBalancer[K any, V any] struct{}
+
+func (llrbBalancer[K, V]) insertFixup(t *Tree[K, V], node *Node[K, V]) {
+	// 새 노드에서 루트까지 올라가며 규칙 세 가지를 적용한다.
+	for h := node.Parent; h != nil; h = h.Parent {
+		h = t.llrbFixUp(h)
+	}
+	t.setColor(t.root, black)
+}
+
+func (llrbBalancer[K, V]) unlink(t *Tree[K, V], node *Node[K, V]) {
+	before := t.metrics
+	root := t.root
+	if colorOf(root.Left) == black && colorOf(root.Right) == black {
+		t.setColor(root, red)
+	}
+	t.llrbDelete(root, node)
+	if t.root != nil {
+		t.setColor(t.root, black)
+	}
+	t.record("delete %v", node.Key)
+	t.logFixup("delete", node.Key, before)
+}
+
+// normalize는 좌편향 규칙이 깨진 트리의 노드를 키 순서대로 떼어 다시 넣는다. 정렬된 순서로 넣으므로
+// 매번 오른쪽 끝에 붙이고 그 자리에서 위로 고치기만 하면 된다. 통째로 다시 엮는 연산의 내부 일이므로
+// 기록기와 카운터에는 남기지 않는다.
+func (b llrbBalancer[K, V]) normalize(t *Tree[K, V]) {
+	if b.check(t.root) == nil {
+		return
+	}
+	var nodes []*Node[K, V]
+	for n := minimum(t.root); n != nil; n = successor(n) {
+		nodes = append(nodes, n)
+	}
+	recorder, metrics := t.recorder, t.metrics
+	t.recorder = nil
+	t.root = nil
+	var last *Node[K, V]
+	for _, n := range nodes {
+		n.Parent, n.Left, n.Right, n.Color = last, nil, nil, red
+		n.count = 0
+		if !n.deleted {
+			n.count = 1
+		}
+		if last == nil {
+			t.root = n
+		} else {
+			last.Right = n
+			adjustCounts(last, n.count)
+		}
+		b.insertFixup(t, n)
+		last = n
+	}
+	t.recorder, t.metrics = recorder, metrics
+}
+
+func (llrbBalancer[K, V]) check(root *Node[K, V]) error {
+	var err error
+	var walk func(n *Node[K, V])
+	walk = func(n *Node[K, V]) {
+		if n == nil || err != nil {
+			return
+		}
+		if colorOf(n.Right) == red {
+			err = fmt.Errorf("red node %v is a right child of %v in a left-leaning tree", n.Right.Key, n.Key)
+			return
+		}
+		walk(n.Left)
+		walk(n.Right)
+	}
+	walk(root)
+	return err
+}
+
+// llrbDelete는 h 서브트리에 있는 z를 떼어 낸다. 내려가는 동안 현재 노드나 그 왼쪽 자식이 빨강이
+// 되도록 빨강을 끌어내려, 지울 노드가 검정 하나뿐인 2-노드에 있지 않게 한다.
+func (t *Tree[K, V]) llrbDelete(h, z *Node[K, V]) {
+	if h != z && t.compare(z.Key, h.Key) < 0 {
+		if colorOf(h.Left) == black && colorOf(h.Left.Left) == black {
+			h = t.moveRedLeft(h)
+		}
+		t.llrbDelete(h.Left, z)
+	} else {
+		if colorOf(h.Left) == red {
+			h = t.llrbRotateRight(h)
+		}
+		if h == z && h.Right == nil {
+			// 빨강 잎이므로 그냥 떼어 낸다. 위쪽 원소 수는 돌아가는 길의 llrbFixUp이 다시 센다.
+			t.transplant(h, nil)
+			return
+		}
+		if colorOf(h.Right) == black && colorOf(h.Right.Left) == black {
+			h = t.moveRedRight(h)
+		}
+		if h == z {
+			m := t.llrbDetachMin(h.Right)
+			t.substitute(h, m)
+			h = m
+		} else {
+			t.llrbDelete(h.Right, z)
+		}
+	}
+	t.llrbFixUp(h)
+}
+
+// llrbDetachMin은 h 서브트리의 최소 노드를 떼어 내 돌려준다.
+func (t *Tree[K, V]) llrbDetachMin(h *Node[K, V]) *Node[K, V] {
+	if h.Left == nil {
+		// 좌편향 트리에서 왼쪽 자식이 없으면 오른쪽 자식도 없다.
+		t.transplant(h, nil)
+		return h
+	}
+	if colorOf(h.Left) == black && colorOf(h.Left.Left) == black {
+		h = t.moveRedLeft(h)
+	}
+	m := t.llrbDetachMin(h.Left)
+	t.llrbFixUp(h)
+	return m
+}
+
+// substitute는 떼어 낸 노드 m을 h 자리에 넣는다. 원소 수는 호출부의 llrbFixUp이 다시 센다.
+func (t *Tree[K, V]) substitute(h, m *Node[K, V]) {
+	m.Left, m.Right, m.Color, m.count = h.Left, h.Right, h.Color, h.count
+	t.transplant(h, m)
+	if m.Left != nil {
+		m.Left.Parent = m
+	}
+	if m.Right != nil {
+		m.Right.Parent = m
+	}
+}
+
+// llrbFixUp은 h의 원소 수를 다시 세고 좌편향 규칙을 되찾은 뒤 서브트리의 새 루트를 돌려준다.
+func (t *Tree[K, V]) llrbFixUp(h *Node[K, V]) *Node[K, V] {
+	t.metrics.FixupIterations++
+	updateCount(h)
+	t.augmentNode(h)
+	if colorOf(h.Right) == red && colorOf(h.Left) == black {
+		h = t.llrbRotateLeft(h)
+	}
+	if colorOf(h.Left) == red && colorOf(h.Left.Left) == red {
+		h = t.llrbRotateRight(h)
+	}
+	if colorOf(h.Left) == red && colorOf(h.Right) == red {
+		t.flipColors(h)
+	}
+	return h
+}
+
+// moveRedLeft는 h가 빨강이고 왼쪽 자식과 그 왼쪽 자식이 검정일 때 왼쪽 자식이나 그 자식 하나를
+// 빨강으로 만든다. 서브트리의 새 루트를 돌려준다.
+func (t *Tree[K, V]) moveRedLeft(h *Node[K, V]) *Node[K, V] {
+	t.flipColors(h)
+	if colorOf(h.Right.Left) == red {
+		t.llrbRotateRight(h.Right)
+		h = t.llrbRotateLeft(h)
+		t.flipColors(h)
+	}
+	return h
+}
+
+// moveRedRight는 moveRedLeft의 대칭이다.
+func (t *Tree[K, V]) moveRedRight(h *Node[K, V]) *Node[K, V] {
+	t.flipColors(h)
+	if colorOf(h.Left.Left) == red {
+		h = t.llrbRotateRight(h)
+		t.flipColors(h)
+	}
+	return h
+}
+
+// llrbRotateLeft는 회전하면서 올라간 노드가 h의 색을 물려받고 h는 빨강이 되게 한다.
+func (t *Tree[K, V]) llrbRotateLeft(h *Node[K, V]) *Node[K, V] {
+	x := h.Right
+	t.rotateLeft(h)
+	t.setColor(x, h.Color)
+	t.setColor(h, red)
+	return x
+}
+
+// llrbRotateRight는 llrbRotateLeft의 대칭이다.
+func (t *Tree[K, V]) llrbRotateRight(h *Node[K, V]) *Node[K, V] {
+	x := h.Left
+	t.rotateRight(h)
+	t.setColor(x, h.Color)
+	t.setColor(h, red)
+	return x
+}
+
+// flipColors는 h와 두 자식의 색을 뒤집는다. 2-3-4 트리로 보면 4-노드를 쪼개거나 세 노드를 합친다.
+func (t *Tree[K, V]) flipColors(h *Node[K, V]) {
+	t.setColor(h, !h.Color)
+	if h.Left != nil {
+		t.setColor(h.Left, !h.Left.Color)
+	}
+	if h.Right != nil {
+		t.setColor(h.Right, !h.Right.Color)
+	}
+}
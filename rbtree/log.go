@@ -0,0 +1,88 @@
+package rbtree
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogLevels는 WithLogger로 남기는 로그의 종류별 수준이다.
+type LogLevels struct {
+	// Mutation은 insert, update, delete, clear 한 건마다 남기는 로그의 수준이다.
+	Mutation slog.Level
+	// Fixup은 삽입·삭제 한 건의 재균형을 요약한 로그(회전, 색 변경, 보정 반복 횟수)의 수준이다.
+	Fixup slog.Level
+	// Check와 CheckFailure는 Validate가 통과했을 때와 깨진 곳을 찾았을 때 남기는 로그의 수준이다.
+	Check        slog.Level
+	CheckFailure slog.Level
+}
+
+// defaultLogLevels는 WithLogLevels를 주지 않았을 때의 수준이다. 재균형 요약은 변경 로그보다
+// 훨씬 잦고 자세하므로 Debug보다 한 단계 낮춘다.
+var defaultLogLevels = LogLevels{
+	Mutation:     slog.LevelDebug,
+	Fixup:        slog.LevelDebug - 4,
+	Check:        slog.LevelDebug,
+	CheckFailure: slog.LevelError,
+}
+
+// WithLogger는 트리의 변경, 재균형 요약, Validate 결과를 l로 남긴다. 디버깅하며 라이브러리에
+// fmt.Printf를 끼워 넣는 대신 쓴다. 수준은 기본으로 변경과 통과한 검사가 Debug, 재균형 요약이
+// Debug-4, 실패한 검사가 Error이며 WithLogLevels로 바꿀 수 있다. l의 핸들러가 해당 수준을 받지
+// 않으면 로그 항목을 만들지도 않는다.
+//
+//	tree := rbtree.New[int, string](rbtree.WithLogger(slog.Default()))
+func WithLogger(l *slog.Logger) Option {
+	return func(o *options) { o.logger = l }
+}
+
+// WithLogLevels는 WithLogger로 남기는 로그의 수준을 바꾼다. levels의 모든 필드가 그대로 쓰이므로
+// 채우지 않은 필드는 slog.LevelInfo(0)가 된다.
+func WithLogLevels(levels LogLevels) Option {
+	return func(o *options) { o.logLevels = levels }
+}
+
+// treeLogger는 WithLogger로 받은 로거와 종류별 수준이다.
+type treeLogger struct {
+	l      *slog.Logger
+	levels LogLevels
+}
+
+// mutations와 fixups는 해당 종류의 로그를 남길지 알려 준다. 로거가 없으면(nil) false다.
+func (lg *treeLogger) mutations() bool {
+	return lg != nil && lg.l.Enabled(context.Background(), lg.levels.Mutation)
+}
+
+func (lg *treeLogger) fixups() bool {
+	return lg != nil && lg.l.Enabled(context.Background(), lg.levels.Fixup)
+}
+
+// logMutation은 키 하나의 변경을 남긴다.
+func (t *Tree[K, V]) logMutation(op string, key K) {
+	if !t.log.mutations() {
+		return
+	}
+	t.log.l.LogAttrs(context.Background(), t.log.levels.Mutation, "rbtree "+op,
+		slog.Any("key", key), slog.Int("size", t.size))
+}
+
+// logClear는 트리를 통째로 비운 변경을 남긴다.
+func (t *Tree[K, V]) logClear(removed int) {
+	if !t.log.mutations() {
+		return
+	}
+	t.log.l.LogAttrs(context.Background(), t.log.levels.Mutation, "rbtree clear", slog.Int("removed", removed))
+}
+
+// logFixup은 before 이후 쌓인 재균형 카운터의 차이로 한 연산의 재균형을 요약한다.
+// 회전도 색 변경도 없었던 연산은 남기지 않는다.
+func (t *Tree[K, V]) logFixup(op string, key K, before Metrics) {
+	if !t.log.fixups() || (t.metrics.Rotations == before.Rotations && t.metrics.Recolors == before.Recolors) {
+		return
+	}
+	t.log.l.LogAttrs(context.Background(), t.log.levels.Fixup, "rbtree fixup",
+		slog.String("op", op),
+		slog.Any("key", key),
+		slog.Uint64("rotations", t.metrics.Rotations-before.Rotations),
+		slog.Uint64("recolors", t.metrics.Recolors-before.Recolors),
+		slog.Uint64("iterations", t.metrics.FixupIterations-before.FixupIterations))
+}
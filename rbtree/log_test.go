@@ -0,0 +1,67 @@
+package rbtree
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// newTestLogger는 시간 없이 한 줄씩 쓰는 텍스트 로거를 만든다.
+func newTestLogger(buf *bytes.Buffer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	tree := New[int, string](WithLogger(newTestLogger(&buf, slog.LevelDebug-4)))
+	tree.Insert(10, "a")
+	tree.Insert(20, "b")
+	tree.Insert(30, "c")
+	tree.Put(20, "B")
+	tree.Delete(10)
+	tree.Clear()
+
+	want := []string{
+		`level=DEBUG-4 msg="rbtree fixup" op=insert key=10 rotations=0 recolors=1 iterations=0`,
+		`level=DEBUG msg="rbtree insert" key=10 size=1`,
+		`level=DEBUG msg="rbtree insert" key=20 size=2`,
+		`level=DEBUG-4 msg="rbtree fixup" op=insert key=30 rotations=1 recolors=2 iterations=1`,
+		`level=DEBUG msg="rbtree insert" key=30 size=3`,
+		`level=DEBUG msg="rbtree update" key=20 size=3`,
+		`level=DEBUG msg="rbtree delete" key=10 size=2`,
+		`level=DEBUG msg="rbtree clear" removed=2`,
+	}
+	got := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !equalStrings(got, want) {
+		t.Fatalf("log =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestWithLogLevels(t *testing.T) {
+	var buf bytes.Buffer
+	levels := LogLevels{Mutation: slog.LevelInfo, Fixup: slog.LevelDebug}
+	tree := New[int, int](WithLogger(newTestLogger(&buf, slog.LevelInfo)), WithLogLevels(levels))
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, i)
+	}
+	tree.DeleteRange(0, 5)
+	out := buf.String()
+	if strings.Contains(out, "fixup") {
+		t.Fatalf("fixup summaries below the handler level should be dropped:\n%s", out)
+	}
+	if n := strings.Count(out, `level=INFO msg="rbtree insert"`); n != 10 {
+		t.Fatalf("logged %d inserts at INFO, want 10:\n%s", n, out)
+	}
+	if n := strings.Count(out, `msg="rbtree delete"`); n != 5 {
+		t.Fatalf("DeleteRange logged %d deletes, want 5:\n%s", n, out)
+	}
+}
@@ -0,0 +1,116 @@
+package rbtree
+
+import "sync"
+
+// lruList는 EvictLRU 트리의 최근 사용 순서다. 키마다 원형 이중 연결 리스트의 칸을 하나 두고, 키로
+// 칸을 찾는 색인은 같은 비교 함수를 쓰는 보조 트리에 둔다. 노드에 칸을 달면 노드를 복사하거나
+// 다시 엮는 연산마다 칸을 옮겨야 하므로, 노드와는 키로만 이어 둔다.
+//
+// Get은 읽기 잠금 아래에서 여러 고루틴이 함께 부를 수 있으므로 순서를 바꿀 때는 mu로 잠근다.
+type lruList[K any] struct {
+	mu    sync.Mutex
+	index *Tree[K, *lruEntry[K]]
+	// head는 경계 칸이다. head.next가 가장 최근에, head.prev가 가장 오래전에 쓴 키다.
+	head lruEntry[K]
+}
+
+type lruEntry[K any] struct {
+	key        K
+	prev, next *lruEntry[K]
+}
+
+func newLRUList[K any](compare func(a, b K) int) *lruList[K] {
+	l := &lruList[K]{index: &Tree[K, *lruEntry[K]]{compare: compare}}
+	l.head.prev, l.head.next = &l.head, &l.head
+	return l
+}
+
+// push는 key를 가장 최근에 쓴 키로 만든다. 처음 보는 키면 칸을 새로 만든다.
+func (l *lruList[K]) push(key K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e, ok := l.index.Get(key); ok {
+		l.unlinkEntry(e)
+		l.linkAfter(&l.head, e)
+		return
+	}
+	e := &lruEntry[K]{key: key}
+	l.index.Insert(key, e)
+	l.linkAfter(&l.head, e)
+}
+
+// touch는 이미 있는 key만 가장 최근에 쓴 키로 옮긴다.
+func (l *lruList[K]) touch(key K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e, ok := l.index.Get(key); ok {
+		l.unlinkEntry(e)
+		l.linkAfter(&l.head, e)
+	}
+}
+
+// remove는 key의 칸을 뺀다.
+func (l *lruList[K]) remove(key K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e, ok := l.index.Pop(key); ok {
+		l.unlinkEntry(e)
+	}
+}
+
+// reset은 모든 칸을 버린다.
+func (l *lruList[K]) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.index.Clear()
+	l.head.prev, l.head.next = &l.head, &l.head
+}
+
+// oldest는 가장 오래전에 쓴 키를 돌려준다. 비었으면 ok가 false다.
+func (l *lruList[K]) oldest() (key K, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e := l.head.prev; e != &l.head {
+		return e.key, true
+	}
+	return key, false
+}
+
+func (l *lruList[K]) linkAfter(at, e *lruEntry[K]) {
+	e.prev, e.next = at, at.next
+	at.next.prev = e
+	at.next = e
+}
+
+func (l *lruList[K]) unlinkEntry(e *lruEntry[K]) {
+	e.prev.next, e.next.prev = e.next, e.prev
+	e.prev, e.next = nil, nil
+}
+
+// syncLRU는 ReadFrom이나 Clone, Split·Join 결과처럼 원소별 삽입을 거치지 않고 트리에 들어온 키를
+// 최근 사용 순서에 채워 넣는다. 그런 키는 모두 이미 있던 키보다 오래된 것으로 보고, 그들끼리는 작은
+// 키가 더 오래된 것처럼 뒤쪽에 붙인다. 빠진 키가 없으면 O(1)이다.
+func (t *Tree[K, V]) syncLRU() {
+	l := t.lru
+	if l.index.Size() >= t.size {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for node := t.last(); node != nil; node = prevLive(node) {
+		if l.index.Contains(node.Key) {
+			continue
+		}
+		e := &lruEntry[K]{key: node.Key}
+		l.index.Insert(node.Key, e)
+		l.linkAfter(l.head.prev, e)
+	}
+}
+
+// fresh는 같은 비교 함수를 쓰는 빈 목록을 돌려준다. l이 nil이면 nil이다.
+func (l *lruList[K]) fresh() *lruList[K] {
+	if l == nil {
+		return nil
+	}
+	return newLRUList(l.index.compare)
+}
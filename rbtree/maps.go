@@ -0,0 +1,31 @@
+package rbtree
+
+import (
+	"cmp"
+	"slices"
+)
+
+// ToMap은 트리의 모든 원소를 담은 새 맵을 돌려준다. Tree의 키 타입에는 comparable 제약이
+// 없으므로(NewFunc로 만든 트리도 있으므로) 메서드가 아닌 패키지 함수로 둔다.
+func ToMap[K comparable, V any](t *Tree[K, V]) map[K]V {
+	m := make(map[K]V, t.Size())
+	for node := t.first(); node != nil; node = nextLive(node) {
+		m[node.Key] = node.Value
+	}
+	return m
+}
+
+// FromMap은 맵의 원소로 새 트리를 만든다. 키를 정렬한 뒤 한 번에 균형 트리를 세우므로
+// 원소마다 Insert하는 것보다 빠르다(O(n log n) 정렬 + O(n) 구성).
+func FromMap[K cmp.Ordered, V any](m map[K]V) *Tree[K, V] {
+	pairs := make([]Pair[K, V], 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, Pair[K, V]{Key: k, Value: v})
+	}
+	slices.SortFunc(pairs, func(a, b Pair[K, V]) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+	t := New[K, V]()
+	t.setRoot(buildSorted(pairs))
+	return t
+}
@@ -0,0 +1,31 @@
+package rbtree
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestMapConversion(t *testing.T) {
+	m := map[string]int{}
+	for i := 0; i < 200; i++ {
+		m[string(rune('a'+i%26))+string(rune('a'+i/26))] = i
+	}
+
+	tree := FromMap(m)
+	assertRBProperties(t, tree)
+	if tree.Size() != len(m) {
+		t.Fatalf("expected size %d, got %d", len(m), tree.Size())
+	}
+	for k, v := range m {
+		if node := tree.Search(k); node == nil || node.Value != v {
+			t.Fatalf("key %q missing or wrong after FromMap", k)
+		}
+	}
+
+	if back := ToMap(tree); !maps.Equal(back, m) {
+		t.Fatalf("ToMap(FromMap(m)) should equal m")
+	}
+	if empty := FromMap(map[int]int{}); empty.Size() != 0 || len(ToMap(empty)) != 0 {
+		t.Fatalf("empty map should round-trip to an empty tree")
+	}
+}
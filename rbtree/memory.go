@@ -0,0 +1,57 @@
+package rbtree
+
+import "unsafe"
+
+// MemoryUsage는 트리가 붙잡고 있는 메모리를 바이트 단위로 어림한다. 노드 구조체(키·값 자리와 링크,
+// 색, 보강 필드 포함)에 노드 수를 곱하고, 키와 값이 string이면 길이를, []byte면 용량을 더한다.
+// MeasureValues로 값 크기 함수를 주었다면 값은 그 함수로 잰다. 톰스톤 노드도 메모리를 차지하므로
+// 함께 센다.
+//
+// 할당기의 크기 반올림, 스냅숏과 공유 중인 노드, 키와 값이 가리키는 그 밖의 메모리는 반영하지 않는
+// 어림값이다. 노드 수만으로 계산할 수 있으면 O(1)이고, 내용 길이를 더해야 하면 O(n)이다.
+// 테넌트별로 트리를 여러 개 두고 메모리 예산을 지킬 때 쓴다.
+func (t *Tree[K, V]) MemoryUsage() uint64 {
+	nodes := uint64(t.size + t.dead)
+	total := nodes * uint64(unsafe.Sizeof(Node[K, V]{}))
+	var key K
+	var value V
+	_, keyBytes := contentSize(key)
+	_, valueBytes := contentSize(value)
+	if t.root == nil || (!keyBytes && !valueBytes && t.valueSize == nil) {
+		return total
+	}
+	for n := minimum(t.root); n != nil; n = successor(n) {
+		if keyBytes {
+			size, _ := contentSize(n.Key)
+			total += size
+		}
+		switch {
+		case n.deleted:
+			// 톰스톤은 값을 이미 놓아 주었다.
+		case t.valueSize != nil:
+			total += t.valueSize(n.Value)
+		case valueBytes:
+			size, _ := contentSize(n.Value)
+			total += size
+		}
+	}
+	return total
+}
+
+// MeasureValues는 MemoryUsage가 값 하나가 노드 밖에서 붙잡고 있는 바이트 수를 셀 때 쓸 함수를
+// 등록한다. 포인터나 슬라이스, 맵처럼 노드에 헤더만 들어가는 값의 실제 크기를 반영할 때 쓴다.
+// nil을 넘기면 기본 방식(string과 []byte만 센다)으로 돌아간다.
+func (t *Tree[K, V]) MeasureValues(fn func(value V) uint64) {
+	t.valueSize = fn
+}
+
+// contentSize는 v가 string이면 길이, []byte면 용량과 true를, 아니면 0과 false를 돌려준다.
+func contentSize[T any](v T) (uint64, bool) {
+	switch v := any(v).(type) {
+	case string:
+		return uint64(len(v)), true
+	case []byte:
+		return uint64(cap(v)), true
+	}
+	return 0, false
+}
@@ -0,0 +1,41 @@
+package rbtree
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestMemoryUsage(t *testing.T) {
+	if got := New[int, int]().MemoryUsage(); got != 0 {
+		t.Fatalf("empty tree uses %d bytes", got)
+	}
+
+	ints := NewWithTombstones[int, int]()
+	for i := 0; i < 10; i++ {
+		ints.Insert(i, i)
+	}
+	ints.Delete(3)
+	node := uint64(unsafe.Sizeof(Node[int, int]{}))
+	if got := ints.MemoryUsage(); got != 10*node {
+		t.Fatalf("MemoryUsage = %d, want %d (tombstones still occupy a node)", got, 10*node)
+	}
+
+	strs := New[string, []byte]()
+	strs.Insert("ab", make([]byte, 3, 8))
+	strs.Insert("cde", nil)
+	node = uint64(unsafe.Sizeof(Node[string, []byte]{}))
+	if got, want := strs.MemoryUsage(), 2*node+2+3+8; got != want {
+		t.Fatalf("MemoryUsage = %d, want %d", got, want)
+	}
+
+	type blob struct{ data []int }
+	blobs := New[int, *blob]()
+	blobs.Insert(1, &blob{data: make([]int, 100)})
+	blobs.MeasureValues(func(b *blob) uint64 {
+		return uint64(unsafe.Sizeof(*b)) + uint64(cap(b.data))*8
+	})
+	node = uint64(unsafe.Sizeof(Node[int, *blob]{}))
+	if got, want := blobs.MemoryUsage(), node+24+800; got != want {
+		t.Fatalf("MemoryUsage with MeasureValues = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,70 @@
+package rbtree
+
+import "sync/atomic"
+
+// Metrics는 삽입·삭제의 재균형 비용과 연산 횟수를 센 카운터다. 삽입 순서(정렬된 입력, 무작위 입력 등)에
+// 따라 회전과 색 변경이 얼마나 일어나는지처럼 걸린 시간만으로는 보이지 않는 비용을 비교할 때 쓴다.
+// Comparisons는 CountComparisons로 켠 동안만 세고 나머지는 항상 센다. Split·Join·InsertMany처럼
+// 트리를 통째로 다시 엮는 연산의 내부 회전은 세지 않으며, Clear·Split·Join으로 비운 원소는
+// RegisterExpvar와 마찬가지로 Deletes에 넣지 않는다.
+type Metrics struct {
+	Rotations       uint64 // 회전 횟수
+	Recolors        uint64 // 노드 색이 실제로 바뀐 횟수
+	FixupIterations uint64 // insertFixup과 deleteFixup의 루프를 돈 횟수
+	Comparisons     uint64 // 키 비교 함수를 부른 횟수
+
+	Inserts uint64 // 새 키 삽입 횟수(톰스톤 되살리기 포함)
+	Updates uint64 // 기존 키의 값을 바꾼 횟수
+	Deletes uint64 // 키가 빠진 횟수(크기 제한에 따른 내보내기 포함)
+}
+
+// comparisonCounter는 CountComparisons가 비교 함수를 감쌀 때 원래 함수와 횟수를 담는다.
+// 잠금 아래에서 여러 고루틴이 동시에 읽기 연산을 할 수 있으므로 횟수는 원자적으로 센다.
+type comparisonCounter[K any] struct {
+	n       atomic.Uint64
+	compare func(a, b K) int
+}
+
+// Metrics는 트리가 만들어진 뒤(또는 마지막 ResetMetrics 뒤) 쌓인 카운터를 돌려준다.
+func (t *Tree[K, V]) Metrics() Metrics {
+	m := t.metrics
+	if t.comparisons != nil {
+		m.Comparisons += t.comparisons.n.Load()
+	}
+	return m
+}
+
+// ResetMetrics는 모든 카운터를 0으로 되돌린다. 측정할 구간 직전에 부른다.
+func (t *Tree[K, V]) ResetMetrics() {
+	t.metrics = Metrics{}
+	if t.comparisons != nil {
+		t.comparisons.n.Store(0)
+	}
+}
+
+// CountComparisons는 키 비교 횟수 세기를 켜거나 끈다. 비교 함수를 세는 함수로 감싸므로 켜 둔 동안은
+// 탐색이 조금 느려진다. 끄면 그때까지 센 횟수는 Metrics에 남는다.
+func (t *Tree[K, V]) CountComparisons(on bool) {
+	switch {
+	case on && t.comparisons == nil:
+		c := &comparisonCounter[K]{compare: t.compare}
+		t.comparisons = c
+		t.compare = func(a, b K) int {
+			c.n.Add(1)
+			return c.compare(a, b)
+		}
+	case !on && t.comparisons != nil:
+		t.metrics.Comparisons += t.comparisons.n.Load()
+		t.compare = t.comparisons.compare
+		t.comparisons = nil
+	}
+}
+
+// plainCompare는 CountComparisons가 감싸기 전의 비교 함수다. 새 트리에 비교 함수를 물려줄 때 써서
+// 다른 트리의 비교가 이 트리의 횟수에 섞이지 않게 한다.
+func (t *Tree[K, V]) plainCompare() func(a, b K) int {
+	if t.comparisons != nil {
+		return t.comparisons.compare
+	}
+	return t.compare
+}
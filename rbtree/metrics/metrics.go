@@ -0,0 +1,177 @@
+// Package metrics는 트리의 크기, 높이, 재균형 카운터(rbtree.Metrics)를 expvar 변수나 Prometheus
+// 텍스트 형식으로 게시한다. 트리를 품은 서비스의 대시보드에 연결하는 용도다.
+//
+// 게시한 값은 /debug/vars나 /metrics 요청을 처리하는 고루틴이 읽으므로, 다른 고루틴이 함께 쓰는
+// 트리라면 syncrbtree.Tree처럼 잠금으로 보호된 Source를 넘겨야 한다. 카운터는 모두 누적값이며,
+// 초당 연산 수 같은 비율은 수집 쪽(Prometheus의 rate() 등)에서 두 시점의 차이로 구한다.
+package metrics
+
+import (
+	"bufio"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/EletricSaw/rbtree/rbtree"
+)
+
+// Source는 게시할 수 있는 트리다. *rbtree.Tree와 *syncrbtree.Tree가 이를 만족한다.
+// Height() int 메서드도 있으면 높이를 함께 게시한다. 두 트리 모두 Height가 있다.
+type Source interface {
+	Size() int
+	Metrics() rbtree.Metrics
+}
+
+type heighter interface {
+	Height() int
+}
+
+// sample은 한 시점에 Source에서 읽은 값이다.
+type sample struct {
+	size      int
+	height    int
+	hasHeight bool
+	m         rbtree.Metrics
+}
+
+// read는 src의 값을 읽는다. Height는 O(n) 순회이므로 withHeight가 참일 때만 부른다.
+func read(src Source, withHeight bool) sample {
+	s := sample{size: src.Size(), m: src.Metrics()}
+	if h, ok := src.(heighter); ok && withHeight {
+		s.height, s.hasHeight = h.Height(), true
+	}
+	return s
+}
+
+// family는 게시하는 값 하나의 이름, 종류, 설명과 sample에서 값을 꺼내는 방법이다.
+type family struct {
+	name   string
+	kind   string // "gauge" 또는 "counter"
+	help   string
+	height bool // 값을 꺼내려면 Height를 읽어야 한다.
+	value  func(s sample) (uint64, bool)
+}
+
+var families = []family{
+	{"size", "gauge", "Number of live elements.", false, func(s sample) (uint64, bool) { return uint64(s.size), true }},
+	{"height", "gauge", "Number of nodes on the longest root-to-leaf path.", true, func(s sample) (uint64, bool) { return uint64(s.height), s.hasHeight }},
+	{"rotations", "counter", "Rotations performed while rebalancing.", false, func(s sample) (uint64, bool) { return s.m.Rotations, true }},
+	{"recolors", "counter", "Node color changes performed while rebalancing.", false, func(s sample) (uint64, bool) { return s.m.Recolors, true }},
+	{"fixup_iterations", "counter", "Iterations of the insert and delete fixup loops.", false, func(s sample) (uint64, bool) { return s.m.FixupIterations, true }},
+	{"comparisons", "counter", "Key comparisons while comparison counting is on.", false, func(s sample) (uint64, bool) { return s.m.Comparisons, true }},
+	{"inserts", "counter", "New keys inserted.", false, func(s sample) (uint64, bool) { return s.m.Inserts, true }},
+	{"updates", "counter", "Values replaced for existing keys.", false, func(s sample) (uint64, bool) { return s.m.Updates, true }},
+	{"deletes", "counter", "Keys removed.", false, func(s sample) (uint64, bool) { return s.m.Deletes, true }},
+}
+
+// Expose는 src의 값을 m 아래에 size, height, rotations, recolors, fixup_iterations, comparisons,
+// inserts, updates, deletes 변수로 게시한다. 값은 /debug/vars를 읽을 때마다 새로 계산된다.
+// height는 src가 Height를 제공할 때만 게시한다.
+//
+//	metrics.Expose(tree, expvar.NewMap("index"))
+func Expose(src Source, m *expvar.Map) {
+	for _, f := range families {
+		if _, ok := src.(heighter); f.height && !ok {
+			continue
+		}
+		m.Set(f.name, expvar.Func(func() any {
+			v, _ := f.value(read(src, f.height))
+			return v
+		}))
+	}
+}
+
+// Collector는 여러 트리의 값을 Prometheus 텍스트 형식(0.0.4)으로 내보낸다. 클라이언트 라이브러리에
+// 기대지 않고 형식을 직접 쓰므로, 그대로 /metrics 핸들러로 달거나 기존 수집기의 출력에 덧붙일 수 있다.
+// 값 이름은 namespace_rbtree_size처럼 붙고, 트리는 tree 레이블로 구분한다.
+type Collector struct {
+	namespace string
+
+	mu    sync.Mutex
+	names []string
+	trees map[string]Source
+}
+
+// NewCollector는 빈 Collector를 만든다. namespace가 비어 있으면 이름은 rbtree_로 시작한다.
+func NewCollector(namespace string) *Collector {
+	return &Collector{namespace: namespace, trees: make(map[string]Source)}
+}
+
+// Add는 src를 tree 레이블 name으로 등록한다. 같은 name을 두 번 등록하면 expvar.Publish처럼 panic한다.
+func (c *Collector) Add(name string, src Source) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.trees[name]; ok {
+		panic(fmt.Sprintf("metrics: tree %q is already registered", name))
+	}
+	c.names = append(c.names, name)
+	c.trees[name] = src
+}
+
+// WriteTo는 등록된 모든 트리의 현재 값을 Prometheus 텍스트 형식으로 w에 쓴다.
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	c.mu.Lock()
+	names := append([]string(nil), c.names...)
+	samples := make([]sample, len(names))
+	for i, name := range names {
+		samples[i] = read(c.trees[name], true)
+	}
+	c.mu.Unlock()
+
+	prefix := "rbtree_"
+	if c.namespace != "" {
+		prefix = c.namespace + "_" + prefix
+	}
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	for _, f := range families {
+		name := prefix + f.name
+		if f.kind == "counter" {
+			name += "_total"
+		}
+		header := false
+		for i, s := range samples {
+			v, ok := f.value(s)
+			if !ok {
+				continue
+			}
+			if !header {
+				fmt.Fprintf(cw, "# HELP %s %s\n# TYPE %s %s\n", name, f.help, name, f.kind)
+				header = true
+			}
+			fmt.Fprintf(cw, "%s{tree=\"%s\"} %d\n", name, labelEscaper.Replace(names[i]), v)
+		}
+	}
+	err := cw.w.Flush()
+	if err == nil {
+		err = cw.err
+	}
+	return cw.n, err
+}
+
+// ServeHTTP는 WriteTo의 출력을 응답으로 돌려준다.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.WriteTo(w)
+}
+
+// labelEscaper는 Prometheus 레이블 값에서 역슬래시, 큰따옴표, 줄바꿈을 이스케이프한다.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// countingWriter는 쓴 바이트 수와 처음 난 에러를 기억한다.
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	if cw.err == nil {
+		cw.err = err
+	}
+	return n, err
+}
@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"expvar"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/EletricSaw/rbtree/rbtree"
+	"github.com/EletricSaw/rbtree/rbtree/syncrbtree"
+)
+
+var (
+	_ Source = (*rbtree.Tree[int, int])(nil)
+	_ Source = (*syncrbtree.Tree[int, int])(nil)
+)
+
+// fixedSource는 정해 둔 값을 돌려주는 Source다.
+type fixedSource struct {
+	size, height int
+	m            rbtree.Metrics
+}
+
+func (f fixedSource) Size() int               { return f.size }
+func (f fixedSource) Height() int             { return f.height }
+func (f fixedSource) Metrics() rbtree.Metrics { return f.m }
+
+// countingSource는 Height가 불린 횟수를 센다.
+type countingSource struct {
+	fixedSource
+	heights int
+}
+
+func (c *countingSource) Height() int {
+	c.heights++
+	return c.height
+}
+
+// plainSource는 Height가 없는 Source다.
+type plainSource struct{}
+
+func (plainSource) Size() int               { return 0 }
+func (plainSource) Metrics() rbtree.Metrics { return rbtree.Metrics{} }
+
+func TestExpose(t *testing.T) {
+	tree := rbtree.New[int, int]()
+	m := expvar.NewMap("metrics_test_expose")
+	Expose(tree, m)
+
+	for i := 0; i < 3; i++ {
+		tree.Insert(i, i)
+	}
+	// 값은 읽을 때마다 새로 계산된다.
+	if got := m.Get("size").String(); got != "3" {
+		t.Fatalf("size = %s, want 3", got)
+	}
+	if got := m.Get("inserts").String(); got != "3" {
+		t.Fatalf("inserts = %s, want 3", got)
+	}
+	if got := m.Get("rotations").String(); got != "1" {
+		t.Fatalf("rotations = %s, want 1", got)
+	}
+	if got := m.Get("height").String(); got != "2" {
+		t.Fatalf("height = %s, want 2", got)
+	}
+
+	plain := expvar.NewMap("metrics_test_expose_plain")
+	Expose(plainSource{}, plain)
+	if plain.Get("height") != nil || plain.Get("size") == nil {
+		t.Fatalf("height should only be published for sources with Height")
+	}
+}
+
+func TestCollector(t *testing.T) {
+	c := NewCollector("app")
+	c.Add("users", fixedSource{size: 5, height: 3, m: rbtree.Metrics{Rotations: 2, Inserts: 5}})
+	c.Add(`odd "name"`, fixedSource{size: 1, height: 1})
+
+	var b strings.Builder
+	n, err := c.WriteTo(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	if int(n) != len(out) {
+		t.Fatalf("WriteTo reported %d bytes, wrote %d", n, len(out))
+	}
+	for _, want := range []string{
+		"# HELP app_rbtree_size Number of live elements.\n# TYPE app_rbtree_size gauge\napp_rbtree_size{tree=\"users\"} 5\napp_rbtree_size{tree=\"odd \\\"name\\\"\"} 1\n",
+		"app_rbtree_height{tree=\"users\"} 3\n",
+		"# TYPE app_rbtree_rotations_total counter\napp_rbtree_rotations_total{tree=\"users\"} 2\n",
+		"app_rbtree_inserts_total{tree=\"users\"} 5\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Body.String() != out || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Fatalf("ServeHTTP should serve the WriteTo output")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("registering the same name twice should panic")
+		}
+	}()
+	c.Add("users", fixedSource{})
+}
+
+func TestHeightOncePerScrape(t *testing.T) {
+	src := &countingSource{fixedSource: fixedSource{size: 4, height: 3}}
+	m := expvar.NewMap("metrics_test_height_once")
+	Expose(src, m)
+	if src.heights != 0 {
+		t.Fatalf("Expose called Height %d times before any scrape", src.heights)
+	}
+	m.Do(func(kv expvar.KeyValue) { _ = kv.Value.String() })
+	if src.heights != 1 {
+		t.Fatalf("one expvar scrape called Height %d times, want 1", src.heights)
+	}
+
+	src.heights = 0
+	c := NewCollector("")
+	c.Add("t", src)
+	if _, err := c.WriteTo(io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if src.heights != 1 {
+		t.Fatalf("one Collector scrape called Height %d times, want 1", src.heights)
+	}
+}
@@ -0,0 +1,61 @@
+package rbtree
+
+import "testing"
+
+func TestMetrics(t *testing.T) {
+	tree := New[int, int]()
+	tree.Insert(10, 10)
+	tree.Insert(20, 20)
+	tree.Insert(30, 30)
+	// 첫 삽입에서 루트를 검정으로 한 번, 30의 보정에서 20·10의 색을 바꾸고 왼쪽으로 한 번 돈다.
+	got := tree.Metrics()
+	want := Metrics{Rotations: 1, Recolors: 3, FixupIterations: 1, Inserts: 3}
+	if got != want {
+		t.Fatalf("Metrics() = %+v, want %+v", got, want)
+	}
+
+	tree.ResetMetrics()
+	if got := tree.Metrics(); got != (Metrics{}) {
+		t.Fatalf("after ResetMetrics: %+v", got)
+	}
+
+	for i := 0; i < 1000; i++ {
+		tree.Insert(i, i)
+	}
+	for i := 0; i < 1000; i += 2 {
+		tree.Delete(i)
+	}
+	tree.DeleteRange(900, 1000)
+	m := tree.Metrics()
+	if m.Rotations == 0 || m.Recolors == 0 || m.FixupIterations == 0 || m.Comparisons != 0 {
+		t.Fatalf("unexpected metrics after bulk work: %+v", m)
+	}
+	// 10, 20, 30은 이미 있었으므로 값 갱신이다.
+	if m.Inserts != 997 || m.Updates != 3 || m.Deletes != 500+50 {
+		t.Fatalf("Inserts = %d, Updates = %d, Deletes = %d; want 997, 3, 550", m.Inserts, m.Updates, m.Deletes)
+	}
+}
+
+func TestCountComparisons(t *testing.T) {
+	tree := New[int, int]()
+	for i := 0; i < 7; i++ {
+		tree.Insert(i, i)
+	}
+	tree.CountComparisons(true)
+	tree.Get(tree.Root().Key) // 루트 키는 한 번 비교로 찾는다.
+	if got := tree.Metrics().Comparisons; got != 1 {
+		t.Fatalf("Comparisons = %d, want 1", got)
+	}
+
+	clone := tree.Clone()
+	clone.Get(6)
+	if got := tree.Metrics().Comparisons; got != 1 {
+		t.Fatalf("clone comparisons leaked into the original: %d", got)
+	}
+
+	tree.CountComparisons(false)
+	tree.Get(6)
+	if got := tree.Metrics().Comparisons; got != 1 {
+		t.Fatalf("Comparisons after turning counting off = %d, want 1", got)
+	}
+}
@@ -0,0 +1,77 @@
+package rbtree
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"fmt"
+)
+
+// RawPair는 타입 정보에 묶이지 않은 키/값 한 쌍이다. Key와 Value는 각각 독립된
+// gob 스트림이라 어떤 쪽이든 원래 타입 없이도 따로 디코딩할 수 있다.
+type RawPair struct {
+	Key   []byte
+	Value []byte
+}
+
+// ExportForMigration은 트리의 모든 원소를 키 순서대로 RawPair로 내보낸다.
+// 값 타입 V의 정의가 바뀌는 경우(V1 구조체 → V2 구조체 등) 옛 타입으로 내보낸 뒤
+// ImportFromMigration에 새 타입용 디코더를 넘겨 트리를 다시 만들면 된다.
+// gob으로 인코딩할 수 없는 키나 값(함수, 채널 등)을 만나면 에러를 돌려준다.
+func ExportForMigration[K any, V any](t *Tree[K, V]) ([]RawPair, error) {
+	pairs := make([]RawPair, 0, t.Size())
+	var err error
+	t.InOrder(func(key K, value V) {
+		if err != nil {
+			return
+		}
+		var pair RawPair
+		if pair.Key, err = encodeGob(key); err != nil {
+			err = fmt.Errorf("rbtree: encode key %v: %w", key, err)
+			return
+		}
+		if pair.Value, err = encodeGob(value); err != nil {
+			err = fmt.Errorf("rbtree: encode value of key %v: %w", key, err)
+			return
+		}
+		pairs = append(pairs, pair)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pairs, nil
+}
+
+// ImportFromMigration은 RawPair 목록을 주어진 디코더로 풀어 새 트리를 만든다.
+// 디코딩 결과 키가 겹치면 Insert와 마찬가지로 나중 값이 남는다. 첫 번째 디코딩 에러에서 멈춘다.
+func ImportFromMigration[K cmp.Ordered, V any](pairs []RawPair, keyDecode func([]byte) (K, error), valueDecode func([]byte) (V, error)) (*Tree[K, V], error) {
+	t := New[K, V]()
+	for i, pair := range pairs {
+		key, err := keyDecode(pair.Key)
+		if err != nil {
+			return nil, fmt.Errorf("rbtree: decode key of pair %d: %w", i, err)
+		}
+		value, err := valueDecode(pair.Value)
+		if err != nil {
+			return nil, fmt.Errorf("rbtree: decode value of pair %d: %w", i, err)
+		}
+		t.Insert(key, value)
+	}
+	return t, nil
+}
+
+// DecodeGob은 ExportForMigration이 만든 바이트를 T로 그대로 디코딩하는 기본 디코더다.
+// gob은 필드 이름으로 대응시키므로 필드가 추가되거나 빠진 구조체로도 바로 풀 수 있다.
+func DecodeGob[T any](b []byte) (T, error) {
+	var v T
+	err := gob.NewDecoder(bytes.NewReader(b)).Decode(&v)
+	return v, err
+}
+
+func encodeGob(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,87 @@
+package rbtree
+
+import (
+	"strings"
+	"testing"
+)
+
+type userV1 struct {
+	Name string
+	Age  int
+}
+
+type userV2 struct {
+	FullName string
+	Age      int
+	Adult    bool
+}
+
+func TestMigrationRoundTrip(t *testing.T) {
+	old := New[string, userV1]()
+	old.Insert("kim", userV1{Name: "Kim", Age: 17})
+	old.Insert("lee", userV1{Name: "Lee", Age: 30})
+	old.Insert("park", userV1{Name: "Park", Age: 45})
+
+	pairs, err := ExportForMigration(old)
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if len(pairs) != old.Size() {
+		t.Fatalf("expected %d pairs, got %d", old.Size(), len(pairs))
+	}
+
+	// V1을 풀어서 V2로 옮기는 디코더.
+	upgrade := func(b []byte) (userV2, error) {
+		v1, err := DecodeGob[userV1](b)
+		if err != nil {
+			return userV2{}, err
+		}
+		return userV2{FullName: v1.Name, Age: v1.Age, Adult: v1.Age >= 18}, nil
+	}
+	migrated, err := ImportFromMigration(pairs, DecodeGob[string], upgrade)
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if migrated.Size() != old.Size() {
+		t.Fatalf("expected size %d, got %d", old.Size(), migrated.Size())
+	}
+	old.InOrder(func(key string, v1 userV1) {
+		node := migrated.Search(key)
+		if node == nil {
+			t.Fatalf("key %q lost in migration", key)
+		}
+		want := userV2{FullName: v1.Name, Age: v1.Age, Adult: v1.Age >= 18}
+		if node.Value != want {
+			t.Fatalf("key %q expected %+v got %+v", key, want, node.Value)
+		}
+	})
+	assertRBProperties(t, migrated)
+
+	// 키 스킴도 함께 바꿀 수 있다. 대문자 키로 바꿔도 순서와 개수가 유지되어야 한다.
+	upper := func(b []byte) (string, error) {
+		k, err := DecodeGob[string](b)
+		return strings.ToUpper(k), err
+	}
+	rekeyed, err := ImportFromMigration(pairs, upper, DecodeGob[userV1])
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if rekeyed.Search("LEE") == nil {
+		t.Fatalf("expected rekeyed tree to contain LEE")
+	}
+}
+
+func TestImportFromMigrationError(t *testing.T) {
+	pairs := []RawPair{{Key: []byte("garbage"), Value: nil}}
+	if _, err := ImportFromMigration(pairs, DecodeGob[int], DecodeGob[int]); err == nil {
+		t.Fatalf("expected decode error for corrupted key")
+	}
+}
+
+func TestExportForMigrationUnsupportedValue(t *testing.T) {
+	tree := New[int, func()]()
+	tree.Insert(1, func() {})
+	if _, err := ExportForMigration(tree); err == nil {
+		t.Fatalf("expected error when exporting func values")
+	}
+}
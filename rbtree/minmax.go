@@ -0,0 +1,45 @@
+package rbtree
+
+// Min은 가장 작은 키의 노드를 돌려준다. 트리가 비었으면 nil이다.
+func (t *Tree[K, V]) Min() *Node[K, V] {
+	return t.first()
+}
+
+// Max는 가장 큰 키의 노드를 돌려준다. 트리가 비었으면 nil이다.
+func (t *Tree[K, V]) Max() *Node[K, V] {
+	return t.last()
+}
+
+// DeleteMin은 가장 작은 키를 삭제하고, 삭제했으면 true를 돌려준다.
+func (t *Tree[K, V]) DeleteMin() bool {
+	_, _, ok := t.PopMin()
+	return ok
+}
+
+// DeleteMax는 가장 큰 키를 삭제하고, 삭제했으면 true를 돌려준다.
+func (t *Tree[K, V]) DeleteMax() bool {
+	_, _, ok := t.PopMax()
+	return ok
+}
+
+// PopMin은 가장 작은 원소를 꺼내 그 키와 값을 돌려준다. Search 후 Delete처럼 두 번 내려가지 않고
+// 왼쪽 끝 노드를 바로 떼어 내므로 트리를 순서 있는 큐로 쓸 수 있다. 비었으면 ok가 false다.
+func (t *Tree[K, V]) PopMin() (key K, value V, ok bool) {
+	t.willWrite()
+	return t.pop(t.first())
+}
+
+// PopMax는 PopMin의 대칭으로, 가장 큰 원소를 꺼낸다.
+func (t *Tree[K, V]) PopMax() (key K, value V, ok bool) {
+	t.willWrite()
+	return t.pop(t.last())
+}
+
+func (t *Tree[K, V]) pop(node *Node[K, V]) (key K, value V, ok bool) {
+	if node == nil {
+		return key, value, false
+	}
+	key, value = node.Key, node.Value
+	t.remove(node)
+	return key, value, true
+}
@@ -0,0 +1,51 @@
+package rbtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMinMax(t *testing.T) {
+	tree := New[int, int]()
+	if tree.Min() != nil || tree.Max() != nil {
+		t.Fatalf("Min/Max of empty tree should be nil")
+	}
+	for _, k := range rand.Perm(50) {
+		tree.Insert(k, k)
+	}
+	if tree.Min().Key != 0 || tree.Max().Key != 49 {
+		t.Fatalf("expected Min 0 and Max 49, got %d and %d", tree.Min().Key, tree.Max().Key)
+	}
+}
+
+func TestPopMinMax(t *testing.T) {
+	tree := New[int, string]()
+	for _, k := range rand.Perm(100) {
+		tree.Insert(k, "v")
+	}
+
+	for want := 0; want < 50; want++ {
+		key, value, ok := tree.PopMin()
+		if !ok || key != want || value != "v" {
+			t.Fatalf("PopMin expected %d, got %d (ok=%v)", want, key, ok)
+		}
+		assertRBProperties(t, tree)
+	}
+	for want := 99; want >= 60; want-- {
+		key, _, ok := tree.PopMax()
+		if !ok || key != want {
+			t.Fatalf("PopMax expected %d, got %d (ok=%v)", want, key, ok)
+		}
+	}
+	if !tree.DeleteMin() || !tree.DeleteMax() {
+		t.Fatalf("DeleteMin/DeleteMax should succeed on a non-empty tree")
+	}
+	if tree.Min().Key != 51 || tree.Max().Key != 58 || tree.Size() != 8 {
+		t.Fatalf("expected keys 51..58 to remain, got %d..%d (size %d)", tree.Min().Key, tree.Max().Key, tree.Size())
+	}
+	for tree.DeleteMin() {
+	}
+	if _, _, ok := tree.PopMax(); ok || tree.Size() != 0 {
+		t.Fatalf("popping an empty tree should fail")
+	}
+}
@@ -0,0 +1,93 @@
+package rbtree
+
+import (
+	"cmp"
+	"iter"
+)
+
+// MultiMap은 같은 키를 여러 번 담을 수 있는 정렬 맵이다. 키마다 값 목록을 한 노드에 두므로
+// 트리의 균형과 탐색 비용은 서로 다른 키의 수에만 좌우되고, 같은 키의 값들은 넣은 순서를 지킨다.
+// 타임스탬프처럼 충돌이 잦은 키로 이벤트를 색인할 때 쓴다.
+type MultiMap[K any, V any] struct {
+	tree *Tree[K, []V]
+	size int
+}
+
+// NewMultiMap은 빈 MultiMap을 만든다.
+func NewMultiMap[K cmp.Ordered, V any]() *MultiMap[K, V] {
+	return &MultiMap[K, V]{tree: New[K, []V]()}
+}
+
+// NewMultiMapFunc는 less로 키 순서를 정하는 빈 MultiMap을 만든다. less의 조건은 NewFunc와 같다.
+func NewMultiMapFunc[K any, V any](less func(a, b K) bool) *MultiMap[K, V] {
+	return &MultiMap[K, V]{tree: NewFunc[K, []V](less)}
+}
+
+// Insert는 key에 value를 더한다. 이미 같은 키가 있으면 그 값들 뒤에 붙는다.
+func (m *MultiMap[K, V]) Insert(key K, value V) {
+	m.tree.Update(key, func(old []V, exists bool) ([]V, bool) {
+		return append(old, value), true
+	})
+	m.size++
+}
+
+// Get은 key에 담긴 값들을 넣은 순서대로 돌려준다. 키가 없으면 nil이다.
+// 돌려준 슬라이스는 내부 저장소이므로 고치지 말아야 한다.
+func (m *MultiMap[K, V]) Get(key K) []V {
+	values, _ := m.tree.Get(key)
+	return values
+}
+
+// Count는 key에 담긴 값의 수를 돌려준다.
+func (m *MultiMap[K, V]) Count(key K) int {
+	return len(m.Get(key))
+}
+
+// DeleteOne은 key에 담긴 값 중 가장 먼저 넣은 것 하나를 지우고, 지웠으면 true를 돌려준다.
+// 마지막 값이 빠지면 키도 함께 사라진다.
+func (m *MultiMap[K, V]) DeleteOne(key K) bool {
+	deleted := false
+	m.tree.Update(key, func(old []V, exists bool) ([]V, bool) {
+		if !exists {
+			return nil, false
+		}
+		deleted = true
+		var zero V
+		old[0] = zero // 빠진 값이 붙잡고 있는 메모리를 놓아 준다.
+		return old[1:], len(old) > 1
+	})
+	if deleted {
+		m.size--
+	}
+	return deleted
+}
+
+// DeleteAll은 key와 거기 담긴 값을 모두 지우고 지운 값의 수를 돌려준다.
+func (m *MultiMap[K, V]) DeleteAll(key K) int {
+	values, _ := m.tree.Pop(key)
+	m.size -= len(values)
+	return len(values)
+}
+
+// Len은 담긴 값의 총수(같은 키의 값도 각각 센다)를 돌려준다.
+func (m *MultiMap[K, V]) Len() int {
+	return m.size
+}
+
+// KeyCount는 서로 다른 키의 수를 돌려준다.
+func (m *MultiMap[K, V]) KeyCount() int {
+	return m.tree.Size()
+}
+
+// All은 모든 (키, 값) 쌍을 키 오름차순으로, 같은 키 안에서는 넣은 순서로 내놓는다.
+func (m *MultiMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, values := range m.tree.All() {
+			for _, v := range values {
+				if !yield(key, v) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package rbtree
+
+import "testing"
+
+func TestMultiMap(t *testing.T) {
+	m := NewMultiMap[int, string]()
+	m.Insert(2, "b1")
+	m.Insert(1, "a1")
+	m.Insert(2, "b2")
+	m.Insert(2, "b3")
+	if m.Len() != 4 || m.KeyCount() != 2 || m.Count(2) != 3 {
+		t.Fatalf("unexpected sizes: len %d, keys %d, count(2) %d", m.Len(), m.KeyCount(), m.Count(2))
+	}
+
+	var got []string
+	for _, v := range m.All() {
+		got = append(got, v)
+	}
+	want := []string{"a1", "b1", "b2", "b3"}
+	if len(got) != len(want) {
+		t.Fatalf("All expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("All should yield keys in order and duplicates in insertion order, got %v", got)
+		}
+	}
+
+	if !m.DeleteOne(2) || m.Get(2)[0] != "b2" {
+		t.Fatalf("DeleteOne should remove the oldest value, left %v", m.Get(2))
+	}
+	if n := m.DeleteAll(2); n != 2 || m.Get(2) != nil {
+		t.Fatalf("DeleteAll removed %d, left %v", n, m.Get(2))
+	}
+	if !m.DeleteOne(1) || m.KeyCount() != 0 || m.Len() != 0 {
+		t.Fatalf("removing the last value should drop the key")
+	}
+	if m.DeleteOne(1) || m.DeleteAll(1) != 0 {
+		t.Fatalf("deleting a missing key should report nothing removed")
+	}
+}
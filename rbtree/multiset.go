@@ -0,0 +1,84 @@
+package rbtree
+
+import (
+	"cmp"
+	"iter"
+)
+
+// MultiSet은 키마다 등장 횟수를 세는 정렬된 빈도표다. 같은 키를 더하면 횟수가 늘고,
+// 지우면 하나씩 줄어 0이 되면 키가 사라진다. 외부 맵 없이 키 순서대로 빈도를 훑을 수 있다.
+type MultiSet[K any] struct {
+	tree *Tree[K, int]
+	size int
+}
+
+// NewMultiSet은 빈 MultiSet을 만든다.
+func NewMultiSet[K cmp.Ordered]() *MultiSet[K] {
+	return &MultiSet[K]{tree: New[K, int]()}
+}
+
+// NewMultiSetFunc는 less로 키 순서를 정하는 빈 MultiSet을 만든다. less의 조건은 NewFunc와 같다.
+func NewMultiSetFunc[K any](less func(a, b K) bool) *MultiSet[K] {
+	return &MultiSet[K]{tree: NewFunc[K, int](less)}
+}
+
+// Add는 key의 횟수를 1 늘리고 늘어난 횟수를 돌려준다.
+func (s *MultiSet[K]) Add(key K) int {
+	return s.AddN(key, 1)
+}
+
+// AddN은 key의 횟수를 n만큼 늘리고 늘어난 횟수를 돌려준다. n이 0 이하면 아무 일도 하지 않는다.
+func (s *MultiSet[K]) AddN(key K, n int) int {
+	if n <= 0 {
+		return s.Count(key)
+	}
+	var count int
+	s.tree.Update(key, func(old int, exists bool) (int, bool) {
+		count = old + n
+		return count, true
+	})
+	s.size += n
+	return count
+}
+
+// Delete는 key의 횟수를 1 줄이고 남은 횟수를 돌려준다. 0이 되면 키가 사라지고,
+// 원래 없던 키면 0을 돌려준다.
+func (s *MultiSet[K]) Delete(key K) int {
+	var count int
+	s.tree.Update(key, func(old int, exists bool) (int, bool) {
+		if exists {
+			s.size--
+			count = old - 1
+		}
+		return count, count > 0
+	})
+	return count
+}
+
+// DeleteAll은 key를 횟수와 상관없이 지우고 지우기 전의 횟수를 돌려준다.
+func (s *MultiSet[K]) DeleteAll(key K) int {
+	count, _ := s.tree.Pop(key)
+	s.size -= count
+	return count
+}
+
+// Count는 key의 횟수를 돌려준다. 없는 키면 0이다.
+func (s *MultiSet[K]) Count(key K) int {
+	count, _ := s.tree.Get(key)
+	return count
+}
+
+// Len은 모든 키의 횟수를 더한 값이다.
+func (s *MultiSet[K]) Len() int {
+	return s.size
+}
+
+// Distinct는 서로 다른 키의 수를 돌려준다.
+func (s *MultiSet[K]) Distinct() int {
+	return s.tree.Size()
+}
+
+// All은 (키, 횟수) 쌍을 키 오름차순으로 내놓는다.
+func (s *MultiSet[K]) All() iter.Seq2[K, int] {
+	return s.tree.All()
+}
@@ -0,0 +1,42 @@
+package rbtree
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMultiSet(t *testing.T) {
+	s := NewMultiSet[string]()
+	for _, word := range []string{"b", "a", "b", "c", "b"} {
+		s.Add(word)
+	}
+	if s.Count("b") != 3 || s.Len() != 5 || s.Distinct() != 3 {
+		t.Fatalf("unexpected counts: b=%d len=%d distinct=%d", s.Count("b"), s.Len(), s.Distinct())
+	}
+	if got := s.AddN("a", 4); got != 5 {
+		t.Fatalf("AddN should return the new count, got %d", got)
+	}
+
+	var keys []string
+	var counts []int
+	for k, n := range s.All() {
+		keys = append(keys, k)
+		counts = append(counts, n)
+	}
+	if len(keys) != 3 || keys[0] != "a" || keys[2] != "c" || !slices.Equal(counts, []int{5, 3, 1}) {
+		t.Fatalf("All expected a:5 b:3 c:1, got %v %v", keys, counts)
+	}
+
+	if got := s.Delete("c"); got != 0 || s.Distinct() != 2 {
+		t.Fatalf("deleting the last occurrence should drop the key, got %d", got)
+	}
+	if got := s.Delete("b"); got != 2 {
+		t.Fatalf("Delete should return the remaining count, got %d", got)
+	}
+	if got := s.Delete("missing"); got != 0 || s.Len() != 7 {
+		t.Fatalf("deleting a missing key should change nothing, got %d len %d", got, s.Len())
+	}
+	if got := s.DeleteAll("a"); got != 5 || s.Len() != 2 {
+		t.Fatalf("DeleteAll removed %d, len %d", got, s.Len())
+	}
+}
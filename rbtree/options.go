@@ -0,0 +1,46 @@
+package rbtree
+
+import (
+	"log/slog"
+	"sync"
+)
+
+// Option은 New, NewFunc, NewBounded, NewWithTombstones에 넘겨 트리의 부가 동작을 켠다.
+// 키·값 타입과 무관한 설정만 담으므로 같은 Option 값을 여러 트리에 재사용할 수 있다.
+type Option func(*options)
+
+// options는 Option들이 채우는 설정이다. 생성자가 apply로 Tree에 옮겨 담는다.
+type options struct {
+	logger    *slog.Logger
+	logLevels LogLevels
+	debug     bool
+	pool      bool
+	balancing Balancing
+	// sized는 WithMaxSize를 받았는지 뜻한다. maxSize와 eviction은 그때만 쓴다.
+	sized    bool
+	maxSize  int
+	eviction EvictionPolicy
+}
+
+// apply는 opts를 차례로 적용해 t에 반영하고 t를 돌려준다.
+func (t *Tree[K, V]) apply(opts []Option) *Tree[K, V] {
+	if len(opts) == 0 {
+		return t
+	}
+	o := options{logLevels: defaultLogLevels}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.logger != nil {
+		t.log = &treeLogger{l: o.logger, levels: o.logLevels}
+	}
+	t.debug = o.debug
+	t.balance = newBalancer[K, V](o.balancing)
+	if o.pool {
+		t.nodes = new(sync.Pool)
+	}
+	if o.sized {
+		t.setEviction(o.maxSize, o.eviction)
+	}
+	return t
+}
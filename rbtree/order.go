@@ -0,0 +1,64 @@
+package rbtree
+
+// Rank는 key보다 작은 키의 개수를 돌려준다. key가 트리에 있으면 그 키의 0부터 센 순위와 같고,
+// 없으면 key를 넣었을 때 들어갈 자리다. 노드마다 유지하는 서브트리 크기 덕분에 O(log n)이다.
+func (t *Tree[K, V]) Rank(key K) int {
+	rank := 0
+	for cur := t.root; cur != nil; {
+		if t.compare(key, cur.Key) <= 0 {
+			cur = cur.Left
+			continue
+		}
+		rank += countOf(cur.Left)
+		if !cur.deleted {
+			rank++
+		}
+		cur = cur.Right
+	}
+	return rank
+}
+
+// Select는 0부터 센 i번째로 작은 원소의 키와 값을 돌려준다. i가 범위를 벗어나면 ok가 false다.
+func (t *Tree[K, V]) Select(i int) (key K, value V, ok bool) {
+	node := t.selectNode(i)
+	if node == nil {
+		return key, value, false
+	}
+	return node.Key, node.Value, true
+}
+
+// selectNode는 서브트리 크기를 따라 내려가 i번째 살아 있는 노드를 찾는다.
+func (t *Tree[K, V]) selectNode(i int) *Node[K, V] {
+	if i < 0 || i >= t.size {
+		return nil
+	}
+	cur := t.root
+	for cur != nil {
+		left := countOf(cur.Left)
+		switch {
+		case i < left:
+			cur = cur.Left
+		case i == left && !cur.deleted:
+			return cur
+		default:
+			i -= left
+			if !cur.deleted {
+				i--
+			}
+			cur = cur.Right
+		}
+	}
+	return nil
+}
+
+// At은 0부터 센 i번째로 작은 원소의 노드를 돌려준다. 트리를 정렬된 배열처럼 인덱스로 읽을 때 쓴다.
+// i가 범위를 벗어나면 nil이다.
+func (t *Tree[K, V]) At(i int) *Node[K, V] {
+	return t.selectNode(i)
+}
+
+// DeleteAt은 i번째 원소를 삭제하고 그 키와 값을 돌려준다. i가 범위를 벗어나면 ok가 false다.
+func (t *Tree[K, V]) DeleteAt(i int) (key K, value V, ok bool) {
+	t.willWrite()
+	return t.pop(t.selectNode(i))
+}
@@ -0,0 +1,92 @@
+package rbtree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestRankSelect(t *testing.T) {
+	for _, tree := range []*Tree[int, int]{New[int, int](), NewWithTombstones[int, int]()} {
+		present := map[int]bool{}
+		for i := 0; i < 2000; i++ {
+			key := rand.Intn(500)
+			if rand.Intn(3) == 0 {
+				tree.Delete(key)
+				delete(present, key)
+			} else {
+				tree.Insert(key, key*2)
+				present[key] = true
+			}
+		}
+		assertRBProperties(t, tree)
+
+		var sorted []int
+		for k := range present {
+			sorted = append(sorted, k)
+		}
+		sort.Ints(sorted)
+
+		for i, k := range sorted {
+			if got := tree.Rank(k); got != i {
+				t.Fatalf("Rank(%d) expected %d, got %d", k, i, got)
+			}
+			key, value, ok := tree.Select(i)
+			if !ok || key != k || value != k*2 {
+				t.Fatalf("Select(%d) expected %d, got %d (ok=%v)", i, k, key, ok)
+			}
+		}
+		if got := tree.Rank(-1); got != 0 {
+			t.Fatalf("Rank below all keys should be 0, got %d", got)
+		}
+		if got := tree.Rank(1000); got != len(sorted) {
+			t.Fatalf("Rank above all keys should be %d, got %d", len(sorted), got)
+		}
+		if _, _, ok := tree.Select(len(sorted)); ok {
+			t.Fatalf("Select past the end should fail")
+		}
+		if _, _, ok := tree.Select(-1); ok {
+			t.Fatalf("Select with a negative index should fail")
+		}
+
+		tree.Compact(nil)
+		assertRBProperties(t, tree)
+	}
+}
+
+func TestAtAndDeleteAt(t *testing.T) {
+	tree := New[string, int]()
+	seq := []string{"a", "b", "c", "d", "e", "f", "g"}
+	for i, k := range seq {
+		tree.Insert(k, i)
+	}
+
+	for i, k := range seq {
+		if node := tree.At(i); node == nil || node.Key != k {
+			t.Fatalf("At(%d) expected %q", i, k)
+		}
+	}
+	if tree.At(len(seq)) != nil || tree.At(-1) != nil {
+		t.Fatalf("At out of range should be nil")
+	}
+
+	// 가운데, 맨 앞, 맨 뒤 순서로 지우며 남은 인덱스가 당겨지는지 확인한다.
+	for _, step := range []struct {
+		index int
+		key   string
+	}{{3, "d"}, {0, "a"}, {4, "g"}, {1, "c"}} {
+		key, value, ok := tree.DeleteAt(step.index)
+		if !ok || key != step.key || value != int(step.key[0]-'a') {
+			t.Fatalf("DeleteAt(%d) expected %q, got %q (ok=%v)", step.index, step.key, key, ok)
+		}
+		assertRBProperties(t, tree)
+	}
+	var rest []string
+	tree.InOrder(func(key string, _ int) { rest = append(rest, key) })
+	if len(rest) != 3 || rest[0] != "b" || rest[1] != "e" || rest[2] != "f" {
+		t.Fatalf("expected [b e f] to remain, got %v", rest)
+	}
+	if _, _, ok := tree.DeleteAt(3); ok {
+		t.Fatalf("DeleteAt out of range should fail")
+	}
+}
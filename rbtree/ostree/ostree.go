@@ -0,0 +1,388 @@
+// Package ostree는 노드마다 서브트리 크기를 들고 있어 순위와 k번째 원소를 O(log n)에 구하는
+// 순서 통계 레드블랙 트리를 제공한다.
+//
+// rbtree.Tree도 Rank와 Select를 위해 서브트리 크기를 유지하지만, 톰스톤, 스냅숏, 훅, 기록기 같은
+// 기능 때문에 노드와 트리가 무겁다. 이 패키지의 트리는 키, 값, 링크 세 개, 크기, 색만 가진 노드로
+// 순서 통계만 제공한다. 순위를 셀 일이 없으면 순서 통계 비용이 아예 없는 arena나 persistent를 쓴다.
+//
+// CLRS 14장처럼 잎의 자식과 루트의 부모는 트리마다 하나 있는 NIL 경계 노드를 가리킨다. 경계 노드의
+// 크기는 0이고 색은 검정이므로 회전과 보정에서 nil 검사를 하지 않아도 된다.
+package ostree
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Tree는 순서 통계 레드블랙 트리다. 제로값은 쓸 수 없으므로 New나 NewFunc로 만든다.
+// 여러 고루틴이 함께 쓰려면 호출하는 쪽에서 잠가야 한다.
+type Tree[K any, V any] struct {
+	root     *node[K, V]
+	sentinel *node[K, V] // NIL 경계 노드
+	compare  func(a, b K) int
+}
+
+type node[K any, V any] struct {
+	key                 K
+	value               V
+	left, right, parent *node[K, V]
+	size                int // 이 노드를 뿌리로 하는 서브트리의 원소 수
+	red                 bool
+}
+
+// New는 K의 기본 순서를 쓰는 빈 트리를 만든다.
+func New[K cmp.Ordered, V any]() *Tree[K, V] {
+	return newTree[K, V](cmp.Compare[K])
+}
+
+// NewFunc는 less로 키 순서를 정하는 빈 트리를 만든다. less는 엄격한 약순서여야 하며,
+// less(a, b)와 less(b, a)가 모두 false인 두 키는 같은 키로 취급한다.
+func NewFunc[K any, V any](less func(a, b K) bool) *Tree[K, V] {
+	return newTree[K, V](func(a, b K) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	})
+}
+
+func newTree[K any, V any](compare func(a, b K) int) *Tree[K, V] {
+	sentinel := &node[K, V]{}
+	sentinel.left, sentinel.right, sentinel.parent = sentinel, sentinel, sentinel
+	return &Tree[K, V]{root: sentinel, sentinel: sentinel, compare: compare}
+}
+
+// Size는 원소 수를 돌려준다.
+func (t *Tree[K, V]) Size() int {
+	return t.root.size
+}
+
+// Get은 키에 대응하는 값을 돌려준다. 없으면 V의 제로값과 false다.
+func (t *Tree[K, V]) Get(key K) (V, bool) {
+	if n := t.find(key); n != t.sentinel {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains는 키가 트리에 있는지 알려 준다.
+func (t *Tree[K, V]) Contains(key K) bool {
+	return t.find(key) != t.sentinel
+}
+
+func (t *Tree[K, V]) find(key K) *node[K, V] {
+	n := t.root
+	for n != t.sentinel {
+		switch c := t.compare(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return t.sentinel
+}
+
+// Min은 가장 작은 원소를 돌려준다. 비었으면 ok가 false다.
+func (t *Tree[K, V]) Min() (key K, value V, ok bool) {
+	return t.Select(0)
+}
+
+// Max는 가장 큰 원소를 돌려준다. 비었으면 ok가 false다.
+func (t *Tree[K, V]) Max() (key K, value V, ok bool) {
+	return t.Select(t.Size() - 1)
+}
+
+// Rank는 key가 트리에 있으면 0부터 센 그 키의 순위와 true를, 없으면 0과 false를 돌려준다.
+// 키가 없어도 들어갈 자리를 알고 싶으면 CountLess를 쓴다.
+func (t *Tree[K, V]) Rank(key K) (int, bool) {
+	rank := 0
+	for n := t.root; n != t.sentinel; {
+		switch c := t.compare(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			rank += n.left.size + 1
+			n = n.right
+		default:
+			return rank + n.left.size, true
+		}
+	}
+	return 0, false
+}
+
+// Select는 0부터 센 i번째로 작은 원소를 돌려준다. i가 범위를 벗어나면 ok가 false다.
+func (t *Tree[K, V]) Select(i int) (key K, value V, ok bool) {
+	if i < 0 || i >= t.Size() {
+		return key, value, false
+	}
+	n := t.root
+	for {
+		switch left := n.left.size; {
+		case i < left:
+			n = n.left
+		case i > left:
+			i -= left + 1
+			n = n.right
+		default:
+			return n.key, n.value, true
+		}
+	}
+}
+
+// CountLess는 key보다 작은 키의 개수, 즉 key를 넣었을 때 들어갈 자리를 돌려준다.
+func (t *Tree[K, V]) CountLess(key K) int {
+	count := 0
+	for n := t.root; n != t.sentinel; {
+		if t.compare(key, n.key) <= 0 {
+			n = n.left
+		} else {
+			count += n.left.size + 1
+			n = n.right
+		}
+	}
+	return count
+}
+
+// CountBetween은 [lo, hi) 구간에 있는 키의 개수를 O(log n)에 돌려준다. lo >= hi이면 0이다.
+func (t *Tree[K, V]) CountBetween(lo, hi K) int {
+	return max(t.CountLess(hi)-t.CountLess(lo), 0)
+}
+
+// Insert는 key에 value를 넣는다. 키가 이미 있으면 값만 바꾼다.
+func (t *Tree[K, V]) Insert(key K, value V) {
+	parent, n := t.sentinel, t.root
+	c := 0
+	for n != t.sentinel {
+		parent = n
+		c = t.compare(key, n.key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			n.value = value
+			return
+		}
+	}
+
+	z := &node[K, V]{key: key, value: value, left: t.sentinel, right: t.sentinel, parent: parent, size: 1, red: true}
+	switch {
+	case parent == t.sentinel:
+		t.root = z
+	case c < 0:
+		parent.left = z
+	default:
+		parent.right = z
+	}
+	for p := parent; p != t.sentinel; p = p.parent {
+		p.size++
+	}
+	t.insertFixup(z)
+}
+
+// insertFixup은 CLRS의 RB-INSERT-FIXUP이다. 회전이 크기를 고치므로 색만 신경 쓰면 된다.
+func (t *Tree[K, V]) insertFixup(z *node[K, V]) {
+	for z.parent.red {
+		p, g := z.parent, z.parent.parent
+		if p == g.left {
+			if u := g.right; u.red {
+				p.red, u.red, g.red = false, false, true
+				z = g
+				continue
+			}
+			if z == p.right {
+				z = p
+				t.rotateLeft(z)
+				p = z.parent
+			}
+			p.red, g.red = false, true
+			t.rotateRight(g)
+		} else {
+			if u := g.left; u.red {
+				p.red, u.red, g.red = false, false, true
+				z = g
+				continue
+			}
+			if z == p.left {
+				z = p
+				t.rotateRight(z)
+				p = z.parent
+			}
+			p.red, g.red = false, true
+			t.rotateLeft(g)
+		}
+	}
+	t.root.red = false
+}
+
+// Delete는 key를 지우고, 있었으면 true를 돌려준다.
+func (t *Tree[K, V]) Delete(key K) bool {
+	z := t.find(key)
+	if z == t.sentinel {
+		return false
+	}
+	y, removedRed := z, z.red
+	var x *node[K, V]
+	switch {
+	case z.left == t.sentinel:
+		x = z.right
+		t.transplant(z, x)
+	case z.right == t.sentinel:
+		x = z.left
+		t.transplant(z, x)
+	default:
+		y = minimum(t.sentinel, z.right)
+		removedRed = y.red
+		x = y.right
+		if y.parent == z {
+			x.parent = y // x가 경계 노드여도 보정이 부모를 찾아갈 수 있게 한다.
+		} else {
+			t.transplant(y, x)
+			y.right = z.right
+			y.right.parent = y
+		}
+		t.transplant(z, y)
+		y.left = z.left
+		y.left.parent = y
+		y.red = z.red
+	}
+	// 실제로 구조에서 빠진 자리(x의 부모)부터 루트까지 크기를 다시 센다.
+	for p := x.parent; p != t.sentinel; p = p.parent {
+		p.size = p.left.size + p.right.size + 1
+	}
+	if !removedRed {
+		t.deleteFixup(x)
+	}
+	t.sentinel.parent = t.sentinel
+	return true
+}
+
+// transplant는 u 자리에 v를 붙인다. v가 경계 노드여도 parent를 적어 둔다.
+func (t *Tree[K, V]) transplant(u, v *node[K, V]) {
+	switch p := u.parent; {
+	case p == t.sentinel:
+		t.root = v
+	case u == p.left:
+		p.left = v
+	default:
+		p.right = v
+	}
+	v.parent = u.parent
+}
+
+// deleteFixup은 CLRS의 RB-DELETE-FIXUP이다.
+func (t *Tree[K, V]) deleteFixup(x *node[K, V]) {
+	for x != t.root && !x.red {
+		p := x.parent
+		if x == p.left {
+			w := p.right
+			if w.red {
+				w.red, p.red = false, true
+				t.rotateLeft(p)
+				w = p.right
+			}
+			if !w.left.red && !w.right.red {
+				w.red = true
+				x = p
+				continue
+			}
+			if !w.right.red {
+				w.left.red, w.red = false, true
+				t.rotateRight(w)
+				w = p.right
+			}
+			w.red, p.red, w.right.red = p.red, false, false
+			t.rotateLeft(p)
+			x = t.root
+		} else {
+			w := p.left
+			if w.red {
+				w.red, p.red = false, true
+				t.rotateRight(p)
+				w = p.left
+			}
+			if !w.right.red && !w.left.red {
+				w.red = true
+				x = p
+				continue
+			}
+			if !w.left.red {
+				w.right.red, w.red = false, true
+				t.rotateLeft(w)
+				w = p.left
+			}
+			w.red, p.red, w.left.red = p.red, false, false
+			t.rotateRight(p)
+			x = t.root
+		}
+	}
+	x.red = false
+}
+
+// rotateLeft는 CLRS 14.1의 LEFT-ROTATE다. 올라간 y는 x의 크기를 물려받고 x는 다시 센다.
+func (t *Tree[K, V]) rotateLeft(x *node[K, V]) {
+	y := x.right
+	x.right = y.left
+	if y.left != t.sentinel {
+		y.left.parent = x
+	}
+	t.transplant(x, y)
+	y.left = x
+	x.parent = y
+	y.size = x.size
+	x.size = x.left.size + x.right.size + 1
+}
+
+func (t *Tree[K, V]) rotateRight(x *node[K, V]) {
+	y := x.left
+	x.left = y.right
+	if y.right != t.sentinel {
+		y.right.parent = x
+	}
+	t.transplant(x, y)
+	y.right = x
+	x.parent = y
+	y.size = x.size
+	x.size = x.left.size + x.right.size + 1
+}
+
+func minimum[K any, V any](sentinel, n *node[K, V]) *node[K, V] {
+	for n.left != sentinel {
+		n = n.left
+	}
+	return n
+}
+
+// All은 모든 원소를 키 오름차순으로 내놓는다. 순회 도중 트리를 고치면 안 된다.
+func (t *Tree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if t.root == t.sentinel {
+			return
+		}
+		for n := minimum(t.sentinel, t.root); n != t.sentinel; n = t.successor(n) {
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
+
+func (t *Tree[K, V]) successor(n *node[K, V]) *node[K, V] {
+	if n.right != t.sentinel {
+		return minimum(t.sentinel, n.right)
+	}
+	p := n.parent
+	for p != t.sentinel && n == p.right {
+		n, p = p, p.parent
+	}
+	return p
+}
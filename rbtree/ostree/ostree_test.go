@@ -0,0 +1,127 @@
+package ostree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// check는 레드블랙 규칙과 서브트리 크기를 확인하고 검정 높이를 돌려준다.
+func check[K any, V any](t *testing.T, tree *Tree[K, V], n *node[K, V]) int {
+	t.Helper()
+	if n == tree.sentinel {
+		return 1
+	}
+	if n.size != n.left.size+n.right.size+1 {
+		t.Fatalf("node %v has size %d, children %d+%d", n.key, n.size, n.left.size, n.right.size)
+	}
+	if n.red && (n.left.red || n.right.red) {
+		t.Fatalf("red node %v has a red child", n.key)
+	}
+	for _, c := range []*node[K, V]{n.left, n.right} {
+		if c != tree.sentinel && c.parent != n {
+			t.Fatalf("child %v of %v has a wrong parent", c.key, n.key)
+		}
+	}
+	l, r := check(t, tree, n.left), check(t, tree, n.right)
+	if l != r {
+		t.Fatalf("node %v has black heights %d and %d", n.key, l, r)
+	}
+	if !n.red {
+		l++
+	}
+	return l
+}
+
+func TestAgainstSortedSlice(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := New[int, int]()
+	var model []int
+	for i := 0; i < 20000; i++ {
+		k := rng.Intn(2000)
+		pos, found := slices.BinarySearch(model, k)
+		if rng.Intn(3) == 0 {
+			if tree.Delete(k) != found {
+				t.Fatalf("Delete(%d) disagreed with the model", k)
+			}
+			if found {
+				model = slices.Delete(model, pos, pos+1)
+			}
+		} else {
+			tree.Insert(k, -k)
+			if !found {
+				model = slices.Insert(model, pos, k)
+			}
+		}
+		if tree.sentinel.size != 0 || tree.sentinel.red {
+			t.Fatalf("sentinel was modified")
+		}
+		if i%500 == 0 {
+			check(t, tree, tree.root)
+		}
+	}
+	check(t, tree, tree.root)
+
+	if tree.Size() != len(model) {
+		t.Fatalf("Size = %d, want %d", tree.Size(), len(model))
+	}
+	for i, k := range model {
+		if got, v, ok := tree.Select(i); !ok || got != k || v != -k {
+			t.Fatalf("Select(%d) = %d, %d, %v, want %d", i, got, v, ok, k)
+		}
+		if r, ok := tree.Rank(k); !ok || r != i {
+			t.Fatalf("Rank(%d) = %d, %v, want %d", k, r, ok, i)
+		}
+	}
+	for probe := -5; probe < 2005; probe += 7 {
+		want, found := slices.BinarySearch(model, probe)
+		if got := tree.CountLess(probe); got != want {
+			t.Fatalf("CountLess(%d) = %d, want %d", probe, got, want)
+		}
+		if _, ok := tree.Rank(probe); ok != found {
+			t.Fatalf("Rank(%d) found = %v, want %v", probe, ok, found)
+		}
+		hi := probe + 100
+		end, _ := slices.BinarySearch(model, hi)
+		if got := tree.CountBetween(probe, hi); got != end-want {
+			t.Fatalf("CountBetween(%d, %d) = %d, want %d", probe, hi, got, end-want)
+		}
+	}
+	if got := tree.CountBetween(100, 50); got != 0 {
+		t.Fatalf("CountBetween with lo > hi = %d, want 0", got)
+	}
+
+	var keys []int
+	for k := range tree.All() {
+		keys = append(keys, k)
+	}
+	if !slices.Equal(keys, model) {
+		t.Fatalf("All does not match the model")
+	}
+	if k, _, _ := tree.Min(); k != model[0] {
+		t.Fatalf("Min = %d, want %d", k, model[0])
+	}
+	if k, _, _ := tree.Max(); k != model[len(model)-1] {
+		t.Fatalf("Max = %d, want %d", k, model[len(model)-1])
+	}
+}
+
+func TestEmptyAndFunc(t *testing.T) {
+	tree := NewFunc[string, int](func(a, b string) bool { return len(a) < len(b) })
+	if _, _, ok := tree.Min(); ok {
+		t.Fatalf("Min on empty tree should report no element")
+	}
+	if _, ok := tree.Rank("x"); ok || tree.CountLess("x") != 0 {
+		t.Fatalf("empty tree should rank nothing")
+	}
+	for _, s := range []string{"ccc", "a", "bb", "dd"} {
+		tree.Insert(s, len(s))
+	}
+	// "dd"는 "bb"와 길이가 같아 같은 키로 취급되므로 값만 바뀐다.
+	if tree.Size() != 3 {
+		t.Fatalf("Size = %d, want 3", tree.Size())
+	}
+	if k, _, _ := tree.Select(1); k != "bb" {
+		t.Fatalf("Select(1) = %q, want bb", k)
+	}
+}
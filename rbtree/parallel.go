@@ -0,0 +1,69 @@
+package rbtree
+
+import (
+	"runtime"
+	"sync"
+)
+
+// WalkParallel은 모든 원소를 workers개의 고루틴으로 나눠 방문하며 fn을 호출하고, 모두 끝나면
+// 돌아온다. 루트 근처에서 트리를 서브트리 여러 개로 쪼갠 뒤 고루틴마다 서브트리를 하나씩 가져가
+// 걸으므로, 원소마다 CPU를 많이 쓰는 재직렬화나 검증을 큰 트리에 돌릴 때 쓴다. 방문 순서는 정해져
+// 있지 않고 fn은 여러 고루틴에서 동시에 불리므로 fn이 스스로 동시성을 책임져야 한다.
+// workers가 0 이하이면 GOMAXPROCS를 쓴다.
+//
+// 순회하는 동안 트리를 고치면 안 된다. 다른 고루틴이 쓰는 트리라면 Snapshot을 떠서 그 위에서 부른다.
+func (t *Tree[K, V]) WalkParallel(workers int, fn func(key K, value V)) {
+	if t.root == nil {
+		return
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers == 1 {
+		inOrder(t.root, fn)
+		return
+	}
+
+	// 한 서브트리가 유난히 크더라도 고루틴들이 고르게 일하도록 workers의 몇 배로 잘게 쪼갠다.
+	// 쪼개며 지나온 위쪽 노드는 따로 모아 직접 방문한다.
+	var tops []*Node[K, V]
+	parts := []*Node[K, V]{t.root}
+	for len(parts) < 4*workers {
+		var next []*Node[K, V]
+		for _, n := range parts {
+			tops = append(tops, n)
+			if n.Left != nil {
+				next = append(next, n.Left)
+			}
+			if n.Right != nil {
+				next = append(next, n.Right)
+			}
+		}
+		parts = next
+		if len(parts) == 0 {
+			break
+		}
+	}
+
+	work := make(chan *Node[K, V])
+	var wg sync.WaitGroup
+	for range min(workers, len(parts)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for root := range work {
+				inOrder(root, fn)
+			}
+		}()
+	}
+	for _, root := range parts {
+		work <- root
+	}
+	close(work)
+	for _, n := range tops {
+		if !n.deleted {
+			fn(n.Key, n.Value)
+		}
+	}
+	wg.Wait()
+}
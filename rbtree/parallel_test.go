@@ -0,0 +1,43 @@
+package rbtree
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWalkParallel(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 7, 100, 10000} {
+		tree := NewWithTombstones[int, int]()
+		for i := 0; i < n; i++ {
+			tree.Insert(i, i)
+		}
+		for i := 0; i < n; i += 3 {
+			tree.Delete(i)
+		}
+		for _, workers := range []int{0, 1, 3, 64} {
+			var mu sync.Mutex
+			seen := make(map[int]int)
+			var sum atomic.Int64
+			tree.WalkParallel(workers, func(key, value int) {
+				sum.Add(int64(value))
+				mu.Lock()
+				seen[key]++
+				mu.Unlock()
+			})
+			if len(seen) != tree.Size() {
+				t.Fatalf("n=%d workers=%d: visited %d keys, want %d", n, workers, len(seen), tree.Size())
+			}
+			var want int64
+			for k := range tree.All() {
+				if seen[k] != 1 {
+					t.Fatalf("n=%d workers=%d: key %d visited %d times", n, workers, k, seen[k])
+				}
+				want += int64(k)
+			}
+			if sum.Load() != want {
+				t.Fatalf("n=%d workers=%d: sum %d, want %d", n, workers, sum.Load(), want)
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package persistent
+
+// ChangeKind는 Diff가 알려 주는 변경의 종류다.
+type ChangeKind int
+
+const (
+	Added    ChangeKind = iota // to에만 있는 키
+	Removed                    // from에만 있는 키
+	Modified                   // 양쪽에 있지만 값이 다른 키
+)
+
+// Change는 두 버전 사이의 변경 하나다. Added면 Old가, Removed면 New가 제로값이다.
+type Change[K any, V any] struct {
+	Key      K
+	Old, New V
+	Kind     ChangeKind
+}
+
+// Diff는 from에서 to로 바뀐 원소를 키 오름차순으로 fn에 넘긴다. fn이 false를 돌려주면 멈춘다.
+// 두 버전이 공유하는 서브트리는 내려가지 않고 건너뛰므로, 한 버전에서 몇 번 고쳐 만든 버전과 비교하면
+// 트리 전체가 아니라 바뀐 경로 근처만 훑는다. 값이 같은지는 eq로 판단하며, eq가 nil이면 키의 추가와
+// 삭제만 알리고 Modified는 알리지 않는다. 두 트리는 같은 키 순서를 써야 한다.
+func Diff[K any, V any](from, to *Tree[K, V], eq func(a, b V) bool, fn func(Change[K, V]) bool) {
+	var a, b iterator[K, V]
+	a.push(from.root)
+	b.push(to.root)
+	for {
+		na, nb := a.peek(), b.peek()
+		switch {
+		case na == nil && nb == nil:
+			return
+		case na == nb:
+			// 같은 노드면 그 노드와 오른쪽 서브트리가 양쪽에서 똑같으므로 통째로 건너뛴다.
+			a.pop()
+			b.pop()
+			continue
+		}
+
+		var c int
+		switch {
+		case na == nil:
+			c = 1
+		case nb == nil:
+			c = -1
+		default:
+			c = to.compare(na.key, nb.key)
+		}
+
+		var ch Change[K, V]
+		switch {
+		case c < 0:
+			a.next()
+			ch = Change[K, V]{Key: na.key, Old: na.value, Kind: Removed}
+		case c > 0:
+			b.next()
+			ch = Change[K, V]{Key: nb.key, New: nb.value, Kind: Added}
+		default:
+			a.next()
+			b.next()
+			if eq == nil || eq(na.value, nb.value) {
+				continue
+			}
+			ch = Change[K, V]{Key: nb.key, Old: na.value, New: nb.value, Kind: Modified}
+		}
+		if !fn(ch) {
+			return
+		}
+	}
+}
@@ -0,0 +1,318 @@
+// Package persistent는 고칠 때마다 새 버전을 돌려주는 불변(persistent) 레드블랙 트리를 제공한다.
+//
+// Insert와 Delete는 원래 트리를 건드리지 않고, 루트에서 바뀐 노드까지의 경로만 새로 만든 트리를
+// 돌려준다(경로 복사). 나머지 서브트리는 이전 버전과 공유하므로 버전 하나에 O(log n) 노드만 더
+// 들고, 이전 버전은 언제든 그대로 읽을 수 있다. 실행 취소 기록이나 버전 사이의 비교(Diff)에 쓴다.
+//
+// rbtree.Tree의 노드는 부모 포인터를 가져서 경로 복사를 할 수 없으므로, 이 패키지는 부모 포인터가
+// 없는 좌편향 레드블랙 트리(LLRB, Sedgewick)로 따로 구현한다. 빨강 링크가 항상 왼쪽에만 오도록
+// 제한한 2-3 트리 표현이라 균형 조건과 높이 상한은 일반 레드블랙 트리와 같다.
+//
+// 모든 버전은 불변이므로 여러 고루틴이 잠금 없이 함께 읽어도 안전하다.
+package persistent
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Tree는 불변 레드블랙 트리의 한 버전이다. 제로값은 쓸 수 없으므로 New나 NewFunc로 만든다.
+type Tree[K any, V any] struct {
+	root    *node[K, V]
+	size    int
+	compare func(a, b K) int
+}
+
+type node[K any, V any] struct {
+	key         K
+	value       V
+	left, right *node[K, V]
+	red         bool
+}
+
+// New는 K의 기본 순서를 쓰는 빈 트리를 만든다.
+func New[K cmp.Ordered, V any]() *Tree[K, V] {
+	return &Tree[K, V]{compare: cmp.Compare[K]}
+}
+
+// NewFunc는 less로 키 순서를 정하는 빈 트리를 만든다. less는 엄격한 약순서여야 하며,
+// less(a, b)와 less(b, a)가 모두 false인 두 키는 같은 키로 취급한다.
+func NewFunc[K any, V any](less func(a, b K) bool) *Tree[K, V] {
+	return &Tree[K, V]{compare: func(a, b K) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	}}
+}
+
+// Size는 원소 수를 돌려준다.
+func (t *Tree[K, V]) Size() int {
+	return t.size
+}
+
+// Get은 키에 대응하는 값을 돌려준다. 키가 없으면 V의 제로값과 false다.
+func (t *Tree[K, V]) Get(key K) (V, bool) {
+	if n := t.find(key); n != nil {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains는 키가 트리에 있는지 알려 준다.
+func (t *Tree[K, V]) Contains(key K) bool {
+	return t.find(key) != nil
+}
+
+func (t *Tree[K, V]) find(key K) *node[K, V] {
+	n := t.root
+	for n != nil {
+		switch c := t.compare(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// Min은 가장 작은 원소를 돌려준다. 비었으면 ok가 false다.
+func (t *Tree[K, V]) Min() (key K, value V, ok bool) {
+	n := t.root
+	if n == nil {
+		return key, value, false
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.key, n.value, true
+}
+
+// Max는 가장 큰 원소를 돌려준다. 비었으면 ok가 false다.
+func (t *Tree[K, V]) Max() (key K, value V, ok bool) {
+	n := t.root
+	if n == nil {
+		return key, value, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, n.value, true
+}
+
+// Insert는 key에 value를 담은 새 버전을 돌려준다. 키가 이미 있으면 새 버전에서 값만 바뀐다.
+// t는 바뀌지 않는다.
+func (t *Tree[K, V]) Insert(key K, value V) *Tree[K, V] {
+	added := false
+	root := t.insert(t.root, key, value, &added)
+	root.red = false // insert가 돌려준 루트는 항상 새로 만든 노드다.
+	out := &Tree[K, V]{root: root, size: t.size, compare: t.compare}
+	if added {
+		out.size++
+	}
+	return out
+}
+
+func (t *Tree[K, V]) insert(h *node[K, V], key K, value V, added *bool) *node[K, V] {
+	if h == nil {
+		*added = true
+		return &node[K, V]{key: key, value: value, red: true}
+	}
+	h = clone(h)
+	switch c := t.compare(key, h.key); {
+	case c < 0:
+		h.left = t.insert(h.left, key, value, added)
+	case c > 0:
+		h.right = t.insert(h.right, key, value, added)
+	default:
+		h.value = value
+	}
+	return fixUp(h)
+}
+
+// Delete는 key를 뺀 새 버전을 돌려준다. 키가 없으면 t를 그대로 돌려준다. t는 바뀌지 않는다.
+func (t *Tree[K, V]) Delete(key K) *Tree[K, V] {
+	if t.find(key) == nil {
+		return t
+	}
+	root := clone(t.root)
+	if !isRed(root.left) && !isRed(root.right) {
+		root.red = true
+	}
+	root = t.delete(root, key)
+	if root != nil {
+		root.red = false
+	}
+	return &Tree[K, V]{root: root, size: t.size - 1, compare: t.compare}
+}
+
+// delete는 h 아래에 key가 있다고 가정한다. h는 이미 복사된 노드여야 한다.
+// 내려가는 동안 현재 노드나 그 왼쪽 자식이 빨강이 되도록 유지해, 지울 노드가 3-노드에 있게 한다.
+func (t *Tree[K, V]) delete(h *node[K, V], key K) *node[K, V] {
+	if t.compare(key, h.key) < 0 {
+		if !isRed(h.left) && !isRed(h.left.left) {
+			h = moveRedLeft(h)
+		}
+		h.left = t.delete(clone(h.left), key)
+		return fixUp(h)
+	}
+	if isRed(h.left) {
+		h = rotateRight(h)
+	}
+	if t.compare(key, h.key) == 0 && h.right == nil {
+		return nil
+	}
+	if !isRed(h.right) && !isRed(h.right.left) {
+		h = moveRedRight(h)
+	}
+	if t.compare(key, h.key) == 0 {
+		m := h.right
+		for m.left != nil {
+			m = m.left
+		}
+		h.key, h.value = m.key, m.value
+		h.right = deleteMin(clone(h.right))
+	} else {
+		h.right = t.delete(clone(h.right), key)
+	}
+	return fixUp(h)
+}
+
+// deleteMin은 이미 복사된 h에서 최소 노드를 뗀다.
+func deleteMin[K any, V any](h *node[K, V]) *node[K, V] {
+	if h.left == nil {
+		return nil
+	}
+	if !isRed(h.left) && !isRed(h.left.left) {
+		h = moveRedLeft(h)
+	}
+	h.left = deleteMin(clone(h.left))
+	return fixUp(h)
+}
+
+// 아래 보조 함수들은 인자로 받은 h가 이미 복사된 노드라고 가정하고 제자리에서 고친다.
+// 새로 고쳐야 하는 자식은 고치기 전에 복사하므로 이전 버전의 노드는 바뀌지 않는다.
+
+func clone[K any, V any](n *node[K, V]) *node[K, V] {
+	c := *n
+	return &c
+}
+
+func isRed[K any, V any](n *node[K, V]) bool {
+	return n != nil && n.red
+}
+
+func rotateLeft[K any, V any](h *node[K, V]) *node[K, V] {
+	x := clone(h.right)
+	h.right = x.left
+	x.left = h
+	x.red = h.red
+	h.red = true
+	return x
+}
+
+func rotateRight[K any, V any](h *node[K, V]) *node[K, V] {
+	x := clone(h.left)
+	h.left = x.right
+	x.right = h
+	x.red = h.red
+	h.red = true
+	return x
+}
+
+func flipColors[K any, V any](h *node[K, V]) {
+	h.red = !h.red
+	h.left = clone(h.left)
+	h.left.red = !h.left.red
+	h.right = clone(h.right)
+	h.right.red = !h.right.red
+}
+
+func moveRedLeft[K any, V any](h *node[K, V]) *node[K, V] {
+	flipColors(h)
+	if isRed(h.right.left) {
+		h.right = rotateRight(h.right)
+		h = rotateLeft(h)
+		flipColors(h)
+	}
+	return h
+}
+
+func moveRedRight[K any, V any](h *node[K, V]) *node[K, V] {
+	flipColors(h)
+	if isRed(h.left.left) {
+		h = rotateRight(h)
+		flipColors(h)
+	}
+	return h
+}
+
+// fixUp은 올라오면서 오른쪽으로 기운 빨강과 연속된 빨강을 없애 LLRB 조건을 되살린다.
+func fixUp[K any, V any](h *node[K, V]) *node[K, V] {
+	if isRed(h.right) && !isRed(h.left) {
+		h = rotateLeft(h)
+	}
+	if isRed(h.left) && isRed(h.left.left) {
+		h = rotateRight(h)
+	}
+	if isRed(h.left) && isRed(h.right) {
+		flipColors(h)
+	}
+	return h
+}
+
+// All은 모든 원소를 키 오름차순으로 내놓는다. 버전이 불변이므로 순회 도중 새 버전을 만들어도 안전하다.
+func (t *Tree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var it iterator[K, V]
+		it.push(t.root)
+		for n := it.next(); n != nil; n = it.next() {
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
+
+// iterator는 중위 순회를 위한 명시적 스택이다. 스택 맨 위가 다음에 방문할 노드다.
+type iterator[K any, V any] struct {
+	stack []*node[K, V]
+}
+
+// push는 n부터 왼쪽 척추를 스택에 쌓는다.
+func (it *iterator[K, V]) push(n *node[K, V]) {
+	for ; n != nil; n = n.left {
+		it.stack = append(it.stack, n)
+	}
+}
+
+func (it *iterator[K, V]) peek() *node[K, V] {
+	if len(it.stack) == 0 {
+		return nil
+	}
+	return it.stack[len(it.stack)-1]
+}
+
+func (it *iterator[K, V]) pop() *node[K, V] {
+	n := it.peek()
+	if n != nil {
+		it.stack = it.stack[:len(it.stack)-1]
+	}
+	return n
+}
+
+func (it *iterator[K, V]) next() *node[K, V] {
+	n := it.pop()
+	if n != nil {
+		it.push(n.right)
+	}
+	return n
+}
@@ -0,0 +1,125 @@
+package persistent
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkLLRB는 좌편향 레드블랙 트리의 조건(빨강은 왼쪽에만, 연속 빨강 없음, 검정 높이 균일)과
+// 키 순서를 확인하고 원소 수를 돌려준다.
+func checkLLRB[K any, V any](t *testing.T, tree *Tree[K, V]) {
+	t.Helper()
+	if isRed(tree.root) {
+		t.Fatalf("root must be black")
+	}
+	var walk func(n *node[K, V], lo, hi *K) (count, black int)
+	walk = func(n *node[K, V], lo, hi *K) (int, int) {
+		if n == nil {
+			return 0, 1
+		}
+		if isRed(n.right) {
+			t.Fatalf("red right link at %v", n.key)
+		}
+		if n.red && isRed(n.left) {
+			t.Fatalf("two reds in a row at %v", n.key)
+		}
+		if (lo != nil && tree.compare(n.key, *lo) <= 0) || (hi != nil && tree.compare(n.key, *hi) >= 0) {
+			t.Fatalf("key %v out of order", n.key)
+		}
+		lc, lb := walk(n.left, lo, &n.key)
+		rc, rb := walk(n.right, &n.key, hi)
+		if lb != rb {
+			t.Fatalf("black height mismatch at %v: %d vs %d", n.key, lb, rb)
+		}
+		if !n.red {
+			lb++
+		}
+		return lc + rc + 1, lb
+	}
+	if count, _ := walk(tree.root, nil, nil); count != tree.Size() {
+		t.Fatalf("size %d disagrees with node count %d", tree.Size(), count)
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	tree := New[int, int]()
+	versions := []*Tree[int, int]{tree}
+	models := []map[int]int{{}}
+	for i := 0; i < 2000; i++ {
+		model := make(map[int]int, len(models[len(models)-1]))
+		for k, v := range models[len(models)-1] {
+			model[k] = v
+		}
+		k := rand.Intn(300)
+		if rand.Intn(3) == 0 {
+			tree = tree.Delete(k)
+			delete(model, k)
+		} else {
+			tree = tree.Insert(k, i)
+			model[k] = i
+		}
+		checkLLRB(t, tree)
+		versions = append(versions, tree)
+		models = append(models, model)
+	}
+
+	// 모든 이전 버전이 만들어질 때의 내용을 그대로 지니고 있어야 한다.
+	for i, v := range versions {
+		if v.Size() != len(models[i]) {
+			t.Fatalf("version %d size %d, want %d", i, v.Size(), len(models[i]))
+		}
+		prev := -1
+		for k, val := range v.All() {
+			if k <= prev || models[i][k] != val {
+				t.Fatalf("version %d changed at key %d", i, k)
+			}
+			prev = k
+		}
+	}
+}
+
+func TestDeleteMissingReturnsSameTree(t *testing.T) {
+	tree := New[string, int]().Insert("a", 1)
+	if tree.Delete("b") != tree {
+		t.Fatalf("deleting a missing key should return the same version")
+	}
+	if empty := tree.Delete("a"); empty.Size() != 0 || empty.Contains("a") || !tree.Contains("a") {
+		t.Fatalf("Delete should only affect the new version")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	base := New[int, string]()
+	for i := 0; i < 1000; i++ {
+		base = base.Insert(i, "v")
+	}
+	next := base.Insert(1000, "new").Delete(10).Insert(500, "changed").Insert(600, "v")
+
+	var got []Change[int, string]
+	Diff(base, next, func(a, b string) bool { return a == b }, func(c Change[int, string]) bool {
+		got = append(got, c)
+		return true
+	})
+	want := []Change[int, string]{
+		{Key: 10, Old: "v", Kind: Removed},
+		{Key: 500, Old: "v", New: "changed", Kind: Modified},
+		{Key: 1000, New: "new", Kind: Added},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Diff expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Diff change %d expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+
+	got = got[:0]
+	Diff(base, next, nil, func(c Change[int, string]) bool {
+		got = append(got, c)
+		return true
+	})
+	if len(got) != 2 {
+		t.Fatalf("Diff with nil eq should only report added and removed keys, got %v", got)
+	}
+}
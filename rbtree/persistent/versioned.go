@@ -0,0 +1,110 @@
+package persistent
+
+import (
+	"cmp"
+	"sync"
+)
+
+// Versioned는 고칠 때마다 버전 번호가 하나씩 오르는 MVCC 트리다. 각 버전은 불변 Tree이므로
+// GetAt이나 At으로 지난 버전을 읽는 동안 쓰기가 계속되어도 읽는 쪽은 일관된 내용을 본다.
+// 오래 걸리는 내보내기는 시작할 때의 Version을 잡아 두고 그 버전만 읽으면 된다.
+//
+// 버전 사이에는 바뀐 경로만 새로 만드므로 버전 하나에 O(log n) 노드가 든다. 더 이상 읽지 않는
+// 버전은 Prune으로 놓아 주어야 메모리가 회수된다. 모든 메서드는 여러 고루틴에서 함께 불러도 안전하다.
+type Versioned[K any, V any] struct {
+	mu sync.RWMutex
+	// versions[i]는 버전 base+i의 트리다.
+	versions []*Tree[K, V]
+	base     uint64
+}
+
+// NewVersioned는 K의 기본 순서를 쓰는 빈 트리를 버전 0으로 하는 Versioned를 만든다.
+func NewVersioned[K cmp.Ordered, V any]() *Versioned[K, V] {
+	return &Versioned[K, V]{versions: []*Tree[K, V]{New[K, V]()}}
+}
+
+// NewVersionedFunc는 less로 키 순서를 정하는 Versioned를 만든다. less의 조건은 NewFunc와 같다.
+func NewVersionedFunc[K any, V any](less func(a, b K) bool) *Versioned[K, V] {
+	return &Versioned[K, V]{versions: []*Tree[K, V]{NewFunc[K, V](less)}}
+}
+
+// Version은 현재(가장 최근) 버전 번호를 돌려준다.
+func (v *Versioned[K, V]) Version() uint64 {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.base + uint64(len(v.versions)) - 1
+}
+
+// Current는 현재 버전의 트리를 돌려준다.
+func (v *Versioned[K, V]) Current() *Tree[K, V] {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.versions[len(v.versions)-1]
+}
+
+// At은 version 시점의 트리를 돌려준다. 아직 만들어지지 않았거나 Prune으로 버린 버전이면 false다.
+// 돌려받은 트리는 불변이므로 잠금 없이 순회해도 된다.
+func (v *Versioned[K, V]) At(version uint64) (*Tree[K, V], bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if version < v.base || version-v.base >= uint64(len(v.versions)) {
+		return nil, false
+	}
+	return v.versions[version-v.base], true
+}
+
+// Get은 현재 버전에서 key를 찾는다.
+func (v *Versioned[K, V]) Get(key K) (V, bool) {
+	return v.Current().Get(key)
+}
+
+// GetAt은 version 시점에서 key를 찾는다. 버전이 없으면(At이 false면) 키도 없는 것으로 본다.
+func (v *Versioned[K, V]) GetAt(key K, version uint64) (V, bool) {
+	if t, ok := v.At(version); ok {
+		return t.Get(key)
+	}
+	var zero V
+	return zero, false
+}
+
+// Insert는 key에 value를 넣은 새 버전을 만들고 그 번호를 돌려준다.
+func (v *Versioned[K, V]) Insert(key K, value V) uint64 {
+	return v.commit(func(t *Tree[K, V]) *Tree[K, V] { return t.Insert(key, value) })
+}
+
+// Delete는 key를 뺀 새 버전을 만들고 그 번호를 돌려준다. 키가 없으면 버전을 올리지 않고
+// 현재 번호를 돌려준다.
+func (v *Versioned[K, V]) Delete(key K) uint64 {
+	return v.commit(func(t *Tree[K, V]) *Tree[K, V] { return t.Delete(key) })
+}
+
+// Apply는 fn이 현재 트리로 만든 트리를 새 버전 하나로 기록하고 그 번호를 돌려준다. 여러 변경을
+// 한 버전으로 묶을 때 쓴다. fn이 받은 트리를 그대로 돌려주면 버전을 올리지 않는다.
+// fn은 잠금 아래에서 불리므로 안에서 v를 다시 부르면 안 된다.
+func (v *Versioned[K, V]) Apply(fn func(t *Tree[K, V]) *Tree[K, V]) uint64 {
+	return v.commit(fn)
+}
+
+func (v *Versioned[K, V]) commit(fn func(t *Tree[K, V]) *Tree[K, V]) uint64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	cur := v.versions[len(v.versions)-1]
+	if next := fn(cur); next != cur {
+		v.versions = append(v.versions, next)
+	}
+	return v.base + uint64(len(v.versions)) - 1
+}
+
+// Prune은 before보다 오래된 버전을 버려 그 버전만 붙잡고 있던 노드를 가비지 컬렉터가 거둘 수 있게 한다.
+// 현재 버전은 버리지 않는다. 이미 At으로 받아 둔 트리는 계속 읽을 수 있다.
+func (v *Versioned[K, V]) Prune(before uint64) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if before <= v.base {
+		return
+	}
+	drop := min(before-v.base, uint64(len(v.versions)-1))
+	clear(v.versions[:drop])
+	v.versions = v.versions[drop:]
+	v.base += drop
+}
@@ -0,0 +1,82 @@
+package persistent
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestVersioned(t *testing.T) {
+	v := NewVersioned[string, int]()
+	v1 := v.Insert("a", 1)
+	v2 := v.Insert("a", 2)
+	v3 := v.Delete("a")
+	if v1 != 1 || v2 != 2 || v3 != 3 || v.Version() != 3 {
+		t.Fatalf("versions should count mutations, got %d %d %d", v1, v2, v3)
+	}
+	if v.Delete("missing") != 3 {
+		t.Fatalf("a no-op delete should not bump the version")
+	}
+
+	for version, want := range map[uint64]int{1: 1, 2: 2} {
+		if got, ok := v.GetAt("a", version); !ok || got != want {
+			t.Fatalf("GetAt(a, %d) expected %d, got %d ok=%v", version, want, got, ok)
+		}
+	}
+	if _, ok := v.GetAt("a", 0); ok {
+		t.Fatalf("key should not exist at version 0")
+	}
+	if _, ok := v.Get("a"); ok {
+		t.Fatalf("key should be gone in the current version")
+	}
+
+	batch := v.Apply(func(t *Tree[string, int]) *Tree[string, int] {
+		return t.Insert("x", 1).Insert("y", 2)
+	})
+	if batch != 4 || v.Current().Size() != 2 {
+		t.Fatalf("Apply should commit one version, got %d with %d keys", batch, v.Current().Size())
+	}
+
+	v.Prune(3)
+	if _, ok := v.At(2); ok {
+		t.Fatalf("pruned versions should be gone")
+	}
+	if _, ok := v.At(3); !ok {
+		t.Fatalf("versions at or after the prune point should remain")
+	}
+	v.Prune(100)
+	if v.Version() != 4 || v.Current().Size() != 2 {
+		t.Fatalf("Prune must keep the current version")
+	}
+}
+
+// 긴 읽기가 잡아 둔 버전은 이후 쓰기와 상관없이 같은 내용을 보여야 한다. -race로 돌린다.
+func TestVersionedConsistentReads(t *testing.T) {
+	v := NewVersioned[int, int]()
+	for i := 0; i < 500; i++ {
+		v.Insert(i, i)
+	}
+	snapshot, _ := v.At(v.Version())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			v.Delete(i)
+			v.Insert(i+1000, i)
+		}
+	}()
+	for round := 0; round < 10; round++ {
+		n := 0
+		for k, val := range snapshot.All() {
+			if k != n || val != n {
+				t.Errorf("snapshot changed under a concurrent writer at %d", k)
+			}
+			n++
+		}
+		if n != 500 {
+			t.Errorf("snapshot saw %d keys, want 500", n)
+		}
+	}
+	wg.Wait()
+}
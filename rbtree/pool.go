@@ -0,0 +1,33 @@
+package rbtree
+
+// WithNodePool은 삭제로 떼어 낸 노드를 버리지 않고 모아 두었다가 다음 삽입에 다시 쓰게 한다.
+// 삽입과 삭제가 번갈아 잦은 워크로드에서 할당과 GC 부담을 줄인다. 풀은 sync.Pool이라 쓰지 않는
+// 노드는 GC가 거둬 갈 수 있다.
+//
+// Delete, Pop, PopMin/PopMax, DeleteIf, Compact, Clear, Reset처럼 노드를 구조적으로 떼어 내는 연산이 노드를
+// 돌려주며, 돌려주기 전에 키와 값을 지워 붙잡고 있던 메모리를 놓아 준다. 그래서 이 옵션을 쓰면
+// Search, Min, Root 등으로 받은 *Node는 그 키를 지운 뒤에 쓰면 안 된다. 다른 키의 노드로
+// 다시 쓰이고 있을 수 있다.
+func WithNodePool() Option {
+	return func(o *options) { o.pool = true }
+}
+
+// newNode는 풀이 있으면 풀에서, 없으면 새로 노드를 만든다. 색과 링크는 호출부가 채운다.
+func (t *Tree[K, V]) newNode(key K, value V) *Node[K, V] {
+	if t.nodes != nil {
+		if node, ok := t.nodes.Get().(*Node[K, V]); ok {
+			node.Key, node.Value = key, value
+			return node
+		}
+	}
+	return &Node[K, V]{Key: key, Value: value}
+}
+
+// release는 트리에서 떼어 낸 node를 비워 풀에 돌려준다. 풀이 없으면 아무 일도 하지 않는다.
+func (t *Tree[K, V]) release(node *Node[K, V]) {
+	if t.nodes == nil {
+		return
+	}
+	*node = Node[K, V]{}
+	t.nodes.Put(node)
+}
@@ -0,0 +1,107 @@
+package rbtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNodePool(t *testing.T) {
+	tree := New[int, *int](WithNodePool())
+	for i := 0; i < 1000; i++ {
+		v := i
+		tree.Insert(i, &v)
+	}
+	victim := tree.Search(500)
+	tree.Delete(500)
+	// 돌려준 노드는 키와 값을 놓아 준다.
+	if victim.Value != nil || victim.Parent != nil || victim.Left != nil || victim.Right != nil {
+		t.Fatalf("released node still holds references: %+v", victim)
+	}
+	for _, k := range rand.Perm(1000) {
+		if k%3 == 0 {
+			tree.Delete(k)
+		} else {
+			v := -k
+			tree.Insert(k+1000, &v)
+		}
+	}
+	assertRBProperties(t, tree)
+	for k, v := range tree.All() {
+		if k >= 1000 && *v != 1000-k {
+			t.Fatalf("key %d has value %d", k, *v)
+		}
+	}
+
+	tomb := NewWithTombstones[int, int](WithNodePool())
+	for i := 0; i < 100; i++ {
+		tomb.Insert(i, i)
+	}
+	for i := 0; i < 100; i += 2 {
+		tomb.Delete(i)
+	}
+	tomb.Compact(nil)
+	for i := 0; i < 100; i += 2 {
+		tomb.Insert(i, i)
+	}
+	assertRBProperties(t, tomb)
+	if tomb.Size() != 100 {
+		t.Fatalf("size = %d, want 100", tomb.Size())
+	}
+}
+
+func TestNodePoolClear(t *testing.T) {
+	tree := New[int, *int](WithNodePool())
+	for i := 0; i < 200; i++ {
+		v := i
+		tree.Insert(i, &v)
+	}
+	nodes := []*Node[int, *int]{tree.Root(), tree.Search(0), tree.Search(100), tree.Search(199)}
+	tree.Clear()
+	// Clear도 노드를 비워 풀에 돌려준다.
+	for _, n := range nodes {
+		if n.Value != nil || n.Parent != nil || n.Left != nil || n.Right != nil {
+			t.Fatalf("node not scrubbed after Clear: %+v", n)
+		}
+	}
+	for i := 0; i < 200; i++ {
+		v := -i
+		tree.Insert(i, &v)
+	}
+	assertRBProperties(t, tree)
+
+	// 스냅숏과 공유 중인 노드는 돌려주지 않는다.
+	snap := tree.Snapshot()
+	tree.Clear()
+	if snap.Size() != 200 {
+		t.Fatalf("snapshot lost elements after Clear: size %d", snap.Size())
+	}
+	for k, v := range snap.All() {
+		if v == nil || *v != -k {
+			t.Fatalf("snapshot key %d changed after Clear", k)
+		}
+	}
+	assertRBProperties(t, snap)
+
+	tree.Insert(1, nil)
+	tree.Reset()
+	if tree.Size() != 0 {
+		t.Fatalf("Reset left %d elements", tree.Size())
+	}
+}
+
+func TestNodePoolReducesAllocations(t *testing.T) {
+	churn := func(tree *Tree[int, int]) float64 {
+		for i := 0; i < 1000; i++ {
+			tree.Insert(i, i)
+		}
+		return testing.AllocsPerRun(100, func() {
+			tree.Delete(500)
+			tree.Insert(500, 500)
+		})
+	}
+	plain, pooled := churn(New[int, int]()), churn(New[int, int](WithNodePool()))
+	t.Logf("allocations per delete+insert: plain %.1f, pooled %.1f", plain, pooled)
+	if pooled >= plain {
+		t.Fatalf("pooled churn allocates %.1f per run, plain %.1f", pooled, plain)
+	}
+}
@@ -0,0 +1,28 @@
+package rbtree
+
+import "runtime"
+
+// Prefetch는 각 키에 대한 BST 탐색 경로를 미리 한 번 훑어 경로 위의 노드를 CPU 캐시로 끌어온다.
+// 키 비교에 필요한 Key와 자식 포인터만 읽고 Value는 건드리지 않으며, 트리 구조도 바꾸지 않는다.
+// 곧 이어질 Search 묶음의 메모리 지연을 숨기려는 용도이므로 결과는 돌려주지 않는다.
+// 읽기 전용이라 다른 고루틴의 Search와 동시에 호출해도 된다(쓰기와는 동시에 호출하면 안 된다).
+func (t *Tree[K, V]) Prefetch(keys []K) {
+	for _, key := range keys {
+		cur := t.root
+		last := cur
+		for cur != nil {
+			last = cur
+			c := t.compare(key, cur.Key)
+			if c == 0 {
+				break
+			}
+			if c < 0 {
+				cur = cur.Left
+			} else {
+				cur = cur.Right
+			}
+		}
+		// 마지막으로 읽은 노드를 살려 두어 컴파일러가 위의 로드를 없애지 못하게 한다.
+		runtime.KeepAlive(last)
+	}
+}
@@ -0,0 +1,62 @@
+package rbtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPrefetchLeavesTreeUnchanged(t *testing.T) {
+	tree := New[int, int]()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, i)
+	}
+	root := tree.Root()
+	tree.Prefetch([]int{-1, 0, 50, 99, 1000})
+	New[int, int]().Prefetch([]int{1, 2, 3})
+
+	if tree.Root() != root || tree.Size() != 100 {
+		t.Fatalf("Prefetch must not modify the tree")
+	}
+	assertRBProperties(t, tree)
+}
+
+// 1천만 원소 트리에서, 다음 검색 묶음을 다른 고루틴이 Prefetch하는 경우와 그렇지 않은 경우를 비교한다.
+func BenchmarkPrefetch(b *testing.B) {
+	const (
+		n         = 10_000_000
+		batchSize = 1024
+	)
+	tree := New[int, int]()
+	for _, k := range rand.Perm(n) {
+		tree.Insert(k, k)
+	}
+	batches := make([][]int, 64)
+	for i := range batches {
+		batches[i] = make([]int, batchSize)
+		for j := range batches[i] {
+			batches[i][j] = rand.Intn(n)
+		}
+	}
+
+	b.Run("search", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, k := range batches[i%len(batches)] {
+				tree.Search(k)
+			}
+		}
+	})
+	b.Run("prefetch", func(b *testing.B) {
+		done := make(chan struct{})
+		for i := 0; i < b.N; i++ {
+			next := batches[(i+1)%len(batches)]
+			go func() {
+				tree.Prefetch(next)
+				done <- struct{}{}
+			}()
+			for _, k := range batches[i%len(batches)] {
+				tree.Search(k)
+			}
+			<-done
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package rbtree
+
+// AscendPrefix는 prefix로 시작하는 키만 오름차순으로 방문하며 fn을 호출한다. fn이 false를 돌려주면
+// 즉시 멈춘다. "tenant:resource:id"처럼 이름 공간을 붙인 키에서 한 이름 공간만 훑을 때 쓴다.
+// prefix 바로 다음 문자열(prefixEnd)을 위 끝으로 잡아 AscendRange와 같은 [prefix, 위 끝) 구간을
+// 걸으므로, 맞는 키가 k개면 O(log n + k)이다. 빈 prefix는 모든 키와 맞는다.
+//
+// 구간으로 바꾸는 계산은 바이트 단위 사전순을 가정한다. New로 만든 문자열 트리가 그렇다. NewFunc로
+// 다른 순서를 준 트리에서는 결과가 맞지 않을 수 있다.
+func AscendPrefix[K ~string, V any](t *Tree[K, V], prefix K, fn func(key K, value V) bool) {
+	if end, ok := prefixEnd(prefix); ok {
+		t.AscendRange(prefix, end, fn)
+		return
+	}
+	mods := t.mods
+	for node := t.ceiling(prefix); node != nil; node = nextLive(node) {
+		if !fn(node.Key, node.Value) {
+			return
+		}
+		t.checkMods(mods)
+	}
+}
+
+// prefixEnd는 prefix로 시작하는 모든 문자열보다 크면서 가장 작은 문자열을 돌려준다. 끝의 0xff
+// 바이트를 떼어 내고 마지막 바이트를 하나 올린다. prefix가 비었거나 0xff로만 이뤄져 그런 문자열이
+// 없으면 ok가 false이고, 그때는 위 끝 없이 끝까지 걸으면 된다.
+func prefixEnd[K ~string](prefix K) (end K, ok bool) {
+	b := []byte(prefix)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] < 0xff {
+			b[i]++
+			return K(b[:i+1]), true
+		}
+	}
+	return end, false
+}
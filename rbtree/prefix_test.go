@@ -0,0 +1,54 @@
+package rbtree
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAscendPrefix(t *testing.T) {
+	tree := New[string, int]()
+	keys := []string{
+		"", "a", "acme:", "acme:users:1", "acme:users:2", "acme:zones:1", "acme;", "acmf",
+		"b", "beta:users:1", "\xff", "\xff\xff", "\xff\xffa",
+	}
+	for i, k := range keys {
+		tree.Insert(k, i)
+	}
+
+	for _, prefix := range []string{"", "acme:", "acme:users:", "acme", "b", "zzz", "\xff", "\xff\xff"} {
+		var want []string
+		for _, k := range keys {
+			if strings.HasPrefix(k, prefix) {
+				want = append(want, k)
+			}
+		}
+		slices.Sort(want)
+		var got []string
+		AscendPrefix(tree, prefix, func(key string, _ int) bool {
+			got = append(got, key)
+			return true
+		})
+		if !slices.Equal(got, want) {
+			t.Fatalf("AscendPrefix(%q) = %q, want %q", prefix, got, want)
+		}
+	}
+
+	var got []string
+	AscendPrefix(tree, "acme:", func(key string, _ int) bool {
+		got = append(got, key)
+		return len(got) < 2
+	})
+	if len(got) != 2 {
+		t.Fatalf("AscendPrefix should stop when fn returns false, got %q", got)
+	}
+}
+
+func TestPrefixEnd(t *testing.T) {
+	for prefix, want := range map[string]string{"abc": "abd", "ab\xff": "ac", "a\xff\xff": "b", "": "", "\xff": ""} {
+		end, ok := prefixEnd(prefix)
+		if end != want || ok != (want != "") {
+			t.Fatalf("prefixEnd(%q) = %q, %v, want %q", prefix, end, ok, want)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package rbtree
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// PrintBox는 트리를 ├──/└── 연결선으로 그린다. 부모 아래에 왼쪽 자식(L), 오른쪽 자식(R) 순으로
+// 놓고 세로선으로 형제를 이어 주므로, Print의 들여쓰기만으로는 헷갈리는 깊은 노드의 부모를
+// 바로 알아볼 수 있다. w가 nil이면 stdout으로 대체하며, 옵션은 Print와 같다.
+//
+//	[B] 20 => 20
+//	├── L [R] 10 => 10
+//	│   ├── L [B] 5 => 5
+//	│   └── R [B] 15 => 15
+//	└── R [B] 30 => 30
+func (t *Tree[K, V]) PrintBox(w io.Writer, opts ...PrintOption) {
+	if w == nil {
+		w = os.Stdout
+	}
+	if t.root == nil {
+		fmt.Fprintln(w, "(empty)")
+		return
+	}
+	cfg := newPrintConfig(w, opts)
+	fmt.Fprintln(w, nodeLabel(cfg, t.root))
+	printBoxChildren(w, cfg, t.root, "", 1)
+}
+
+// printBoxChildren은 깊이 depth에 있는 node의 자식들을 prefix 뒤에 연결선과 함께 출력한다.
+func printBoxChildren[K any, V any](w io.Writer, cfg printConfig, node *Node[K, V], prefix string, depth int) {
+	if cfg.maxDepth > 0 && depth >= cfg.maxDepth {
+		if node.Left != nil || node.Right != nil {
+			fmt.Fprintf(w, "%s└── ...\n", prefix)
+		}
+		return
+	}
+	type child struct {
+		side string
+		node *Node[K, V]
+	}
+	var children []child
+	if node.Left != nil {
+		children = append(children, child{"L", node.Left})
+	}
+	if node.Right != nil {
+		children = append(children, child{"R", node.Right})
+	}
+	for i, c := range children {
+		connector, extension := "├── ", "│   "
+		if i == len(children)-1 {
+			connector, extension = "└── ", "    "
+		}
+		fmt.Fprintf(w, "%s%s%s %s\n", prefix, connector, c.side, nodeLabel(cfg, c.node))
+		printBoxChildren(w, cfg, c.node, prefix+extension, depth+1)
+	}
+}
+
+// ColorMode는 출력 함수가 노드 색을 터미널 색으로 칠할지 정한다.
+type ColorMode int
+
+const (
+	ColorNever  ColorMode = iota // 색을 칠하지 않는다(기본값).
+	ColorAuto                    // w가 터미널이고 NO_COLOR가 설정되지 않았을 때만 칠한다.
+	ColorAlways                  // 언제나 ANSI 색 코드를 쓴다.
+)
+
+// PrintOption은 Print, PrintBox의 출력 방식을 바꾼다.
+type PrintOption func(*printConfig)
+
+// WithColor는 빨강 노드를 빨간색으로, 검정 노드를 굵게 출력하게 한다. 색 규칙(빨강 노드의 자식은
+// 검정)이 한눈에 보여 수업 시연에 쓰기 좋다.
+func WithColor(mode ColorMode) PrintOption {
+	return func(c *printConfig) { c.colorMode = mode }
+}
+
+// WithMaxDepth는 루트부터 depth 단계까지만 출력하고, 그보다 깊은 서브트리는 "..." 한 줄로 줄인다.
+// 루트만 보려면 1을 준다. 0 이하는 제한 없음이다. 큰 트리의 위쪽 모양만 훑어볼 때 쓴다.
+func WithMaxDepth(depth int) PrintOption {
+	return func(c *printConfig) { c.maxDepth = depth }
+}
+
+type printConfig struct {
+	maxDepth  int // 0 이하면 제한 없음
+	colorMode ColorMode
+	color     bool // colorMode를 출력 대상에 맞춰 판정한 결과
+}
+
+func newPrintConfig(w io.Writer, opts []PrintOption) printConfig {
+	var cfg printConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	switch cfg.colorMode {
+	case ColorAlways:
+		cfg.color = true
+	case ColorAuto:
+		cfg.color = isTerminal(w) && os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb"
+	}
+	return cfg
+}
+
+// isTerminal은 w가 문자 장치(터미널)에 연결된 파일인지 확인한다.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+const (
+	ansiRed   = "\x1b[31m"
+	ansiBold  = "\x1b[1m"
+	ansiReset = "\x1b[0m"
+)
+
+// nodeLabel은 출력 함수들이 노드 한 줄에 쓰는 "[색] 키 => 값" 표기다.
+func nodeLabel[K any, V any](cfg printConfig, node *Node[K, V]) string {
+	var label string
+	if node.deleted {
+		label = fmt.Sprintf("[%s] %v (deleted)", colorString(node), node.Key)
+	} else {
+		label = fmt.Sprintf("[%s] %v => %v", colorString(node), node.Key, node.Value)
+	}
+	if !cfg.color {
+		return label
+	}
+	if node.Color == red {
+		return ansiRed + label + ansiReset
+	}
+	return ansiBold + label + ansiReset
+}
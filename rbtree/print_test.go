@@ -0,0 +1,102 @@
+package rbtree
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrintBox(t *testing.T) {
+	tree := New[int, int]()
+	for _, k := range []int{20, 10, 30, 5, 15, 1} {
+		tree.Insert(k, k)
+	}
+	var buf bytes.Buffer
+	tree.PrintBox(&buf)
+	want := `[B] 20 => 20
+├── L [R] 10 => 10
+│   ├── L [B] 5 => 5
+│   │   └── L [R] 1 => 1
+│   └── R [B] 15 => 15
+└── R [B] 30 => 30
+`
+	if buf.String() != want {
+		t.Fatalf("unexpected box drawing:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	New[int, int]().PrintBox(&buf)
+	if buf.String() != "(empty)\n" {
+		t.Fatalf("empty tree should print (empty), got %q", buf.String())
+	}
+}
+
+func TestPrintColor(t *testing.T) {
+	tree := New[int, int]()
+	tree.Insert(2, 2)
+	tree.Insert(1, 1)
+
+	var buf bytes.Buffer
+	tree.Print(&buf, WithColor(ColorAlways))
+	want := "\x1b[1m[B] 2 => 2\x1b[0m\n  \x1b[31m[R] 1 => 1\x1b[0m\n"
+	if buf.String() != want {
+		t.Fatalf("expected colored output %q, got %q", want, buf.String())
+	}
+
+	// 버퍼는 터미널이 아니므로 자동 모드에서는 색을 칠하지 않는다.
+	buf.Reset()
+	tree.PrintBox(&buf, WithColor(ColorAuto))
+	if bytes.Contains(buf.Bytes(), []byte("\x1b[")) {
+		t.Fatalf("auto color should fall back to plain text for non-terminals, got %q", buf.String())
+	}
+}
+
+func TestPrintMaxDepth(t *testing.T) {
+	tree := New[int, int]()
+	for _, k := range []int{20, 10, 30, 5, 15, 1} {
+		tree.Insert(k, k)
+	}
+	var buf bytes.Buffer
+	tree.Print(&buf, WithMaxDepth(2))
+	want := `  [B] 30 => 30
+[B] 20 => 20
+    ...
+  [R] 10 => 10
+    ...
+`
+	if buf.String() != want {
+		t.Fatalf("unexpected truncated print:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	tree.PrintBox(&buf, WithMaxDepth(2))
+	want = `[B] 20 => 20
+├── L [R] 10 => 10
+│   └── ...
+└── R [B] 30 => 30
+`
+	if buf.String() != want {
+		t.Fatalf("unexpected truncated box drawing:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestPrintLargeTree(t *testing.T) {
+	// 디버그 확인이 켜진 빌드에서도 빠르도록 Insert를 되풀이하지 않고 InsertMany로 한 번에 만든다.
+	pairs := make([]Pair[int, int], 100_000)
+	for i := range pairs {
+		pairs[i] = Pair[int, int]{Key: i, Value: i}
+	}
+	tree := New[int, int]()
+	tree.InsertMany(pairs)
+	var buf bytes.Buffer
+	tree.Print(&buf)
+	if lines := bytes.Count(buf.Bytes(), []byte("\n")); lines != tree.Size() {
+		t.Fatalf("printed %d lines, want %d", lines, tree.Size())
+	}
+
+	// 쓰기가 실패하면 나머지 노드는 건너뛴다.
+	w := &failingWriter{}
+	tree.Print(w)
+	if w.n != 1 {
+		t.Fatalf("Print kept writing after an error: %d writes", w.n)
+	}
+}
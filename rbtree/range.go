@@ -0,0 +1,74 @@
+package rbtree
+
+// AscendRange는 [lo, hi) 구간의 키를 오름차순으로 방문하며 fn을 호출한다. fn이 false를
+// 돌려주면 즉시 멈춘다. 시작 위치를 O(log n)에 찾고 구간 안의 원소만 걷기 때문에, 키 공간의
+// 작은 조각만 필요할 때 전체를 도는 InOrder보다 훨씬 싸다. lo >= hi이면 아무것도 방문하지 않는다.
+func (t *Tree[K, V]) AscendRange(lo, hi K, fn func(key K, value V) bool) {
+	mods := t.mods
+	for node := t.ceiling(lo); node != nil && t.compare(node.Key, hi) < 0; node = nextLive(node) {
+		if !fn(node.Key, node.Value) {
+			return
+		}
+		t.checkMods(mods)
+	}
+}
+
+// Descend는 모든 키를 내림차순으로 방문하며 fn을 호출한다. fn이 false를 돌려주면 즉시 멈추므로
+// "최근 N개"처럼 큰 쪽부터 몇 개만 필요할 때 슬라이스를 모아 뒤집지 않아도 된다.
+func (t *Tree[K, V]) Descend(fn func(key K, value V) bool) {
+	mods := t.mods
+	for node := t.last(); node != nil; node = prevLive(node) {
+		if !fn(node.Key, node.Value) {
+			return
+		}
+		t.checkMods(mods)
+	}
+}
+
+// DescendRange는 (lo, hi] 구간의 키를 hi 쪽부터 내림차순으로 방문한다. AscendRange를 거꾸로
+// 읽은 것처럼 시작점(hi)은 포함하고 끝점(lo)은 제외한다. fn이 false를 돌려주면 즉시 멈춘다.
+func (t *Tree[K, V]) DescendRange(hi, lo K, fn func(key K, value V) bool) {
+	mods := t.mods
+	for node := t.floor(hi); node != nil && t.compare(node.Key, lo) > 0; node = prevLive(node) {
+		if !fn(node.Key, node.Value) {
+			return
+		}
+		t.checkMods(mods)
+	}
+}
+
+// DeleteRange는 [lo, hi) 구간의 키를 모두 지우고 지운 개수를 돌려준다. AscendRange와 같은 반열림 구간이다.
+// 트리를 lo와 hi에서 split한 뒤 가운데 조각을 버리고 양쪽을 다시 join하므로, 지우는 키가 많아도
+// 재배치 비용은 O(log n)이다. 톰스톤 모드에서는 버리는 조각의 톰스톤 수를 세느라 그 조각을 한 번 훑는다.
+// lo >= hi이면 아무것도 지우지 않는다.
+func (t *Tree[K, V]) DeleteRange(lo, hi K) int {
+	t.willWrite()
+	if t.root == nil || t.compare(lo, hi) >= 0 {
+		return 0
+	}
+	l, hl, rest, hrest := t.split(t.root, blackHeightOf(t.root), lo)
+	mid, _, r, hr := t.split(rest, hrest, hi)
+	removed := countOf(mid)
+	if t.dead > 0 {
+		t.dead -= countDead(mid)
+	}
+	root, _ := concat(l, hl, r, hr)
+	t.setRoot(root)
+	if removed > 0 {
+		t.vars.deletedMany(removed, t.size)
+		t.afterDeleteAll(mid, removed)
+	}
+	return removed
+}
+
+// countDead는 서브트리 안의 톰스톤 노드 수를 센다.
+func countDead[K any, V any](node *Node[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	n := countDead(node.Left) + countDead(node.Right)
+	if node.deleted {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,115 @@
+package rbtree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestAscendRange(t *testing.T) {
+	tree := New[int, int]()
+	for i := 0; i < 100; i += 5 {
+		tree.Insert(i, i)
+	}
+
+	collect := func(lo, hi, stopAfter int) []int {
+		var keys []int
+		tree.AscendRange(lo, hi, func(key, value int) bool {
+			keys = append(keys, key)
+			return len(keys) != stopAfter
+		})
+		return keys
+	}
+
+	if got := collect(12, 31, -1); !slices.Equal(got, []int{15, 20, 25, 30}) {
+		t.Fatalf("AscendRange(12, 31) expected [15 20 25 30], got %v", got)
+	}
+	if got := collect(15, 30, -1); !slices.Equal(got, []int{15, 20, 25}) {
+		t.Fatalf("AscendRange should include lo and exclude hi, got %v", got)
+	}
+	if got := collect(0, 100, 3); !slices.Equal(got, []int{0, 5, 10}) {
+		t.Fatalf("AscendRange should stop when fn returns false, got %v", got)
+	}
+	if got := collect(50, 50, -1); len(got) != 0 {
+		t.Fatalf("empty interval should visit nothing, got %v", got)
+	}
+	if got := collect(200, 300, -1); len(got) != 0 {
+		t.Fatalf("interval above all keys should visit nothing, got %v", got)
+	}
+}
+
+func TestDescend(t *testing.T) {
+	tree := New[int, int]()
+	for i := 0; i < 100; i += 5 {
+		tree.Insert(i, i)
+	}
+
+	var latest []int
+	tree.Descend(func(key, value int) bool {
+		latest = append(latest, key)
+		return len(latest) < 3
+	})
+	if !slices.Equal(latest, []int{95, 90, 85}) {
+		t.Fatalf("Descend should yield the three largest keys first, got %v", latest)
+	}
+
+	var keys []int
+	tree.DescendRange(31, 12, func(key, value int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if !slices.Equal(keys, []int{30, 25, 20, 15}) {
+		t.Fatalf("DescendRange(31, 12) expected [30 25 20 15], got %v", keys)
+	}
+
+	keys = keys[:0]
+	tree.DescendRange(30, 15, func(key, value int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if !slices.Equal(keys, []int{30, 25, 20}) {
+		t.Fatalf("DescendRange should include hi and exclude lo, got %v", keys)
+	}
+}
+
+func TestDeleteRange(t *testing.T) {
+	for _, tree := range []*Tree[int, int]{New[int, int](), NewWithTombstones[int, int]()} {
+		model := map[int]bool{}
+		for round := 0; round < 200; round++ {
+			for i := 0; i < 20; i++ {
+				k := rand.Intn(500)
+				tree.Insert(k, k)
+				model[k] = true
+			}
+			for i := 0; i < 5; i++ {
+				k := rand.Intn(500)
+				tree.Delete(k) // 톰스톤 모드에서 버려질 조각에 톰스톤이 섞이게 한다.
+				delete(model, k)
+			}
+			lo := rand.Intn(500)
+			hi := lo + rand.Intn(60) - 10
+			want := 0
+			for k := range model {
+				if k >= lo && k < hi {
+					delete(model, k)
+					want++
+				}
+			}
+			if got := tree.DeleteRange(lo, hi); got != want {
+				t.Fatalf("DeleteRange(%d, %d) removed %d, want %d", lo, hi, got, want)
+			}
+			assertRBProperties(t, tree)
+			if tree.Size() != len(model) {
+				t.Fatalf("size %d disagrees with model %d", tree.Size(), len(model))
+			}
+		}
+		for k := range model {
+			if tree.Search(k) == nil {
+				t.Fatalf("key %d outside deleted ranges went missing", k)
+			}
+		}
+		if dead := countDead(tree.root); dead != tree.dead {
+			t.Fatalf("tombstone count %d disagrees with tree (%d)", dead, tree.dead)
+		}
+	}
+}
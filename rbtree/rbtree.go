@@ -1,11 +1,14 @@
 package rbtree
 
 import (
+	"bufio"
 	"cmp"
 	"fmt"
 	"io"
 	"os"
+	"reflect"
 	"strings"
+	"sync"
 )
 
 // 아래 구현은 CLRS 교과서에 나오는 레드-블랙 트리(RBTree)를 그대로 옮긴 것이다.
@@ -21,30 +24,101 @@ import (
 // Color는 노드의 색 상태를 표현한다.
 type Color bool
 
+// Red와 Black은 노드가 가질 수 있는 두 색이다. 외부 패키지에서도 노드 색을 비교할 수 있도록 노출한다.
 const (
-	red   Color = true
-	black Color = false
+	Red   Color = true
+	Black Color = false
+)
+
+// red와 black은 패키지 내부 코드가 써 오던 이름으로, Red/Black의 별칭이다.
+const (
+	red   = Red
+	black = Black
 )
 
 // Node는 트리의 한 정점을 표현한다. 실무 구현에서는 NIL 센티넬을 별도로 두지만,
 // 여기서는 이해를 돕기 위해 nil 포인터를 잎으로 간주하고 보정 과정에서 검정으로 취급한다.
-// K는 정렬 가능한(ordered) 키 타입이고, V는 임의의 값 타입이다.
-type Node[K cmp.Ordered, V any] struct {
+// K는 키 타입이고, V는 임의의 값 타입이다.
+type Node[K any, V any] struct {
 	Key    K
 	Value  V
 	Color  Color
 	Parent *Node[K, V]
 	Left   *Node[K, V]
 	Right  *Node[K, V]
+
+	// deleted는 톰스톤 모드에서 논리적으로만 삭제된 노드를 표시한다.
+	deleted bool
+	// count는 이 노드를 루트로 하는 서브트리에 든 살아 있는 노드 수다(순서 통계용 보강 필드).
+	// 회전과 삽입/삭제 경로에서 함께 갱신되어 Rank/Select를 O(log n)에 답할 수 있게 한다.
+	count int
+	// agg는 AugmentWith로 붙인 집계의 칸이다. 집계가 없는 트리에서는 nil이다.
+	agg any
+}
+
+// ColorName은 노드 색을 "red" 또는 "black"으로 돌려준다.
+func (n *Node[K, V]) ColorName() string {
+	if n.Color == Red {
+		return "red"
+	}
+	return "black"
 }
 
 // Tree 구조체는 루트 포인터와 원소 수를 추적하는 래퍼이다. 이 구조체에 연산 메서드를 붙여
 // 회전/보정과 같은 내부 구현을 숨기고 API만 노출한다.
-// K는 정렬 가능한(ordered) 키 타입이고, V는 임의의 값 타입이다.
-// ordered 타입은 숫자, 문자열 등 <, >, <=, >= 연산이 가능한 타입이다.
-type Tree[K cmp.Ordered, V any] struct {
+// K는 키 타입이고, V는 임의의 값 타입이다. 키의 순서는 트리가 들고 있는 비교 함수가 정하므로
+// 제로 값 Tree는 쓸 수 없고 반드시 New나 NewFunc로 만들어야 한다.
+type Tree[K any, V any] struct {
 	root *Node[K, V]
 	size int
+	vars *expvarStats
+
+	// compare는 a < b이면 음수, a == b이면 0, a > b이면 양수를 돌려준다.
+	compare func(a, b K) int
+
+	// tombstones가 켜져 있으면 Delete는 노드를 표시만 하고, dead는 그렇게 남은 노드 수다.
+	tombstones bool
+	dead       int
+
+	// maxSize가 0보다 크면 Insert 후 크기가 이를 넘지 않도록 eviction이 고른 원소를 내보낸다
+	// (NewBounded, WithMaxSize). lru는 EvictLRU일 때만 있는 최근 사용 순서다.
+	maxSize  int
+	eviction EvictionPolicy
+	lru      *lruList[K]
+	onEvict  func(K, V)
+
+	// readOnly는 Snapshot이 돌려준 트리다. shared는 노드를 스냅숏과 공유 중이라 첫 쓰기 전에 복사해야 함을 뜻한다.
+	readOnly bool
+	shared   bool
+
+	// mods는 노드가 붙거나 떨어지는 구조 변경마다 오른다. 순회 도중 구조가 바뀌었는지 알아내는 데 쓴다.
+	mods uint64
+
+	// recorder가 있으면 삽입·삭제 중 회전과 색 변경마다 프레임을 남긴다(Record).
+	recorder *Recorder[K, V]
+	// explain이 있으면 재균형 케이스가 적용될 때마다 불린다(Explain).
+	explain func(FixupCase[K])
+	// journal이 있으면 키 삽입·값 변경·삭제를 기록한다(Journal).
+	journal *Journal[K, V]
+	// 변경 직후 부르는 콜백(OnInsert, OnUpdate, OnDelete).
+	onInsert, onUpdate, onDelete func(key K, old, new V)
+
+	// metrics는 재균형 비용 카운터다(Metrics). comparisons는 CountComparisons로 켰을 때만 있다.
+	metrics     Metrics
+	comparisons *comparisonCounter[K]
+
+	// log는 WithLogger로 받은 로거다. 없으면 nil이다.
+	log *treeLogger
+	// debug가 켜져 있으면 변경마다 규칙을 다시 확인한다(WithDebugChecks).
+	debug bool
+	// nodes는 떼어 낸 노드를 다시 쓰기 위한 풀이다(WithNodePool). 없으면 nil이다.
+	nodes *sync.Pool
+	// valueSize는 MemoryUsage가 값 크기를 잴 때 쓰는 함수다(MeasureValues).
+	valueSize func(V) uint64
+	// balance는 WithBalancing으로 고른 균형 방식이다. nil이면 ClassicRB다.
+	balance balancer[K, V]
+	// aug는 AugmentWith로 붙인 서브트리 집계다. 없으면 nil이다.
+	aug augmenter[K, V]
 }
 
 // New는 빈 RBTree를 만든다. 키 타입 K와 값 타입 V를 지정하여 타입 안전한 트리를 생성한다.
@@ -52,11 +126,63 @@ type Tree[K cmp.Ordered, V any] struct {
 // 예: tree := rbtree.New[string, int]()  // 문자열 키, 정수 값
 //
 //	tree := rbtree.New[int, string]()  // 정수 키, 문자열 값
-func New[K cmp.Ordered, V any]() *Tree[K, V] {
-	return &Tree[K, V]{}
+func New[K cmp.Ordered, V any](opts ...Option) *Tree[K, V] {
+	return (&Tree[K, V]{compare: cmp.Compare[K]}).apply(opts)
+}
+
+// NewFunc는 less로 키 순서를 정하는 빈 트리를 만든다. 구조체 복합 키나 버전 문자열처럼
+// <, > 연산이 없거나 기본 순서가 맞지 않는 키에 쓴다. less는 엄격한 약순서(strict weak
+// ordering)여야 하며, less(a, b)와 less(b, a)가 모두 false인 두 키는 같은 키로 취급한다.
+// 예: tree := rbtree.NewFunc[Version, string](func(a, b Version) bool { return a.Less(b) })
+func NewFunc[K any, V any](less func(a, b K) bool, opts ...Option) *Tree[K, V] {
+	return (&Tree[K, V]{compare: compareFromLess(less)}).apply(opts)
+}
+
+// ensureCompare는 제로값 Tree에 디코딩할 때 kindCompare로 비교 함수를 채운다.
+// K가 기본 순서를 가진 종류가 아니면 에러를 돌려준다.
+func (t *Tree[K, V]) ensureCompare() error {
+	if t.compare != nil {
+		return nil
+	}
+	if t.compare = kindCompare[K](); t.compare == nil {
+		var zero K
+		return fmt.Errorf("rbtree: cannot decode into a zero Tree with unordered key type %T", zero)
+	}
+	return nil
+}
+
+// kindCompare는 K의 기저 종류(reflect.Kind)가 정수, 실수, 문자열이면 그 자연 순서로 비교하는 함수를,
+// 아니면 nil을 돌려준다. 제로값 트리로 디코딩할 때처럼 cmp.Ordered 제약 없이 순서를 정해야 할 때 쓴다.
+// 반사(reflect)를 거치므로 New로 만든 트리의 비교보다 느리다.
+func kindCompare[K any]() func(a, b K) int {
+	switch reflect.TypeFor[K]().Kind() {
+	case reflect.String:
+		return func(a, b K) int { return cmp.Compare(reflect.ValueOf(a).String(), reflect.ValueOf(b).String()) }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b K) int { return cmp.Compare(reflect.ValueOf(a).Int(), reflect.ValueOf(b).Int()) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b K) int { return cmp.Compare(reflect.ValueOf(a).Uint(), reflect.ValueOf(b).Uint()) }
+	case reflect.Float32, reflect.Float64:
+		return func(a, b K) int { return cmp.Compare(reflect.ValueOf(a).Float(), reflect.ValueOf(b).Float()) }
+	}
+	return nil
 }
 
-// Size는 현재 저장된 키 개수를 돌려준다.
+// compareFromLess는 less 함수를 3-way 비교 함수로 바꾼다.
+func compareFromLess[K any](less func(a, b K) bool) func(a, b K) int {
+	return func(a, b K) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	}
+}
+
+// Size는 현재 저장된 키 개수를 돌려준다. 톰스톤으로 표시된 노드는 세지 않는다.
 func (t *Tree[K, V]) Size() int {
 	return t.size
 }
@@ -67,10 +193,39 @@ func (t *Tree[K, V]) Root() *Node[K, V] {
 }
 
 // Search는 키를 가진 노드를 찾아 돌려준다. 일반적인 BST 탐색이므로 트리 구조를 바꾸지 않는다.
+// 톰스톤으로 표시된 노드는 없는 것으로 취급한다.
 func (t *Tree[K, V]) Search(key K) *Node[K, V] {
+	node := t.find(key)
+	if node == nil || node.deleted {
+		return nil
+	}
+	return node
+}
+
+// Get은 키에 대응하는 값을 돌려준다. 키가 없으면 V의 제로값과 false를 돌려준다.
+// 노드 포인터를 다룰 필요가 없는 일반적인 조회에는 Search 대신 이것을 쓰면 된다.
+// WithMaxSize(n, EvictLRU)로 만든 트리에서는 찾은 키를 가장 최근에 쓴 키로 옮긴다.
+func (t *Tree[K, V]) Get(key K) (V, bool) {
+	if node := t.Search(key); node != nil {
+		if t.lru != nil {
+			t.lru.touch(key)
+		}
+		return node.Value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains는 키가 트리에 있는지 알려 준다.
+func (t *Tree[K, V]) Contains(key K) bool {
+	return t.Search(key) != nil
+}
+
+// find는 톰스톤 여부와 관계없이 키를 가진 노드를 찾는다.
+func (t *Tree[K, V]) find(key K) *Node[K, V] {
 	cur := t.root
 	for cur != nil {
-		cmp := cmp.Compare(key, cur.Key)
+		cmp := t.compare(key, cur.Key)
 		switch {
 		case cmp < 0:
 			cur = cur.Left
@@ -83,50 +238,141 @@ func (t *Tree[K, V]) Search(key K) *Node[K, V] {
 	return nil
 }
 
-// Insert는 키를 삽입한다. 단순화를 위해 중복 키는 무시하지만, 필요하다면 갯수 누적 등의 동작으로 확장할 수 있다.
+// Insert는 키를 삽입한다. 같은 키가 이미 있으면 값을 덮어쓴다. 같은 키를 여러 번 담으려면 MultiMap을, 횟수만 세려면 MultiSet을 쓴다.
 func (t *Tree[K, V]) Insert(key K, value V) {
-	var parent *Node[K, V]
-	cur := t.root
+	t.Put(key, value)
+}
 
-	// 먼저 일반 BST 삽입을 통해 부모 위치를 찾는다.
+// Put은 Insert와 같지만, 이미 있던 키의 값을 덮어썼다면 이전 값과 true를 돌려준다.
+// 새 키였다면 V의 제로값과 false다. 캐시처럼 덮어쓴 항목을 따로 정산해야 할 때 쓴다.
+func (t *Tree[K, V]) Put(key K, value V) (previous V, replaced bool) {
+	t.willWrite()
+	node, parent, left := t.locate(key)
+	switch {
+	case node == nil:
+		t.link(parent, left, key, value)
+	case node.deleted:
+		t.revive(node, value)
+	default:
+		previous, node.Value = node.Value, value
+		replaced = true
+		t.augmentPath(node)
+		t.afterUpdate(key, previous, value)
+	}
+	return previous, replaced
+}
+
+// locate는 일반 BST 탐색으로 key를 찾는다. 키가 있으면 (톰스톤이어도) 그 노드를, 없으면
+// 새 노드를 붙일 부모와 방향을 돌려주므로 호출부는 한 번 내려가서 갱신과 삽입을 모두 처리할 수 있다.
+func (t *Tree[K, V]) locate(key K) (node, parent *Node[K, V], left bool) {
+	cur := t.root
 	for cur != nil {
-		parent = cur
-		cmp := cmp.Compare(key, cur.Key)
-		switch {
-		case cmp < 0:
+		cmp := t.compare(key, cur.Key)
+		if cmp == 0 {
+			return cur, nil, false
+		}
+		parent, left = cur, cmp < 0
+		if left {
 			cur = cur.Left
-		case cmp > 0:
+		} else {
 			cur = cur.Right
-		default:
-			// 이미 존재하는 키면 값을 갱신하고 종료한다.
-			cur.Value = value
-			return
 		}
 	}
+	return nil, parent, left
+}
 
+// revive는 톰스톤 노드를 구조를 건드리지 않고 value로 되살린다.
+func (t *Tree[K, V]) revive(node *Node[K, V], value V) {
+	node.Value = value
+	node.deleted = false
+	t.dead--
+	adjustCounts(node, 1)
+	t.augmentPath(node)
+	t.size++
+	t.mods++
+	t.debugCheck("insert", node.Key)
+	t.vars.inserted(t.size)
+	t.afterInsert(node.Key, value)
+	t.evictOverflow()
+}
+
+// link는 새 노드를 parent의 왼쪽(left가 true) 또는 오른쪽 자식으로 붙이고 규칙을 복구한다.
+// parent가 nil이면 빈 트리의 루트가 된다. 자리가 비어 있고 순서가 맞는지는 호출부가 보장한다.
+func (t *Tree[K, V]) link(parent *Node[K, V], left bool, key K, value V) *Node[K, V] {
 	// 삽입 노드는 항상 빨강으로 시작한다. 검정으로 넣으면 규칙 (4)가 깨질 수 있다.
-	node := &Node[K, V]{Key: key, Value: value, Color: red, Parent: parent}
+	node := t.newNode(key, value)
+	node.Color, node.Parent, node.count = red, parent, 1
 	if parent == nil {
 		t.root = node
-	} else if cmp.Compare(node.Key, parent.Key) < 0 {
+	} else if left {
 		parent.Left = node
 	} else {
 		parent.Right = node
 	}
+	adjustCounts(parent, 1)
+	t.augmentPath(node)
+	t.mods++
+	t.record("insert %v", key)
 
 	// 구조적 삽입 뒤 망가졌을 수 있는 규칙을 insertFixup으로 복원한다.
-	t.insertFixup(node)
+	before := t.metrics
+	t.balancer().insertFixup(t, node)
+	t.logFixup("insert", key, before)
 	t.size++
+	t.debugCheck("insert", key)
+	t.vars.inserted(t.size)
+	t.afterInsert(key, value)
+	t.evictOverflow()
+	return node
 }
 
 // Delete는 주어진 키를 삭제한다. 검정 노드를 제거하면 규칙 (2)(4)가 깨질 수 있으므로
 // double black 개념을 사용해 위로 전파하면서 복구한다.
+// 톰스톤 모드에서는 노드를 삭제 표시만 하고 구조 조정은 Compact로 미룬다.
 func (t *Tree[K, V]) Delete(key K) bool {
+	t.willWrite()
 	node := t.Search(key)
 	if node == nil {
 		return false
 	}
+	t.remove(node)
+	return true
+}
+
+// Pop은 키를 삭제하고 삭제된 값을 돌려준다. 키가 없으면 V의 제로값과 false다.
+// Search 후 Delete처럼 트리를 두 번 내려가지 않는다.
+func (t *Tree[K, V]) Pop(key K) (V, bool) {
+	t.willWrite()
+	_, value, ok := t.pop(t.Search(key))
+	return value, ok
+}
+
+// remove는 살아 있는 node를 삭제한다. 톰스톤 모드면 표시만 하고, 아니면 구조적으로 떼어 낸다.
+func (t *Tree[K, V]) remove(node *Node[K, V]) {
+	key, value := node.Key, node.Value
+	if t.tombstones {
+		var zero V
+		node.deleted = true
+		node.Value = zero // 값이 붙잡고 있는 메모리는 바로 놓아 준다.
+		t.dead++
+		adjustCounts(node, -1)
+		t.augmentPath(node)
+		t.record("mark %v deleted", node.Key)
+	} else {
+		t.balancer().unlink(t, node)
+	}
+	t.size--
+	t.mods++
+	t.debugCheck("delete", key)
+	t.vars.deleted(t.size)
+	t.afterDelete(key, value)
+	if !t.tombstones {
+		t.release(node)
+	}
+}
 
+// deleteNode는 node를 트리에서 구조적으로 떼어 내고 규칙을 복구한다. size는 호출부가 관리한다.
+func (t *Tree[K, V]) deleteNode(node *Node[K, V]) {
 	originalColor := node.Color
 	var x, replacementParent *Node[K, V]
 
@@ -161,20 +407,35 @@ func (t *Tree[K, V]) Delete(key K) bool {
 		successor.Color = node.Color
 	}
 
+	// 떼어 낸 자리부터 루트까지 서브트리 크기를 다시 센다. 이후 보정 회전은 rotate가 직접 맞춘다.
+	for p := replacementParent; p != nil; p = p.Parent {
+		updateCount(p)
+		t.augmentNode(p)
+	}
+	t.record("delete %v", node.Key)
+
 	if originalColor == black {
+		before := t.metrics
 		t.deleteFixup(x, replacementParent)
+		t.logFixup("delete", node.Key, before)
 	}
-	t.size--
-	return true
 }
 
 // InOrder는 키를 정렬 순서대로 순회하며 fn을 호출한다. 테스트에서 구조를 확인할 때 유용하다.
+// fn 안에서 트리 구조를 바꾸면 ErrModifiedDuringIteration으로 panic한다.
 func (t *Tree[K, V]) InOrder(fn func(key K, value V)) {
-	inOrder(t.root, fn)
+	mods := t.mods
+	inOrder(t.root, func(key K, value V) {
+		fn(key, value)
+		t.checkMods(mods)
+	})
 }
 
 // Print은 트리 구조를 들여쓰기 형태로 출력한다. w가 nil이면 stdout으로 대체한다.
-func (t *Tree[K, V]) Print(w io.Writer) {
+// WithColor로 빨강/검정 노드를 터미널 색으로 칠할 수 있고, WithMaxDepth로 깊은 노드를 "..."로
+// 줄일 수 있다. 재귀 없이 돌며 출력은 버퍼를 거쳐 조금씩 w로 나가므로 아주 큰 트리도 메모리에
+// 한꺼번에 쌓지 않는다. w에 쓰다 에러가 나면 거기서 멈춘다.
+func (t *Tree[K, V]) Print(w io.Writer, opts ...PrintOption) {
 	if w == nil {
 		w = os.Stdout
 	}
@@ -182,35 +443,41 @@ func (t *Tree[K, V]) Print(w io.Writer) {
 		fmt.Fprintln(w, "(empty)")
 		return
 	}
-	printNode(w, t.root, 0)
+	bw := bufio.NewWriter(w)
+	printNode(bw, newPrintConfig(w, opts), t.root)
+	bw.Flush()
 }
 
-// PrintStdout은 편의를 위해 stdout으로 바로 출력한다.
+// PrintStdout은 편의를 위해 stdout으로 바로 출력한다. stdout이 터미널이면 노드 색을 칠한다.
 func (t *Tree[K, V]) PrintStdout() {
-	t.Print(os.Stdout)
+	t.Print(os.Stdout, WithColor(ColorAuto))
 }
 
 // insertFixup은 삽입으로 깨진 RB 규칙을 되돌린다. 빨강 부모-자식이 없어질 때까지 색을 바꾸거나 회전한다.
 func (t *Tree[K, V]) insertFixup(node *Node[K, V]) {
 	for node != t.root && colorOf(node.Parent) == red {
+		t.metrics.FixupIterations++
 		if node.Parent == node.Parent.Parent.Left {
 			uncle := node.Parent.Parent.Right
 			switch colorOf(uncle) {
 			case red:
 				// Case 1: 부모와 삼촌이 모두 빨강이면 둘 다 검정으로 바꾸고 할아버지를 빨강으로 올린다.
-				node.Parent.Color = black
-				uncle.Color = black
-				node.Parent.Parent.Color = red
+				t.explainCase(insertCase1, node, node.Parent, uncle, node.Parent.Parent)
+				t.setColor(node.Parent, black)
+				t.setColor(uncle, black)
+				t.setColor(node.Parent.Parent, red)
 				node = node.Parent.Parent
 			default:
-				if node == node.Parent.Parent.Right {
+				if node == node.Parent.Right {
 					// Case 2: 현재 노드가 오른쪽 자식이면 회전해서 Case 3으로 만들어 준다.
+					t.explainCase(insertCase2, node, node.Parent, uncle, node.Parent.Parent)
 					node = node.Parent
 					t.rotateLeft(node)
 				}
 				// Case 3: 현재 노드가 왼쪽 자식이므로 부모-할아버지 색을 뒤집고 오른쪽 회전한다.
-				node.Parent.Color = black
-				node.Parent.Parent.Color = red
+				t.explainCase(insertCase3, node, node.Parent, uncle, node.Parent.Parent)
+				t.setColor(node.Parent, black)
+				t.setColor(node.Parent.Parent, red)
 				t.rotateRight(node.Parent.Parent)
 			}
 		} else {
@@ -218,83 +485,100 @@ func (t *Tree[K, V]) insertFixup(node *Node[K, V]) {
 			uncle := node.Parent.Parent.Left
 			switch colorOf(uncle) {
 			case red:
-				node.Parent.Color = black
-				uncle.Color = black
-				node.Parent.Parent.Color = red
+				t.explainCase(insertCase1, node, node.Parent, uncle, node.Parent.Parent)
+				t.setColor(node.Parent, black)
+				t.setColor(uncle, black)
+				t.setColor(node.Parent.Parent, red)
 				node = node.Parent.Parent
 			default:
 				if node == node.Parent.Left {
+					t.explainCase(insertCase2, node, node.Parent, uncle, node.Parent.Parent)
 					node = node.Parent
 					t.rotateRight(node)
 				}
-				node.Parent.Color = black
-				node.Parent.Parent.Color = red
+				t.explainCase(insertCase3, node, node.Parent, uncle, node.Parent.Parent)
+				t.setColor(node.Parent, black)
+				t.setColor(node.Parent.Parent, red)
 				t.rotateLeft(node.Parent.Parent)
 			}
 		}
 	}
-	t.root.Color = black
+	t.setColor(t.root, black)
 }
 
 // deleteFixup은 검정 노드 삭제 후 생기는 double black을 제거한다.
 // x가 nil일 수도 있으므로 parent를 함께 넘겨 nil 역참조를 피한다.
 func (t *Tree[K, V]) deleteFixup(x, parent *Node[K, V]) {
 	for (x != t.root) && colorOf(x) == black {
+		t.metrics.FixupIterations++
 		if x == leftOf(parent) {
 			sibling := rightOf(parent)
 			if colorOf(sibling) == red {
-				sibling.Color = black
-				parent.Color = red
+				// Case 1: 형제가 빨강이면 형제와 부모 색을 바꾸고 부모에서 회전해 형제를 검정으로 만든다.
+				t.explainCase(deleteCase1, x, parent, sibling)
+				t.setColor(sibling, black)
+				t.setColor(parent, red)
 				t.rotateLeft(parent)
 				sibling = rightOf(parent)
 			}
 			if colorOf(sibling.Left) == black && colorOf(sibling.Right) == black {
-				sibling.Color = red
+				// Case 2: 형제의 두 자식이 모두 검정이면 형제를 빨강으로 바꾸고 double black을 부모로 올린다.
+				t.explainCase(deleteCase2, x, parent, sibling)
+				t.setColor(sibling, red)
 				x = parent
 				parent = x.Parent
 			} else {
 				if colorOf(sibling.Right) == black {
+					// Case 3: 먼 조카가 검정이고 가까운 조카가 빨강이면 형제에서 회전해 Case 4로 만든다.
+					t.explainCase(deleteCase3, x, parent, sibling)
 					if sibling.Left != nil {
-						sibling.Left.Color = black
+						t.setColor(sibling.Left, black)
 					}
-					sibling.Color = red
+					t.setColor(sibling, red)
 					t.rotateRight(sibling)
 					sibling = rightOf(parent)
 				}
-				sibling.Color = colorOf(parent)
-				parent.Color = black
+				// Case 4: 먼 조카가 빨강이면 색을 옮기고 부모에서 회전해 double black을 없앤다.
+				t.explainCase(deleteCase4, x, parent, sibling)
+				t.setColor(sibling, colorOf(parent))
+				t.setColor(parent, black)
 				if sibling.Right != nil {
-					sibling.Right.Color = black
+					t.setColor(sibling.Right, black)
 				}
 				t.rotateLeft(parent)
 				x = t.root
 				parent = nil
 			}
 		} else {
+			// 왼쪽/오른쪽만 뒤바꾼 대칭 케이스.
 			sibling := leftOf(parent)
 			if colorOf(sibling) == red {
-				sibling.Color = black
-				parent.Color = red
+				t.explainCase(deleteCase1, x, parent, sibling)
+				t.setColor(sibling, black)
+				t.setColor(parent, red)
 				t.rotateRight(parent)
 				sibling = leftOf(parent)
 			}
 			if colorOf(sibling.Left) == black && colorOf(sibling.Right) == black {
-				sibling.Color = red
+				t.explainCase(deleteCase2, x, parent, sibling)
+				t.setColor(sibling, red)
 				x = parent
 				parent = x.Parent
 			} else {
 				if colorOf(sibling.Left) == black {
+					t.explainCase(deleteCase3, x, parent, sibling)
 					if sibling.Right != nil {
-						sibling.Right.Color = black
+						t.setColor(sibling.Right, black)
 					}
-					sibling.Color = red
+					t.setColor(sibling, red)
 					t.rotateLeft(sibling)
 					sibling = leftOf(parent)
 				}
-				sibling.Color = colorOf(parent)
-				parent.Color = black
+				t.explainCase(deleteCase4, x, parent, sibling)
+				t.setColor(sibling, colorOf(parent))
+				t.setColor(parent, black)
 				if sibling.Left != nil {
-					sibling.Left.Color = black
+					t.setColor(sibling.Left, black)
 				}
 				t.rotateRight(parent)
 				x = t.root
@@ -303,7 +587,7 @@ func (t *Tree[K, V]) deleteFixup(x, parent *Node[K, V]) {
 		}
 	}
 	if x != nil {
-		x.Color = black
+		t.setColor(x, black)
 	}
 }
 
@@ -324,6 +608,14 @@ func (t *Tree[K, V]) rotateLeft(node *Node[K, V]) {
 	}
 	right.Left = node
 	node.Parent = right
+
+	// 회전 후 right가 node 자리의 서브트리 전체를 차지하므로 크기를 넘겨받고, node는 다시 센다.
+	right.count = node.count
+	updateCount(node)
+	t.augmentNode(node)
+	t.augmentNode(right)
+	t.metrics.Rotations++
+	t.record("rotate left at %v", node.Key)
 }
 
 // rotateRight는 rotateLeft의 좌우 대칭이다.
@@ -343,6 +635,13 @@ func (t *Tree[K, V]) rotateRight(node *Node[K, V]) {
 	}
 	left.Right = node
 	node.Parent = left
+
+	left.count = node.count
+	updateCount(node)
+	t.augmentNode(node)
+	t.augmentNode(left)
+	t.metrics.Rotations++
+	t.record("rotate right at %v", node.Key)
 }
 
 // transplant는 서브트리 u 자리에 v를 끼워 넣는다. 삭제 과정에서 부모 포인터를 깔끔하게 유지하기 위한 헬퍼다.
@@ -361,56 +660,235 @@ func (t *Tree[K, V]) transplant(u, v *Node[K, V]) {
 
 // 헬퍼 함수들 ---------------------------------------------------------------
 
-func colorOf[K cmp.Ordered, V any](node *Node[K, V]) Color {
+func colorOf[K any, V any](node *Node[K, V]) Color {
 	if node == nil {
 		return black
 	}
 	return node.Color
 }
 
-func leftOf[K cmp.Ordered, V any](node *Node[K, V]) *Node[K, V] {
+func countOf[K any, V any](node *Node[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.count
+}
+
+// updateCount는 두 자식의 count로 node의 count를 다시 계산한다.
+func updateCount[K any, V any](node *Node[K, V]) {
+	node.count = countOf(node.Left) + countOf(node.Right)
+	if !node.deleted {
+		node.count++
+	}
+}
+
+// adjustCounts는 node부터 루트까지 모든 조상의 count에 delta를 더한다.
+func adjustCounts[K any, V any](node *Node[K, V], delta int) {
+	for ; node != nil; node = node.Parent {
+		node.count += delta
+	}
+}
+
+func leftOf[K any, V any](node *Node[K, V]) *Node[K, V] {
 	if node == nil {
 		return nil
 	}
 	return node.Left
 }
 
-func rightOf[K cmp.Ordered, V any](node *Node[K, V]) *Node[K, V] {
+func rightOf[K any, V any](node *Node[K, V]) *Node[K, V] {
 	if node == nil {
 		return nil
 	}
 	return node.Right
 }
 
-func minimum[K cmp.Ordered, V any](node *Node[K, V]) *Node[K, V] {
+func minimum[K any, V any](node *Node[K, V]) *Node[K, V] {
 	for node.Left != nil {
 		node = node.Left
 	}
 	return node
 }
 
-func inOrder[K cmp.Ordered, V any](node *Node[K, V], fn func(K, V)) {
-	if node == nil {
-		return
+// successor는 중위 순서상 다음 노드를 부모 포인터를 따라 찾는다. 없으면 nil이다.
+func successor[K any, V any](node *Node[K, V]) *Node[K, V] {
+	if node.Right != nil {
+		return minimum(node.Right)
 	}
-	inOrder(node.Left, fn)
-	fn(node.Key, node.Value)
-	inOrder(node.Right, fn)
+	parent := node.Parent
+	for parent != nil && node == parent.Right {
+		node, parent = parent, parent.Parent
+	}
+	return parent
 }
 
-func printNode[K cmp.Ordered, V any](w io.Writer, node *Node[K, V], depth int) {
-	if node == nil {
+func maximum[K any, V any](node *Node[K, V]) *Node[K, V] {
+	for node.Right != nil {
+		node = node.Right
+	}
+	return node
+}
+
+// predecessor는 successor의 좌우 대칭으로, 중위 순서상 이전 노드를 돌려준다.
+func predecessor[K any, V any](node *Node[K, V]) *Node[K, V] {
+	if node.Left != nil {
+		return maximum(node.Left)
+	}
+	parent := node.Parent
+	for parent != nil && node == parent.Left {
+		node, parent = parent, parent.Parent
+	}
+	return parent
+}
+
+// nextLive와 prevLive는 톰스톤 노드를 건너뛰며 이웃 노드로 이동한다.
+func nextLive[K any, V any](node *Node[K, V]) *Node[K, V] {
+	node = successor(node)
+	for node != nil && node.deleted {
+		node = successor(node)
+	}
+	return node
+}
+
+func prevLive[K any, V any](node *Node[K, V]) *Node[K, V] {
+	node = predecessor(node)
+	for node != nil && node.deleted {
+		node = predecessor(node)
+	}
+	return node
+}
+
+// ceiling은 key 이상인 키 가운데 가장 작은 살아 있는 노드를, floor는 key 이하인 키 가운데
+// 가장 큰 살아 있는 노드를 돌려준다. 해당하는 노드가 없으면 nil이다.
+func (t *Tree[K, V]) ceiling(key K) *Node[K, V] {
+	var candidate *Node[K, V]
+	for cur := t.root; cur != nil; {
+		c := t.compare(key, cur.Key)
+		if c == 0 {
+			candidate = cur
+			break
+		}
+		if c < 0 {
+			candidate = cur
+			cur = cur.Left
+		} else {
+			cur = cur.Right
+		}
+	}
+	if candidate != nil && candidate.deleted {
+		candidate = nextLive(candidate)
+	}
+	return candidate
+}
+
+func (t *Tree[K, V]) floor(key K) *Node[K, V] {
+	var candidate *Node[K, V]
+	for cur := t.root; cur != nil; {
+		c := t.compare(key, cur.Key)
+		if c == 0 {
+			candidate = cur
+			break
+		}
+		if c > 0 {
+			candidate = cur
+			cur = cur.Right
+		} else {
+			cur = cur.Left
+		}
+	}
+	if candidate != nil && candidate.deleted {
+		candidate = prevLive(candidate)
+	}
+	return candidate
+}
+
+// first는 톰스톤이 아닌 가장 작은 키의 노드를 돌려준다. 트리가 비었으면 nil이다.
+func (t *Tree[K, V]) first() *Node[K, V] {
+	if t.root == nil {
+		return nil
+	}
+	node := minimum(t.root)
+	if node.deleted {
+		node = nextLive(node)
+	}
+	return node
+}
+
+// last는 first의 좌우 대칭이다.
+func (t *Tree[K, V]) last() *Node[K, V] {
+	if t.root == nil {
+		return nil
+	}
+	node := maximum(t.root)
+	if node.deleted {
+		node = prevLive(node)
+	}
+	return node
+}
+
+// inOrder는 root 서브트리의 살아 있는 원소를 키 순서로 fn에 넘긴다. 재귀나 스택 없이 부모
+// 포인터를 따라 다음 노드로 옮겨 가므로 추가 메모리가 들지 않고, root 위로는 올라가지 않는다.
+func inOrder[K any, V any](root *Node[K, V], fn func(K, V)) {
+	if root == nil {
 		return
 	}
-	printNode(w, node.Right, depth+1)
-	indent := strings.Repeat("  ", depth)
-	fmt.Fprintf(w, "%s[%s] %v => %v\n", indent, colorString(node.Color), node.Key, node.Value)
-	printNode(w, node.Left, depth+1)
+	node := minimum(root)
+	for {
+		if !node.deleted {
+			fn(node.Key, node.Value)
+		}
+		if node.Right != nil {
+			node = minimum(node.Right)
+			continue
+		}
+		// 오른쪽 자식으로 온 동안 올라간다. 왼쪽 자식이었다면 그 부모가 다음 노드다.
+		for node != root && node == node.Parent.Right {
+			node = node.Parent
+		}
+		if node == root {
+			return
+		}
+		node = node.Parent
+	}
 }
 
-func colorString(c Color) string {
-	if c == red {
-		return "R"
+// printNode는 root 아래를 오른쪽 서브트리, 노드, 왼쪽 서브트리 순으로 깊이만큼 들여 써서 출력한다.
+// 명시적 스택을 쓰므로 스택 크기는 트리 높이에 비례한다. cfg.maxDepth를 넘는 서브트리는 "..." 한
+// 줄로 대신하고, 쓰기 에러가 나면 멈춘다.
+func printNode[K any, V any](w *bufio.Writer, cfg printConfig, root *Node[K, V]) {
+	type item struct {
+		node  *Node[K, V]
+		depth int
+		ready bool // 양쪽 자식을 이미 스택에 넣었으면 true다. 이때는 노드 자신을 출력한다.
 	}
-	return "B"
+	stack := []item{{node: root}}
+	for len(stack) > 0 {
+		it := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if it.node == nil {
+			continue
+		}
+		indent := strings.Repeat("  ", it.depth)
+		var err error
+		switch {
+		case it.ready:
+			_, err = fmt.Fprintf(w, "%s%s\n", indent, nodeLabel(cfg, it.node))
+		case cfg.maxDepth > 0 && it.depth >= cfg.maxDepth:
+			_, err = fmt.Fprintf(w, "%s...\n", indent)
+		default:
+			// 스택이므로 나중에 출력할 것부터 넣는다.
+			stack = append(stack,
+				item{node: it.node.Left, depth: it.depth + 1},
+				item{node: it.node, depth: it.depth, ready: true},
+				item{node: it.node.Right, depth: it.depth + 1})
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// colorString은 Print 출력에 쓰는 한 글자 색 표기("R"/"B")다.
+func colorString[K any, V any](node *Node[K, V]) string {
+	return strings.ToUpper(node.ColorName()[:1])
 }
@@ -2,7 +2,7 @@ package rbtree
 
 import (
 	"bytes"
-	"cmp"
+	"fmt"
 	"math/rand"
 	"sort"
 	"strconv"
@@ -54,6 +54,65 @@ func TestInsertAndSearch(t *testing.T) {
 	})
 }
 
+func TestPut(t *testing.T) {
+	for _, tree := range []*Tree[string, int]{New[string, int](), NewWithTombstones[string, int]()} {
+		if prev, replaced := tree.Put("a", 1); replaced || prev != 0 {
+			t.Fatalf("first Put expected (0, false), got (%d, %v)", prev, replaced)
+		}
+		if prev, replaced := tree.Put("a", 2); !replaced || prev != 1 {
+			t.Fatalf("overwriting Put expected (1, true), got (%d, %v)", prev, replaced)
+		}
+		tree.Delete("a")
+		if prev, replaced := tree.Put("a", 3); replaced || prev != 0 {
+			t.Fatalf("Put after Delete should not report a replacement, got (%d, %v)", prev, replaced)
+		}
+		if v, _ := tree.Get("a"); v != 3 || tree.Size() != 1 {
+			t.Fatalf("expected a=3 and size 1, got a=%d size %d", v, tree.Size())
+		}
+		assertRBProperties(t, tree)
+	}
+}
+
+func TestPop(t *testing.T) {
+	for _, tree := range []*Tree[string, int]{New[string, int](), NewWithTombstones[string, int]()} {
+		tree.Insert("a", 1)
+		tree.Insert("b", 2)
+		if v, ok := tree.Pop("a"); !ok || v != 1 {
+			t.Fatalf("Pop(a) expected (1, true), got (%d, %v)", v, ok)
+		}
+		if v, ok := tree.Pop("a"); ok || v != 0 {
+			t.Fatalf("second Pop(a) expected (0, false), got (%d, %v)", v, ok)
+		}
+		if tree.Size() != 1 || tree.Contains("a") {
+			t.Fatalf("Pop should remove the key")
+		}
+		assertRBProperties(t, tree)
+	}
+}
+
+func TestGetAndContains(t *testing.T) {
+	for _, tree := range []*Tree[string, int]{New[string, int](), NewWithTombstones[string, int]()} {
+		tree.Insert("a", 1)
+		tree.Insert("b", 0)
+		if v, ok := tree.Get("a"); !ok || v != 1 {
+			t.Fatalf("Get(a) expected (1, true), got (%d, %v)", v, ok)
+		}
+		if v, ok := tree.Get("b"); !ok || v != 0 {
+			t.Fatalf("Get should report zero values that are present, got (%d, %v)", v, ok)
+		}
+		if v, ok := tree.Get("z"); ok || v != 0 {
+			t.Fatalf("Get on a missing key expected (0, false), got (%d, %v)", v, ok)
+		}
+		tree.Delete("a")
+		if tree.Contains("a") || !tree.Contains("b") {
+			t.Fatalf("Contains disagrees with contents")
+		}
+		if _, ok := tree.Get("a"); ok {
+			t.Fatalf("Get should not see deleted keys")
+		}
+	}
+}
+
 func TestDelete(t *testing.T) {
 	tree := New[string, string]()
 	values := []string{"20", "15", "25", "10", "18", "8", "12", "16", "19"}
@@ -116,6 +175,35 @@ func TestRBPropertiesRandom(t *testing.T) {
 	}
 }
 
+func TestInOrderSubtree(t *testing.T) {
+	tree := NewWithTombstones[int, int]()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, i)
+	}
+	tree.Delete(10)
+
+	// 서브트리만 돌고 그 위로 올라가지 않아야 한다.
+	sub := tree.root.Left
+	var got []int
+	inOrder(sub, func(key, value int) { got = append(got, key) })
+	want := []int{}
+	for i := minimum(sub).Key; i <= maximum(sub).Key; i++ {
+		if i != 10 {
+			want = append(want, i)
+		}
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("inOrder(root.Left) = %v, want %v", got, want)
+	}
+
+	leaf := minimum(tree.root)
+	got = got[:0]
+	inOrder(leaf, func(key, value int) { got = append(got, key) })
+	if len(got) != 1 || got[0] != leaf.Key {
+		t.Fatalf("inOrder(leaf) = %v, want [%d]", got, leaf.Key)
+	}
+}
+
 func TestPrint(t *testing.T) {
 	tree := New[string, int]()
 	tree.Insert("b", 2)
@@ -140,54 +228,65 @@ func TestPrint(t *testing.T) {
 	}
 }
 
-func assertRBProperties[K cmp.Ordered, V any](t *testing.T, tree *Tree[K, V]) {
+func assertRBProperties[K any, V any](t *testing.T, tree *Tree[K, V]) {
 	t.Helper()
-	root := tree.Root()
-	if root == nil {
-		return
+	if err := tree.Validate(); err != nil {
+		t.Fatal(err)
 	}
-	if root.Color != black {
-		t.Fatalf("root must be black, got %v", root.Color)
-	}
-	checkNoRedRed(t, root)
-	expectedBlackHeight := blackHeight(root)
-	verifyBlackHeight(t, root, expectedBlackHeight, 0)
 }
 
-func checkNoRedRed[K cmp.Ordered, V any](t *testing.T, node *Node[K, V]) {
-	if node == nil {
-		return
+func TestColorName(t *testing.T) {
+	tree := New[int, int]()
+	tree.Insert(2, 2)
+	tree.Insert(1, 1)
+
+	root := tree.Root()
+	if root.Color != Black || root.ColorName() != "black" {
+		t.Fatalf("root should be black, got %q", root.ColorName())
 	}
-	if node.Color == red {
-		if colorOf(node.Left) == red || colorOf(node.Right) == red {
-			t.Fatalf("red node %v has red child", node.Key)
-		}
+	if root.Left.Color != Red || root.Left.ColorName() != "red" {
+		t.Fatalf("left child should be red, got %q", root.Left.ColorName())
+	}
+	if red != Red || black != Black {
+		t.Fatalf("unexported color aliases must match the exported constants")
 	}
-	checkNoRedRed(t, node.Left)
-	checkNoRedRed(t, node.Right)
 }
 
-func blackHeight[K cmp.Ordered, V any](node *Node[K, V]) int {
-	height := 0
-	for node != nil {
-		if node.Color == black {
-			height++
+type version struct {
+	major, minor int
+}
+
+func TestNewFuncCompositeKeys(t *testing.T) {
+	tree := NewFunc[version, string](func(a, b version) bool {
+		if a.major != b.major {
+			return a.major < b.major
 		}
-		node = node.Left
+		return a.minor < b.minor
+	})
+	versions := []version{{1, 10}, {1, 2}, {2, 0}, {0, 9}, {1, 0}}
+	for _, v := range versions {
+		tree.Insert(v, fmt.Sprintf("v%d.%d", v.major, v.minor))
 	}
-	return height
-}
+	tree.Insert(version{1, 2}, "v1.2-patched")
 
-func verifyBlackHeight[K cmp.Ordered, V any](t *testing.T, node *Node[K, V], expected, current int) {
-	if node == nil {
-		if current != expected {
-			t.Fatalf("black height mismatch: expected %d got %d", expected, current)
+	if tree.Size() != len(versions) {
+		t.Fatalf("expected size %d, got %d", len(versions), tree.Size())
+	}
+	if node := tree.Search(version{1, 2}); node == nil || node.Value != "v1.2-patched" {
+		t.Fatalf("expected equal keys under less to update in place")
+	}
+
+	var got []version
+	tree.InOrder(func(key version, _ string) { got = append(got, key) })
+	want := []version{{0, 9}, {1, 0}, {1, 2}, {1, 10}, {2, 0}}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected order %v, got %v", want, got)
 		}
-		return
 	}
-	if node.Color == black {
-		current++
+
+	if !tree.Delete(version{1, 10}) || tree.Search(version{1, 10}) != nil {
+		t.Fatalf("delete by composite key failed")
 	}
-	verifyBlackHeight(t, node.Left, expected, current)
-	verifyBlackHeight(t, node.Right, expected, current)
+	assertRBProperties(t, tree)
 }
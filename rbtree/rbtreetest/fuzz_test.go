@@ -0,0 +1,78 @@
+package rbtreetest
+
+import (
+	"testing"
+
+	"github.com/EletricSaw/rbtree/rbtree"
+)
+
+// 퍼즈 입력의 바이트는 두 개씩 (연산, 키)로 읽는다. 키를 바이트 하나로 제한해 같은 키가 자주
+// 되풀이되게 하므로 덮어쓰기와 없는 키 삭제, 삭제 보정의 여러 경우가 고루 나온다.
+const (
+	opInsert = iota
+	opDelete
+	opGet
+	opPopMin
+	opCount
+)
+
+// FuzzTreeOps는 입력을 삽입·삭제·조회·최솟값 꺼내기의 연속으로 해석해 일반 트리와 톰스톤 트리,
+// 좌편향 트리, Model에 똑같이 적용하고 매 단계마다 결과와 규칙을 비교한다.
+func FuzzTreeOps(f *testing.F) {
+	f.Add([]byte{0, 1, 0, 2, 0, 3, 1, 2})
+	f.Add([]byte{0, 5, 0, 4, 0, 3, 0, 2, 0, 1, 1, 4, 1, 5, 3, 0, 3, 0})
+	ascending := make([]byte, 0, 128)
+	for k := byte(0); k < 32; k++ {
+		ascending = append(ascending, opInsert, k)
+	}
+	for k := byte(0); k < 32; k += 3 {
+		ascending = append(ascending, opDelete, k)
+	}
+	f.Add(ascending)
+
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		trees := []*rbtree.Tree[byte, int]{
+			rbtree.New[byte, int](),
+			rbtree.NewWithTombstones[byte, int](),
+			rbtree.New[byte, int](rbtree.WithBalancing(rbtree.LeftLeaningRB)),
+		}
+		var model Model[byte, int]
+		for i := 0; i+1 < len(ops); i += 2 {
+			op, key := ops[i]%opCount, ops[i+1]
+			switch op {
+			case opInsert:
+				model.Insert(key, i)
+				for _, tree := range trees {
+					tree.Insert(key, i)
+				}
+			case opDelete:
+				want := model.Delete(key)
+				for _, tree := range trees {
+					if got := tree.Delete(key); got != want {
+						t.Fatalf("step %d: Delete(%d) = %v, model says %v", i/2, key, got, want)
+					}
+				}
+			case opGet:
+				want, wantOK := model.Get(key)
+				for _, tree := range trees {
+					if got, ok := tree.Get(key); got != want || ok != wantOK {
+						t.Fatalf("step %d: Get(%d) = %d, %v; model says %d, %v", i/2, key, got, ok, want, wantOK)
+					}
+				}
+			case opPopMin:
+				wantKey, wantOK := model.Min()
+				if wantOK {
+					model.Delete(wantKey)
+				}
+				for _, tree := range trees {
+					if got, _, ok := tree.PopMin(); ok != wantOK || got != wantKey {
+						t.Fatalf("step %d: PopMin() = %d, %v; model says %d, %v", i/2, got, ok, wantKey, wantOK)
+					}
+				}
+			}
+			for _, tree := range trees {
+				model.CheckAgainst(t, tree)
+			}
+		}
+	})
+}
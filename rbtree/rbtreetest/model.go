@@ -0,0 +1,83 @@
+package rbtreetest
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/EletricSaw/rbtree/rbtree"
+)
+
+// Model은 트리와 같은 연산을 맵과 정렬된 키 슬라이스로 흉내 내는 단순한 기준 구현이다.
+// 퍼즈나 무작위 테스트에서 트리와 Model에 같은 연산을 적용하고 CheckAgainst로 둘을 비교한다.
+// 제로값은 바로 쓸 수 있다.
+type Model[K cmp.Ordered, V comparable] struct {
+	values map[K]V
+	keys   []K // 정렬된 키
+}
+
+// Insert는 key에 value를 넣거나 덮어쓴다.
+func (m *Model[K, V]) Insert(key K, value V) {
+	if m.values == nil {
+		m.values = make(map[K]V)
+	}
+	if _, ok := m.values[key]; !ok {
+		i, _ := slices.BinarySearch(m.keys, key)
+		m.keys = slices.Insert(m.keys, i, key)
+	}
+	m.values[key] = value
+}
+
+// Delete는 key를 지우고, 있었으면 true를 돌려준다.
+func (m *Model[K, V]) Delete(key K) bool {
+	if _, ok := m.values[key]; !ok {
+		return false
+	}
+	delete(m.values, key)
+	i, _ := slices.BinarySearch(m.keys, key)
+	m.keys = slices.Delete(m.keys, i, i+1)
+	return true
+}
+
+// Get은 key의 값과 존재 여부를 돌려준다.
+func (m *Model[K, V]) Get(key K) (V, bool) {
+	v, ok := m.values[key]
+	return v, ok
+}
+
+// Min은 가장 작은 키를 돌려준다. 비었으면 ok가 false다.
+func (m *Model[K, V]) Min() (key K, ok bool) {
+	if len(m.keys) == 0 {
+		return key, false
+	}
+	return m.keys[0], true
+}
+
+// Len은 원소 수다.
+func (m *Model[K, V]) Len() int {
+	return len(m.keys)
+}
+
+// Keys는 정렬된 키를 돌려준다. 돌려준 슬라이스를 고치면 안 된다.
+func (m *Model[K, V]) Keys() []K {
+	return m.keys
+}
+
+// CheckAgainst는 tree가 규칙을 지키고, m과 같은 키를 같은 순서로, 같은 값과 함께 담고 있는지 확인한다.
+func (m *Model[K, V]) CheckAgainst(tb testing.TB, tree *rbtree.Tree[K, V]) {
+	tb.Helper()
+	AssertValid(tb, tree)
+	if tree.Size() != len(m.keys) {
+		tb.Fatalf("tree has %d elements, model has %d", tree.Size(), len(m.keys))
+	}
+	i := 0
+	for key, value := range tree.All() {
+		if key != m.keys[i] {
+			tb.Fatalf("key at position %d is %v, model has %v", i, key, m.keys[i])
+		}
+		if want := m.values[key]; value != want {
+			tb.Fatalf("key %v has value %v, model has %v", key, value, want)
+		}
+		i++
+	}
+}
@@ -0,0 +1,77 @@
+// Package rbtreetest는 rbtree.Tree를 감싸거나 품은 패키지가 자기 테스트에서 쓸 수 있는 단언 도우미를 제공한다.
+//
+// 모든 도우미는 testing.TB를 받으므로 테스트와 벤치마크, 퍼즈 대상 어디서든 부를 수 있고,
+// 실패하면 t.Fatalf로 그 자리에서 멈춘다.
+package rbtreetest
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/EletricSaw/rbtree/rbtree"
+)
+
+// AssertValid는 tree가 레드블랙 규칙과 내부 기록을 모두 지키는지 확인한다. 확인 내용은 rbtree.Tree.Validate와 같다.
+func AssertValid[K any, V any](tb testing.TB, tree *rbtree.Tree[K, V]) {
+	tb.Helper()
+	if err := tree.Validate(); err != nil {
+		tb.Fatal(err)
+	}
+}
+
+// BlackHeight는 루트에서 잎까지 지나는 검정 노드 수(루트 포함, nil 잎 제외)로, tree.BlackHeight와 같다.
+// 빈 트리는 0이다.
+func BlackHeight[K any, V any](tree *rbtree.Tree[K, V]) int {
+	return tree.BlackHeight()
+}
+
+// AssertBlackHeightBound는 n개 원소의 레드블랙 트리가 지켜야 하는 높이 상한 2·log2(n+1)을
+// 검정 높이로 확인한다. 검정 높이 h인 트리는 적어도 2^h - 1개의 원소를 담으므로 h ≤ log2(n+1)이다.
+func AssertBlackHeightBound[K any, V any](tb testing.TB, tree *rbtree.Tree[K, V]) {
+	tb.Helper()
+	h := tree.BlackHeight()
+	if minSize := 1<<h - 1; tree.Size() < minSize {
+		tb.Fatalf("black height %d needs at least %d elements, tree has %d", h, minSize, tree.Size())
+	}
+}
+
+// AssertSorted는 순회한 키가 엄격하게 증가하는지 확인한다.
+func AssertSorted[K cmp.Ordered, V any](tb testing.TB, tree *rbtree.Tree[K, V]) {
+	tb.Helper()
+	AssertSortedFunc(tb, tree, cmp.Compare[K])
+}
+
+// AssertSortedFunc는 AssertSorted와 같지만 키 순서를 compare로 판단한다. NewFunc로 만든 트리에 쓴다.
+func AssertSortedFunc[K any, V any](tb testing.TB, tree *rbtree.Tree[K, V], compare func(a, b K) int) {
+	tb.Helper()
+	var prev K
+	i := 0
+	for key := range tree.All() {
+		if i > 0 && compare(prev, key) >= 0 {
+			tb.Fatalf("key %v at position %d is not greater than the previous key %v", key, i, prev)
+		}
+		prev = key
+		i++
+	}
+	if i != tree.Size() {
+		tb.Fatalf("iteration visited %d keys, Size is %d", i, tree.Size())
+	}
+}
+
+// AssertContents는 tree가 want와 정확히 같은 키와 값을 담고 있는지 확인한다. 맵 같은 단순한
+// 모형과 나란히 연산을 적용해 가며 비교할 때 쓴다.
+func AssertContents[K comparable, V comparable](tb testing.TB, tree *rbtree.Tree[K, V], want map[K]V) {
+	tb.Helper()
+	if tree.Size() != len(want) {
+		tb.Fatalf("tree has %d elements, want %d", tree.Size(), len(want))
+	}
+	for key, value := range tree.All() {
+		w, ok := want[key]
+		if !ok {
+			tb.Fatalf("tree has unexpected key %v", key)
+		}
+		if value != w {
+			tb.Fatalf("key %v has value %v, want %v", key, value, w)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package rbtreetest
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/EletricSaw/rbtree/rbtree"
+)
+
+// recorder는 실패 메시지를 모으는 testing.TB다. Fatal 계열은 진짜 testing.T처럼 고루틴을 끝낸다.
+type recorder struct {
+	testing.TB
+	msg string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatal(args ...any) {
+	r.msg = fmt.Sprint(args...)
+	runtime.Goexit()
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.msg = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+// failure는 fn을 따로 고루틴에서 돌려 실패 메시지를 돌려준다. 통과하면 빈 문자열이다.
+func failure(fn func(tb testing.TB)) string {
+	r := &recorder{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(r)
+	}()
+	<-done
+	return r.msg
+}
+
+func sample() *rbtree.Tree[int, string] {
+	tree := rbtree.New[int, string]()
+	for i := 1; i <= 15; i++ {
+		tree.Insert(i, fmt.Sprint(i))
+	}
+	return tree
+}
+
+func TestPassingTree(t *testing.T) {
+	tree := sample()
+	want := map[int]string{}
+	for i := 1; i <= 15; i++ {
+		want[i] = fmt.Sprint(i)
+	}
+	AssertValid(t, tree)
+	AssertBlackHeightBound(t, tree)
+	AssertSorted(t, tree)
+	AssertContents(t, tree, want)
+	if h := BlackHeight(rbtree.New[int, int]()); h != 0 {
+		t.Fatalf("empty BlackHeight = %d", h)
+	}
+}
+
+func TestFailures(t *testing.T) {
+	broken := sample()
+	broken.Root().Color = rbtree.Red
+	if msg := failure(func(tb testing.TB) { AssertValid(tb, broken) }); !strings.Contains(msg, "red root") {
+		t.Fatalf("AssertValid message = %q", msg)
+	}
+
+	unsorted := sample()
+	unsorted.Min().Key = 99
+	if msg := failure(func(tb testing.TB) { AssertSorted(tb, unsorted) }); !strings.Contains(msg, "not greater") {
+		t.Fatalf("AssertSorted message = %q", msg)
+	}
+
+	want := map[int]string{1: "1"}
+	if msg := failure(func(tb testing.TB) { AssertContents(tb, sample(), want) }); !strings.Contains(msg, "has 15 elements, want 1") {
+		t.Fatalf("AssertContents message = %q", msg)
+	}
+	want = map[int]string{}
+	for i := 1; i <= 15; i++ {
+		want[i] = "x"
+	}
+	if msg := failure(func(tb testing.TB) { AssertContents(tb, sample(), want) }); !strings.Contains(msg, "want x") {
+		t.Fatalf("AssertContents message = %q", msg)
+	}
+}
@@ -0,0 +1,116 @@
+package rbtree
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Frame은 재균형 도중 한 단계가 끝난 직후의 트리 모습이다. Step은 그 단계를 사람이 읽을 수 있게
+// 적은 것("insert 5", "recolor 3 black", "rotate left at 7" 등)이고, Tree는 그 순간을 복제한
+// 트리라서 이후 연산의 영향을 받지 않는다.
+type Frame[K any, V any] struct {
+	Step string
+	Tree *Tree[K, V]
+}
+
+// WriteDOT은 프레임의 트리를 Graphviz DOT으로 쓰고, Step을 그래프 제목으로 붙인다.
+func (f Frame[K, V]) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	f.Tree.writeDOT(bw, f.Step)
+	return bw.Flush()
+}
+
+// Recorder는 Record로 붙인 트리의 삽입·삭제를 단계별 Frame으로 모은다. 한 연산의 재균형을
+// 처음부터 끝까지 한 단계씩 다시 보여 줄 때 쓴다. 제로 값을 바로 쓸 수 있다.
+//
+// 프레임마다 트리 전체를 복제하므로 작은 트리를 설명하거나 디버깅하는 용도로만 쓴다.
+type Recorder[K any, V any] struct {
+	frames []Frame[K, V]
+}
+
+// Frames는 지금까지 모은 프레임을 기록된 순서대로 돌려준다.
+func (r *Recorder[K, V]) Frames() []Frame[K, V] {
+	return r.frames
+}
+
+// Reset은 모은 프레임을 비운다. 다음 연산만 따로 보고 싶을 때 연산 직전에 부른다.
+func (r *Recorder[K, V]) Reset() {
+	r.frames = nil
+}
+
+// Record는 r을 트리에 붙인다. 이후 Insert·Delete 같은 변경 연산은 노드를 붙이거나 떼어 낸 직후,
+// 그리고 회전과 색 변경이 일어날 때마다 r에 프레임을 하나씩 남긴다. r이 nil이면 기록을 멈춘다.
+// Clone, Snapshot, Split으로 만든 트리에는 기록기가 따라가지 않는다.
+func (t *Tree[K, V]) Record(r *Recorder[K, V]) {
+	t.recorder = r
+}
+
+// record는 기록기가 붙어 있을 때만 현재 트리를 복제해 프레임으로 남긴다. format에는 key가 들어갈
+// %v 하나만 둔다. 가변 인자로 받으면 기록기가 없어도 호출마다 키를 interface로 감싸느라 할당이
+// 생기므로, 키는 K 그대로 받아 기록할 때만 감싼다.
+func (t *Tree[K, V]) record(format string, key K) {
+	if t.recorder == nil {
+		return
+	}
+	frame := &Tree[K, V]{compare: t.plainCompare(), tombstones: t.tombstones}
+	frame.root = cloneNode(t.root, nil, nil)
+	frame.size = countOf(frame.root)
+	frame.dead = countDead(frame.root)
+	t.recorder.frames = append(t.recorder.frames, Frame[K, V]{Step: fmt.Sprintf(format, key), Tree: frame})
+}
+
+// setColor는 재균형 중의 색 변경을 한곳에서 처리해 실제로 색이 바뀔 때만 세고 기록한다.
+func (t *Tree[K, V]) setColor(node *Node[K, V], color Color) {
+	if node.Color == color {
+		return
+	}
+	node.Color = color
+	t.metrics.Recolors++
+	if color == red {
+		t.record("recolor %v red", node.Key)
+	} else {
+		t.record("recolor %v black", node.Key)
+	}
+}
+
+// WriteDOT은 트리를 Graphviz DOT으로 쓴다. `dot -Tpng`로 바로 그림을 얻을 수 있다.
+// 톰스톤 노드는 회색 점선으로 그린다.
+func (t *Tree[K, V]) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	t.writeDOT(bw, "")
+	return bw.Flush()
+}
+
+func (t *Tree[K, V]) writeDOT(w *bufio.Writer, title string) {
+	w.WriteString("digraph rbtree {\n")
+	if title != "" {
+		fmt.Fprintf(w, "\tlabel=%s;\n\tlabelloc=t;\n", strconv.Quote(title))
+	}
+	w.WriteString("\tnode [shape=circle, style=filled, fontcolor=white];\n")
+	id := 0
+	var walk func(node *Node[K, V]) int
+	walk = func(node *Node[K, V]) int {
+		self := id
+		id++
+		fill, style := "black", "filled"
+		switch {
+		case node.deleted:
+			fill, style = "gray", "filled,dashed"
+		case node.Color == red:
+			fill = "red"
+		}
+		fmt.Fprintf(w, "\tn%d [label=%s, fillcolor=%s, style=%q];\n", self, strconv.Quote(fmt.Sprint(node.Key)), fill, style)
+		for _, child := range []*Node[K, V]{node.Left, node.Right} {
+			if child != nil {
+				fmt.Fprintf(w, "\tn%d -> n%d;\n", self, walk(child))
+			}
+		}
+		return self
+	}
+	if t.root != nil {
+		walk(t.root)
+	}
+	w.WriteString("}\n")
+}
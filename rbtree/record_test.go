@@ -0,0 +1,98 @@
+package rbtree
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func frameSteps[K any, V any](r *Recorder[K, V]) []string {
+	var steps []string
+	for _, f := range r.Frames() {
+		steps = append(steps, f.Step)
+	}
+	return steps
+}
+
+func TestRecorderInsert(t *testing.T) {
+	tree := New[int, int]()
+	tree.Insert(10, 10)
+	tree.Insert(20, 20)
+
+	var r Recorder[int, int]
+	tree.Record(&r)
+	tree.Insert(30, 30)
+
+	want := []string{"insert 30", "recolor 20 black", "recolor 10 red", "rotate left at 10"}
+	if got := frameSteps(&r); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("steps = %q, want %q", got, want)
+	}
+	// 첫 프레임은 재균형 전의 일직선 모양이어야 한다.
+	first := r.Frames()[0].Tree
+	if first.root.Key != 10 || first.root.Right.Right == nil || first.root.Right.Right.Color != red {
+		t.Fatalf("first frame should show the unbalanced chain")
+	}
+	last := r.Frames()[len(r.Frames())-1].Tree
+	assertRBProperties(t, last)
+	if !tree.Equal(last, func(a, b int) bool { return a == b }) {
+		t.Fatalf("last frame should match the final tree")
+	}
+}
+
+func TestRecorderDelete(t *testing.T) {
+	tree := New[int, int]()
+	for i := 1; i <= 10; i++ {
+		tree.Insert(i, i)
+	}
+	var r Recorder[int, int]
+	tree.Record(&r)
+	tree.Delete(1)
+
+	frames := r.Frames()
+	if len(frames) < 2 || frames[0].Step != "delete 1" {
+		t.Fatalf("steps = %q", frameSteps(&r))
+	}
+	if frames[0].Tree.Contains(1) {
+		t.Fatalf("delete frame should not contain the removed key")
+	}
+	assertRBProperties(t, frames[len(frames)-1].Tree)
+
+	r.Reset()
+	tree.Record(nil)
+	tree.Insert(11, 11)
+	if len(r.Frames()) != 0 {
+		t.Fatalf("detached recorder should not collect frames")
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	tree := New[int, int]()
+	for _, k := range []int{2, 1, 3} {
+		tree.Insert(k, k)
+	}
+	var buf bytes.Buffer
+	if err := tree.WriteDOT(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `digraph rbtree {
+	node [shape=circle, style=filled, fontcolor=white];
+	n0 [label="2", fillcolor=black, style="filled"];
+	n1 [label="1", fillcolor=red, style="filled"];
+	n0 -> n1;
+	n2 [label="3", fillcolor=red, style="filled"];
+	n0 -> n2;
+}
+`
+	if buf.String() != want {
+		t.Fatalf("WriteDOT =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	frame := Frame[int, int]{Step: "insert 3", Tree: tree}
+	if err := frame.WriteDOT(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "label=\"insert 3\";") {
+		t.Fatalf("frame DOT should carry the step as title:\n%s", buf.String())
+	}
+}
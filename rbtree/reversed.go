@@ -0,0 +1,95 @@
+package rbtree
+
+import "iter"
+
+// ReversedView는 트리를 키 내림차순으로 정렬된 것처럼 보여 주는 뷰다. 데이터를 복사하지 않고 부모
+// 트리를 가리키기만 하므로 부모의 변경이 바로 보인다. 점수 오름차순 트리 하나로 리더보드의 1등부터와
+// 꼴찌부터를 함께 읽을 때처럼, 같은 트리를 양쪽 순서로 넘겨야 할 때 쓴다.
+//
+// 순서에 기대는 메서드는 모두 뒤집힌다. Min은 가장 큰 키, All은 내림차순이고, Floor(key)는 뒤집힌
+// 순서에서 key 이하, 즉 원래 순서로 key 이상인 가장 작은 키를 돌려준다.
+type ReversedView[K any, V any] struct {
+	t *Tree[K, V]
+}
+
+// Reversed는 t를 거꾸로 보는 뷰를 돌려준다.
+func (t *Tree[K, V]) Reversed() *ReversedView[K, V] {
+	return &ReversedView[K, V]{t: t}
+}
+
+// Tree는 뷰가 가리키는 원래 트리를 돌려준다.
+func (r *ReversedView[K, V]) Tree() *Tree[K, V] {
+	return r.t
+}
+
+// Size는 원소 수를 돌려준다.
+func (r *ReversedView[K, V]) Size() int {
+	return r.t.Size()
+}
+
+// Get은 키에 대응하는 값을 돌려준다.
+func (r *ReversedView[K, V]) Get(key K) (V, bool) {
+	return r.t.Get(key)
+}
+
+// Contains는 키가 있는지 알려 준다.
+func (r *ReversedView[K, V]) Contains(key K) bool {
+	return r.t.Contains(key)
+}
+
+// Min은 뒤집힌 순서의 첫 노드, 즉 가장 큰 키의 노드를 돌려준다. 비었으면 nil이다.
+func (r *ReversedView[K, V]) Min() *Node[K, V] {
+	return r.t.last()
+}
+
+// Max는 뒤집힌 순서의 마지막 노드, 즉 가장 작은 키의 노드를 돌려준다. 비었으면 nil이다.
+func (r *ReversedView[K, V]) Max() *Node[K, V] {
+	return r.t.first()
+}
+
+// Floor는 뒤집힌 순서에서 key 이하인 마지막 노드, 즉 key 이상인 가장 작은 키의 노드를 돌려준다.
+func (r *ReversedView[K, V]) Floor(key K) *Node[K, V] {
+	return r.t.ceiling(key)
+}
+
+// Ceiling은 뒤집힌 순서에서 key 이상인 첫 노드, 즉 key 이하인 가장 큰 키의 노드를 돌려준다.
+func (r *ReversedView[K, V]) Ceiling(key K) *Node[K, V] {
+	return r.t.floor(key)
+}
+
+// Rank는 뒤집힌 순서에서 key 앞에 오는 원소 수, 즉 key보다 큰 키의 개수를 돌려준다.
+func (r *ReversedView[K, V]) Rank(key K) int {
+	n := r.t.size - r.t.Rank(key)
+	if r.t.Contains(key) {
+		n--
+	}
+	return n
+}
+
+// Select는 뒤집힌 순서에서 0부터 센 i번째 원소, 즉 i번째로 큰 원소를 돌려준다.
+// i가 범위를 벗어나면 ok가 false다.
+func (r *ReversedView[K, V]) Select(i int) (key K, value V, ok bool) {
+	if i < 0 || i >= r.t.size {
+		return key, value, false
+	}
+	return r.t.Select(r.t.size - 1 - i)
+}
+
+// All은 모든 원소를 키 내림차순으로 내놓는다.
+func (r *ReversedView[K, V]) All() iter.Seq2[K, V] {
+	return r.t.Backward()
+}
+
+// Backward는 모든 원소를 키 오름차순으로 내놓는다.
+func (r *ReversedView[K, V]) Backward() iter.Seq2[K, V] {
+	return r.t.All()
+}
+
+// Keys는 모든 키를 내림차순으로 담은 새 슬라이스를 돌려준다.
+func (r *ReversedView[K, V]) Keys() []K {
+	keys := make([]K, 0, r.t.size)
+	for k := range r.t.Backward() {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,54 @@
+package rbtree
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReversed(t *testing.T) {
+	tree := New[int, string]()
+	for _, k := range []int{10, 20, 30, 40} {
+		tree.Insert(k, "v")
+	}
+	rev := tree.Reversed()
+
+	if got := rev.Keys(); !slices.Equal(got, []int{40, 30, 20, 10}) {
+		t.Fatalf("Keys = %v", got)
+	}
+	if rev.Min().Key != 40 || rev.Max().Key != 10 {
+		t.Fatalf("Min/Max = %d/%d, want 40/10", rev.Min().Key, rev.Max().Key)
+	}
+	if n := rev.Floor(25); n == nil || n.Key != 30 {
+		t.Fatalf("Floor(25) should be 30 in reversed order, got %v", n)
+	}
+	if n := rev.Ceiling(25); n == nil || n.Key != 20 {
+		t.Fatalf("Ceiling(25) should be 20 in reversed order, got %v", n)
+	}
+	if rev.Floor(45) != nil || rev.Ceiling(5) != nil {
+		t.Fatalf("Floor/Ceiling past the ends should be nil")
+	}
+	for key, want := range map[int]int{40: 0, 30: 1, 25: 2, 10: 3, 5: 4, 50: 0} {
+		if got := rev.Rank(key); got != want {
+			t.Fatalf("Rank(%d) = %d, want %d", key, got, want)
+		}
+	}
+	if k, _, ok := rev.Select(0); !ok || k != 40 {
+		t.Fatalf("Select(0) = %d, %v", k, ok)
+	}
+	if _, _, ok := rev.Select(4); ok {
+		t.Fatalf("Select(4) should be out of range")
+	}
+	var forward []int
+	for k := range rev.Backward() {
+		forward = append(forward, k)
+	}
+	if !slices.Equal(forward, []int{10, 20, 30, 40}) {
+		t.Fatalf("Backward = %v", forward)
+	}
+
+	// 뷰는 부모의 변경을 바로 본다.
+	tree.Insert(50, "v")
+	if rev.Min().Key != 50 || rev.Size() != 5 {
+		t.Fatalf("view should reflect inserts into the parent")
+	}
+}
@@ -0,0 +1,79 @@
+package rbtree
+
+import "math/rand"
+
+// Sample은 원소 하나를 고르게 무작위로 골라 돌려준다. 0부터 Size()-1 사이의 순위를 하나 뽑아 서브트리
+// 크기를 따라 내려가므로 O(log n)이고 원소를 모으지 않는다. 캐시에 실제로 들어 있는 항목을 무작위로
+// 찔러 보거나 부하 테스트 입력을 고를 때 쓴다. rng가 nil이면 math/rand의 전역 소스를 쓴다.
+// 비었으면 ok가 false다.
+func (t *Tree[K, V]) Sample(rng *rand.Rand) (key K, value V, ok bool) {
+	if t.size == 0 {
+		return key, value, false
+	}
+	return entryOf(t.selectNode(intn(rng, t.size)))
+}
+
+// SampleWeighted는 weight(key, value)에 비례하는 확률로 원소 하나를 고른다. 가중치가 0 이하인 원소는
+// 뽑히지 않는다. 가중치를 미리 모아 두지 않고 모든 원소를 한 번 훑으며 지금까지 본 것 가운데 하나를
+// 들고 가므로 O(n)이다. 같은 트리에서 자주 뽑는다면 가중치 합을 집계로 붙이고 SampleAugmented를 쓴다.
+// 가중치가 양수인 원소가 없으면 ok가 false다.
+func (t *Tree[K, V]) SampleWeighted(rng *rand.Rand, weight func(key K, value V) float64) (key K, value V, ok bool) {
+	total := 0.0
+	for node := t.first(); node != nil; node = nextLive(node) {
+		w := weight(node.Key, node.Value)
+		if w <= 0 {
+			continue
+		}
+		total += w
+		// 지금까지의 합 가운데 w만큼의 확률로 바꾸면 마지막에 각 원소가 뽑힐 확률은 w/합이다.
+		if float64n(rng)*total < w {
+			key, value, ok = node.Key, node.Value, true
+		}
+	}
+	return key, value, ok
+}
+
+// SampleAugmented는 a의 집계를 가중치 합으로 보고 가중치에 비례하는 확률로 원소 하나를 고른다.
+// a는 FromNode가 원소의 가중치를, Combine이 합을 돌려주는 집계여야 한다. 서브트리마다 가중치 합이
+// 있으므로 SampleWeighted와 달리 O(log n)이다. 가중치가 양수인 원소가 없으면 ok가 false다.
+func SampleAugmented[K any, V any](a *Augmentation[K, V, float64], rng *rand.Rand) (key K, value V, ok bool) {
+	total, _ := a.Total()
+	if total <= 0 {
+		return key, value, false
+	}
+	r := float64n(rng) * total
+	var last *Node[K, V]
+	for n := a.t.root; n != nil; {
+		if lw, _ := a.Subtree(n.Left); r < lw {
+			n = n.Left
+			continue
+		} else {
+			r -= lw
+		}
+		if w, _ := a.self(n); w > 0 {
+			if r < w {
+				return n.Key, n.Value, true
+			}
+			r -= w
+			last = n
+		}
+		n = n.Right
+	}
+	// 부동소수점 오차로 오른쪽 끝을 지나쳤으면 마지막으로 지나온 원소를 고른다.
+	return entryOf(last)
+}
+
+// intn과 float64n은 rng가 nil이면 math/rand의 전역 소스를 쓴다.
+func intn(rng *rand.Rand, n int) int {
+	if rng == nil {
+		return rand.Intn(n)
+	}
+	return rng.Intn(n)
+}
+
+func float64n(rng *rand.Rand) float64 {
+	if rng == nil {
+		return rand.Float64()
+	}
+	return rng.Float64()
+}
@@ -0,0 +1,90 @@
+package rbtree
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSample(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewWithTombstones[int, int]()
+	if _, _, ok := tree.Sample(rng); ok {
+		t.Fatalf("Sample on an empty tree should report no element")
+	}
+	for i := 0; i < 20; i++ {
+		tree.Insert(i, i)
+	}
+	for i := 0; i < 20; i += 2 {
+		tree.Delete(i) // 톰스톤은 뽑히면 안 된다.
+	}
+
+	const draws = 20000
+	counts := make(map[int]int)
+	for i := 0; i < draws; i++ {
+		k, v, ok := tree.Sample(rng)
+		if !ok || k != v || k%2 == 0 {
+			t.Fatalf("Sample returned %d=%d, %v", k, v, ok)
+		}
+		counts[k]++
+	}
+	want := draws / 10
+	for k, n := range counts {
+		if math.Abs(float64(n-want)) > float64(want)/5 {
+			t.Fatalf("key %d drawn %d times, want about %d", k, n, want)
+		}
+	}
+	if len(counts) != 10 {
+		t.Fatalf("drew %d distinct keys, want 10", len(counts))
+	}
+}
+
+// weightAug는 값을 가중치로 보고 합을 유지한다.
+type weightAug struct{}
+
+func (weightAug) FromNode(_ int, v int) float64 { return float64(v) }
+func (weightAug) Combine(a, b float64) float64  { return a + b }
+
+func TestSampleWeighted(t *testing.T) {
+	tree := New[int, int]()
+	// 키 k의 가중치는 k다. 0은 뽑히지 않는다.
+	for k := 0; k <= 4; k++ {
+		tree.Insert(k, k)
+	}
+	weights := AugmentWith[int, int, float64](tree, weightAug{})
+
+	for name, sample := range map[string]func(rng *rand.Rand) (int, int, bool){
+		"SampleWeighted": func(rng *rand.Rand) (int, int, bool) {
+			return tree.SampleWeighted(rng, func(_, v int) float64 { return float64(v) })
+		},
+		"SampleAugmented": func(rng *rand.Rand) (int, int, bool) { return SampleAugmented(weights, rng) },
+	} {
+		rng := rand.New(rand.NewSource(2))
+		const draws = 20000
+		counts := make([]int, 5)
+		for i := 0; i < draws; i++ {
+			k, _, ok := sample(rng)
+			if !ok {
+				t.Fatalf("%s found nothing", name)
+			}
+			counts[k]++
+		}
+		if counts[0] != 0 {
+			t.Fatalf("%s drew a zero-weight key", name)
+		}
+		for k := 1; k <= 4; k++ {
+			want := draws * k / 10
+			if math.Abs(float64(counts[k]-want)) > float64(want)/5 {
+				t.Fatalf("%s drew key %d %d times, want about %d", name, k, counts[k], want)
+			}
+		}
+	}
+
+	empty := New[int, int]()
+	if _, _, ok := empty.SampleWeighted(nil, func(_, v int) float64 { return 1 }); ok {
+		t.Fatalf("SampleWeighted on an empty tree should report no element")
+	}
+	if _, _, ok := SampleAugmented(AugmentWith[int, int, float64](empty, weightAug{}), nil); ok {
+		t.Fatalf("SampleAugmented on an empty tree should report no element")
+	}
+}
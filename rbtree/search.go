@@ -0,0 +1,62 @@
+package rbtree
+
+// Floor는 key 이하인 키 가운데 가장 큰 키의 노드를 돌려준다. 그런 키가 없으면 nil이다.
+// 구간 버킷의 시작점을 찾는 것처럼 "key가 속한 구간"을 조회할 때 쓴다.
+func (t *Tree[K, V]) Floor(key K) *Node[K, V] {
+	return t.floor(key)
+}
+
+// Ceiling은 key 이상인 키 가운데 가장 작은 키의 노드를 돌려준다. 그런 키가 없으면 nil이다.
+func (t *Tree[K, V]) Ceiling(key K) *Node[K, V] {
+	return t.ceiling(key)
+}
+
+// Nearest는 key에 가장 가까운 원소를 돌려준다. Floor와 Ceiling이 찾은 두 후보 가운데 dist(key, 후보)가
+// 작은 쪽을 고르고, 같으면 작은 키를 고른다. 타임스탬프를 가장 가까운 기록 시각에 맞출 때처럼 키
+// 사이의 거리를 잴 수 있을 때 쓴다. dist는 음수가 아닌 값을 돌려줘야 한다. 비었으면 ok가 false다.
+func (t *Tree[K, V]) Nearest(key K, dist func(a, b K) int64) (K, V, bool) {
+	below, above := t.floor(key), t.ceiling(key)
+	if below != nil && above != nil && dist(key, above.Key) < dist(key, below.Key) {
+		below = nil
+	}
+	if below != nil {
+		return below.Key, below.Value, true
+	}
+	return entryOf(above)
+}
+
+// SearchN은 key와 가까운 노드를 최대 n개 돌려준다. 거리는 값의 차이가 아니라 정렬 순서상
+// 몇 칸 떨어져 있는지로 잰다(문자열처럼 뺄셈이 없는 키에도 쓸 수 있도록). 결과는 거리가
+// 가까운 순서이며, key와 같은 노드가 있으면 맨 앞에 오고, 거리가 같으면 작은 키가 먼저 온다.
+// 즉 key 아래쪽과 위쪽 이웃을 번갈아 가며 모은다. n이 0 이하이면 빈 슬라이스를 돌려준다.
+func (t *Tree[K, V]) SearchN(key K, n int) []*Node[K, V] {
+	if n <= 0 || t.size == 0 {
+		return []*Node[K, V]{}
+	}
+	out := make([]*Node[K, V], 0, min(n, t.size))
+
+	// above는 key 이상인 첫 노드에서 출발한다. 그것이 key 자신이면 거리 0으로 먼저 담는다.
+	above := t.ceiling(key)
+	var below *Node[K, V]
+	if above != nil {
+		below = prevLive(above)
+		if t.compare(above.Key, key) == 0 {
+			out = append(out, above)
+			above = nextLive(above)
+		}
+	} else {
+		below = t.floor(key)
+	}
+
+	for len(out) < n && (below != nil || above != nil) {
+		if below != nil {
+			out = append(out, below)
+			below = prevLive(below)
+		}
+		if len(out) < n && above != nil {
+			out = append(out, above)
+			above = nextLive(above)
+		}
+	}
+	return out
+}
@@ -0,0 +1,134 @@
+package rbtree
+
+import (
+	"slices"
+	"testing"
+)
+
+func searchNKeys(nodes []*Node[int, int]) []int {
+	keys := make([]int, len(nodes))
+	for i, node := range nodes {
+		keys[i] = node.Key
+	}
+	return keys
+}
+
+func TestSearchN(t *testing.T) {
+	tree := New[int, int]()
+	for _, k := range []int{10, 20, 30, 40, 50} {
+		tree.Insert(k, k)
+	}
+
+	if got := tree.SearchN(30, 1); len(got) != 1 || got[0] != tree.Search(30) {
+		t.Fatalf("SearchN(30, 1) should equal Search(30), got %v", searchNKeys(got))
+	}
+	if got := tree.SearchN(30, 0); len(got) != 0 {
+		t.Fatalf("SearchN with n=0 should be empty, got %v", searchNKeys(got))
+	}
+	if got := searchNKeys(tree.SearchN(30, 100)); !slices.Equal(got, []int{30, 20, 40, 10, 50}) {
+		t.Fatalf("SearchN(30, 100) expected all nodes by distance, got %v", got)
+	}
+
+	cases := []struct {
+		key  int
+		n    int
+		want []int
+	}{
+		{25, 2, []int{20, 30}}, // 키가 없으면 아래/위 이웃이 같은 거리이고 작은 쪽이 먼저다.
+		{25, 3, []int{20, 30, 10}},
+		{5, 3, []int{10, 20, 30}}, // 아래쪽이 없으면 위쪽만 따라간다.
+		{99, 2, []int{50, 40}},    // 위쪽이 없으면 아래쪽만 따라간다.
+		{50, 4, []int{50, 40, 30, 20}},
+	}
+	for _, c := range cases {
+		if got := searchNKeys(tree.SearchN(c.key, c.n)); !slices.Equal(got, c.want) {
+			t.Fatalf("SearchN(%d, %d) expected %v, got %v", c.key, c.n, c.want, got)
+		}
+	}
+
+	if got := New[int, int]().SearchN(1, 3); len(got) != 0 {
+		t.Fatalf("SearchN on empty tree should be empty")
+	}
+}
+
+func TestSearchNSkipsTombstones(t *testing.T) {
+	tree := NewWithTombstones[int, int]()
+	for _, k := range []int{10, 20, 30, 40, 50} {
+		tree.Insert(k, k)
+	}
+	tree.Delete(30)
+	tree.Delete(20)
+	if got := searchNKeys(tree.SearchN(30, 3)); !slices.Equal(got, []int{10, 40, 50}) {
+		t.Fatalf("expected tombstones to be skipped, got %v", got)
+	}
+}
+
+func TestFloorCeiling(t *testing.T) {
+	tree := New[int, string]()
+	for _, k := range []int{10, 20, 30, 40} {
+		tree.Insert(k, "bucket")
+	}
+
+	cases := []struct {
+		key            int
+		floor, ceiling int // -1은 결과 없음을 뜻한다.
+	}{
+		{5, -1, 10},
+		{10, 10, 10},
+		{15, 10, 20},
+		{40, 40, 40},
+		{45, 40, -1},
+	}
+	keyOf := func(node *Node[int, string]) int {
+		if node == nil {
+			return -1
+		}
+		return node.Key
+	}
+	for _, c := range cases {
+		if got := keyOf(tree.Floor(c.key)); got != c.floor {
+			t.Fatalf("Floor(%d) expected %d, got %d", c.key, c.floor, got)
+		}
+		if got := keyOf(tree.Ceiling(c.key)); got != c.ceiling {
+			t.Fatalf("Ceiling(%d) expected %d, got %d", c.key, c.ceiling, got)
+		}
+	}
+
+	empty := New[int, string]()
+	if empty.Floor(1) != nil || empty.Ceiling(1) != nil {
+		t.Fatalf("Floor/Ceiling on empty tree should be nil")
+	}
+
+	tomb := NewWithTombstones[int, int]()
+	for _, k := range []int{10, 20, 30} {
+		tomb.Insert(k, k)
+	}
+	tomb.Delete(20)
+	if node := tomb.Floor(25); node == nil || node.Key != 10 {
+		t.Fatalf("Floor should skip tombstoned keys")
+	}
+	if node := tomb.Ceiling(20); node == nil || node.Key != 30 {
+		t.Fatalf("Ceiling should skip tombstoned keys")
+	}
+}
+
+func TestNearest(t *testing.T) {
+	dist := func(a, b int64) int64 {
+		if a > b {
+			return a - b
+		}
+		return b - a
+	}
+	tree := New[int64, string]()
+	if _, _, ok := tree.Nearest(5, dist); ok {
+		t.Fatalf("Nearest on empty tree should report no key")
+	}
+	for _, k := range []int64{100, 200, 400} {
+		tree.Insert(k, "v")
+	}
+	for key, want := range map[int64]int64{0: 100, 100: 100, 149: 100, 150: 100, 151: 200, 299: 200, 300: 200, 301: 400, 1000: 400} {
+		if got, _, ok := tree.Nearest(key, dist); !ok || got != want {
+			t.Fatalf("Nearest(%d) = %d, want %d", key, got, want)
+		}
+	}
+}
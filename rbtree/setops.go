@@ -0,0 +1,88 @@
+package rbtree
+
+// 집합 연산은 두 트리를 중위 순서로 나란히 걸으며 결과 원소를 정렬된 슬라이스로 모은 뒤
+// buildSorted로 한 번에 균형 트리를 만든다. 원소마다 Insert하는 것과 달리 회전/보정이 없고
+// 결과 노드 수만큼만 할당하므로 O(n + m)이다. 두 트리는 같은 순서(비교 함수)를 써야 하며,
+// 결과는 t의 비교 함수와 설정을 물려받는다. 입력 트리는 바뀌지 않는다.
+
+// Union은 두 트리의 모든 키를 담은 새 트리를 돌려준다. 양쪽에 모두 있는 키의 값은
+// resolve(t의 값, other의 값)으로 정하며, resolve가 nil이면 other의 값을 쓴다.
+func (t *Tree[K, V]) Union(other *Tree[K, V], resolve func(a, b V) V) *Tree[K, V] {
+	out := make([]Pair[K, V], 0, t.size+other.size)
+	a, b := t.first(), other.first()
+	for a != nil || b != nil {
+		var c int
+		switch {
+		case a == nil:
+			c = 1
+		case b == nil:
+			c = -1
+		default:
+			c = t.compare(a.Key, b.Key)
+		}
+		switch {
+		case c < 0:
+			out = append(out, Pair[K, V]{Key: a.Key, Value: a.Value})
+			a = nextLive(a)
+		case c > 0:
+			out = append(out, Pair[K, V]{Key: b.Key, Value: b.Value})
+			b = nextLive(b)
+		default:
+			value := b.Value
+			if resolve != nil {
+				value = resolve(a.Value, b.Value)
+			}
+			out = append(out, Pair[K, V]{Key: a.Key, Value: value})
+			a, b = nextLive(a), nextLive(b)
+		}
+	}
+	return t.fromSortedPairs(out)
+}
+
+// Intersect는 두 트리에 모두 있는 키만 t의 값과 함께 담은 새 트리를 돌려준다.
+func (t *Tree[K, V]) Intersect(other *Tree[K, V]) *Tree[K, V] {
+	out := make([]Pair[K, V], 0, min(t.size, other.size))
+	a, b := t.first(), other.first()
+	for a != nil && b != nil {
+		switch c := t.compare(a.Key, b.Key); {
+		case c < 0:
+			a = nextLive(a)
+		case c > 0:
+			b = nextLive(b)
+		default:
+			out = append(out, Pair[K, V]{Key: a.Key, Value: a.Value})
+			a, b = nextLive(a), nextLive(b)
+		}
+	}
+	return t.fromSortedPairs(out)
+}
+
+// Difference는 t에는 있고 other에는 없는 키만 담은 새 트리를 돌려준다.
+func (t *Tree[K, V]) Difference(other *Tree[K, V]) *Tree[K, V] {
+	out := make([]Pair[K, V], 0, t.size)
+	a, b := t.first(), other.first()
+	for a != nil {
+		c := -1
+		if b != nil {
+			c = t.compare(a.Key, b.Key)
+		}
+		switch {
+		case c < 0:
+			out = append(out, Pair[K, V]{Key: a.Key, Value: a.Value})
+			a = nextLive(a)
+		case c > 0:
+			b = nextLive(b)
+		default:
+			a, b = nextLive(a), nextLive(b)
+		}
+	}
+	return t.fromSortedPairs(out)
+}
+
+// fromSortedPairs는 정렬된 pairs로 t와 같은 설정의 새 트리를 만든다.
+func (t *Tree[K, V]) fromSortedPairs(pairs []Pair[K, V]) *Tree[K, V] {
+	out := t.newLike()
+	out.setRoot(buildSorted(pairs))
+	out.evictOverflow()
+	return out
+}
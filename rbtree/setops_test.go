@@ -0,0 +1,83 @@
+package rbtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBuildSortedIsValid(t *testing.T) {
+	for n := 0; n < 300; n++ {
+		pairs := make([]Pair[int, int], n)
+		for i := range pairs {
+			pairs[i] = Pair[int, int]{Key: i, Value: i}
+		}
+		tree := New[int, int]().fromSortedPairs(pairs)
+		assertRBProperties(t, tree)
+		if tree.Size() != n {
+			t.Fatalf("expected size %d, got %d", n, tree.Size())
+		}
+		tree.Insert(n, n)
+		tree.Delete(0)
+		assertRBProperties(t, tree)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	a, b := New[int, string](), New[int, string]()
+	inA, inB := map[int]bool{}, map[int]bool{}
+	for i := 0; i < 500; i++ {
+		if k := rand.Intn(400); !inA[k] {
+			inA[k] = true
+			a.Insert(k, "a")
+		}
+		if k := rand.Intn(400); !inB[k] {
+			inB[k] = true
+			b.Insert(k, "b")
+		}
+	}
+
+	union := a.Union(b, func(x, y string) string { return x + y })
+	intersect := a.Intersect(b)
+	diff := a.Difference(b)
+	for _, tree := range []*Tree[int, string]{union, intersect, diff} {
+		assertRBProperties(t, tree)
+	}
+
+	for k := 0; k < 400; k++ {
+		checkMember := func(name string, tree *Tree[int, string], want bool, value string) {
+			node := tree.Search(k)
+			if (node != nil) != want {
+				t.Fatalf("%s: key %d membership expected %v", name, k, want)
+			}
+			if node != nil && node.Value != value {
+				t.Fatalf("%s: key %d expected value %q, got %q", name, k, value, node.Value)
+			}
+		}
+		unionValue := "a"
+		switch {
+		case inA[k] && inB[k]:
+			unionValue = "ab"
+		case inB[k]:
+			unionValue = "b"
+		}
+		checkMember("union", union, inA[k] || inB[k], unionValue)
+		checkMember("intersect", intersect, inA[k] && inB[k], "a")
+		checkMember("difference", diff, inA[k] && !inB[k], "a")
+	}
+
+	if got := a.Union(b, nil).Search(firstCommonKey(inA, inB)); got == nil || got.Value != "b" {
+		t.Fatalf("Union with nil resolve should keep the other tree's value")
+	}
+	if a.Size() != len(inA) || b.Size() != len(inB) {
+		t.Fatalf("set operations must not modify their inputs")
+	}
+}
+
+func firstCommonKey(a, b map[int]bool) int {
+	for k := range a {
+		if b[k] {
+			return k
+		}
+	}
+	return -1
+}
@@ -0,0 +1,45 @@
+package rbtree
+
+// Snapshot은 현재 내용을 담은 읽기 전용 트리를 O(1)에 돌려준다. 스냅숏은 원본과 노드를 공유하며,
+// 원본은 Snapshot 뒤 첫 쓰기에서 자기 노드를 새로 복사한 다음 그 복사본을 고친다. 그래서 스냅숏의
+// 노드는 다시 바뀌지 않고, 스냅숏을 읽는 고루틴은 잠금 없이 순회해도 원본의 쓰기와 겹치지 않는다.
+// (원본에서 스냅숏을 떠 다른 고루틴에 넘기는 일만 쓰기와 같은 잠금 아래에서 하면 된다.)
+//
+// 노드가 부모 포인터를 가지므로 루트에서 잎까지의 경로만 복사하는 방식은 쓸 수 없다. 경로 위 노드를
+// 새로 만들면 그 자식들의 Parent도 바꿔야 해서 결국 트리 전체를 복사하게 된다. 대신 스냅숏마다
+// 첫 쓰기가 O(n) 복사를 한 번 치르고, 그다음 쓰기부터는 평소 비용으로 돌아간다. 스냅숏을 뜨는
+// 주기가 쓰기 횟수보다 훨씬 드문 읽기 위주 색인에 알맞다. 버전마다 O(log n)만 더 드는 트리가
+// 필요하면 persistent 패키지를 쓴다.
+//
+// 첫 쓰기에서 원본의 노드가 바뀌므로, 그 전에 원본에서 Search 등으로 얻은 노드 포인터와
+// Iterator는 스냅숏 쪽 노드를 가리키게 된다. MutableCursor는 쓰기 때 키로 자리를 다시 찾는다.
+// Search로 얻은 노드의 Value를 직접 고치는 것은 쓰기로 잡히지 않으므로, 스냅숏을 뜬 뒤에는 Put이나
+// Update로 고쳐야 한다. 스냅숏에 쓰려 하면 panic한다. 고쳐 쓸 사본이 필요하면 스냅숏의 Clone을 쓴다.
+func (t *Tree[K, V]) Snapshot() *Tree[K, V] {
+	if t.readOnly {
+		return t
+	}
+	s := t.newLike()
+	s.root, s.size, s.dead = t.root, t.size, t.dead
+	s.readOnly = true
+	s.lru = nil // 스냅숏은 원소를 내보내지 않으므로 사용 순서도 필요 없다.
+	t.shared = t.root != nil
+	return s
+}
+
+// willWrite는 트리를 고치는 모든 공개 연산이 노드를 만지기 전에 부른다. 읽기 전용이면 panic하고,
+// 스냅숏과 노드를 공유 중이면 먼저 노드를 복사해 떼어 낸다. 복사했으면 true를 돌려준다.
+func (t *Tree[K, V]) willWrite() bool {
+	if t.readOnly {
+		panic("rbtree: write to a read-only snapshot")
+	}
+	if !t.shared {
+		return false
+	}
+	t.root = cloneNode(t.root, nil, nil)
+	t.shared = false
+	// cloneNode는 집계 칸을 복사하지 않으므로 스냅숏 쪽 칸은 그대로 두고 새 노드에 새로 만든다.
+	t.augmentAll()
+	t.mods++ // 노드가 모두 바뀌었으므로 진행 중인 순회는 더 이상 원본을 걷지 않는다.
+	return true
+}
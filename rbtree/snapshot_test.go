@@ -0,0 +1,95 @@
+package rbtree
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSnapshotIsolation(t *testing.T) {
+	for _, tree := range []*Tree[int, int]{New[int, int](), NewWithTombstones[int, int]()} {
+		for i := 0; i < 100; i++ {
+			tree.Insert(i, i)
+		}
+		snap := tree.Snapshot()
+		tree.Insert(1000, 1000)
+		tree.Delete(5)
+		tree.Put(6, -6)
+		tree.DeleteRange(50, 60)
+
+		if snap.Size() != 100 || snap.Contains(1000) || !snap.Contains(5) || !snap.Contains(55) {
+			t.Fatalf("snapshot changed after writes to the original (size %d)", snap.Size())
+		}
+		if v, _ := snap.Get(6); v != 6 {
+			t.Fatalf("snapshot value changed to %d", v)
+		}
+		if tree.Size() != 90 || tree.Contains(5) {
+			t.Fatalf("original lost writes after snapshot (size %d)", tree.Size())
+		}
+		assertRBProperties(t, snap)
+		assertRBProperties(t, tree)
+	}
+}
+
+func TestSnapshotIsReadOnly(t *testing.T) {
+	tree := New[int, int]()
+	tree.Insert(1, 1)
+	snap := tree.Snapshot()
+	if snap.Snapshot() != snap {
+		t.Fatalf("snapshot of a snapshot should be itself")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("writing to a snapshot should panic")
+		}
+	}()
+	snap.Insert(2, 2)
+}
+
+func TestSnapshotCursorFollowsCopy(t *testing.T) {
+	tree := New[int, int]()
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, i)
+	}
+	c := tree.MutableCursor()
+	c.Seek(4)
+	snap := tree.Snapshot()
+	c.SetValue(40)
+	c.DeleteCurrent()
+	c.Next()
+	c.InsertBefore(4, 44)
+	if v, _ := tree.Get(4); v != 44 {
+		t.Fatalf("cursor writes should land in the original, got %d", v)
+	}
+	if v, _ := snap.Get(4); v != 4 {
+		t.Fatalf("cursor writes leaked into the snapshot, got %d", v)
+	}
+	assertRBProperties(t, tree)
+}
+
+// go test -race로 돌리면 스냅숏을 읽는 쪽과 원본에 쓰는 쪽이 노드를 공유하지 않음을 확인할 수 있다.
+func TestSnapshotLockFreeReaders(t *testing.T) {
+	tree := New[int, int]()
+	for i := 0; i < 1000; i++ {
+		tree.Insert(i, i)
+	}
+	var wg sync.WaitGroup
+	for round := 0; round < 5; round++ {
+		snap := tree.Snapshot()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n := 0
+			for range snap.All() {
+				n++
+			}
+			if n != snap.Size() {
+				t.Errorf("snapshot iteration saw %d of %d keys", n, snap.Size())
+			}
+		}()
+		for i := 0; i < 200; i++ {
+			tree.Insert(1000+round*200+i, i)
+			tree.Delete(round*200 + i)
+		}
+	}
+	wg.Wait()
+}
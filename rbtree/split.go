@@ -0,0 +1,266 @@
+package rbtree
+
+// 이 파일은 검정 높이(black height)를 이용한 join/split을 구현한다.
+// join(L, k, R)은 L의 모든 키 < k < R의 모든 키일 때 세 부분을 하나의 RB 트리로 잇는데,
+// 검정 높이가 더 큰 쪽의 오른쪽(또는 왼쪽) 척추를 따라 내려가 높이가 같은 검정 노드를 찾고
+// 거기에 k를 빨강으로 끼운 뒤 삽입 보정과 같은 회전으로 빨강-빨강을 없앤다.
+// 두 트리의 검정 높이 차이만큼만 내려가므로 O(|h(L) - h(R)| + 1)이고,
+// split은 경로를 따라 내려가며 조각들을 join하므로 높이 차이의 합이 망원급수로 줄어 O(log n)이다.
+//
+// 아래 함수들에서 검정 높이 h는 "그 노드부터 잎까지 지나는 검정 노드 수(자신 포함)"이며 nil은 0이다.
+
+// Split은 트리를 key보다 작은 키만 담은 트리와 key 이상인 키만 담은 트리로 나눈다.
+// 노드를 새로 만들지 않고 기존 노드를 재배치하므로 O(log n)이며, 호출 뒤 t는 빈 트리가 된다.
+// 두 결과 트리는 t의 비교 함수와 설정(톰스톤 모드, 크기 제한, 균형 방식)을 물려받는다.
+// 톰스톤 모드라면 먼저 Compact로 톰스톤을 정리한다.
+func (t *Tree[K, V]) Split(key K) (left, right *Tree[K, V]) {
+	t.willWrite()
+	if t.dead > 0 {
+		t.Compact(nil)
+	}
+	pairs, removed := t.pendingClear(), t.size
+	l, _, r, _ := t.split(t.root, blackHeightOf(t.root), key)
+	left, right = t.newLike(), t.newLike()
+	left.setRoot(l)
+	right.setRoot(r)
+	t.root, t.size = nil, 0
+	t.afterClear(removed, pairs)
+	return left, right
+}
+
+// Join은 left의 모든 키가 right의 모든 키보다 작을 때 두 트리를 이어 하나의 RB 트리로 만든다.
+// right의 최소 노드를 떼어 가운데 노드로 삼고 검정 높이를 맞춰 붙이므로 O(log n)이다.
+// Split과 함께 쓰면 키 구간을 통째로 다른 트리로 옮길 수 있다. 두 입력 트리의 노드를 그대로
+// 가져오므로 호출 뒤 left와 right는 빈 트리가 되고, 결과는 left의 비교 함수와 설정을 물려받는다.
+// 키 순서 조건이 깨지면 panic한다.
+func Join[K any, V any](left, right *Tree[K, V]) *Tree[K, V] {
+	for _, t := range []*Tree[K, V]{left, right} {
+		t.willWrite()
+		if t.dead > 0 {
+			t.Compact(nil)
+		}
+	}
+	if left.size > 0 && right.size > 0 && left.compare(left.last().Key, right.first().Key) >= 0 {
+		panic("rbtree: Join requires every key in left to be less than every key in right")
+	}
+
+	leftPairs, rightPairs := left.pendingClear(), right.pendingClear()
+	leftSize, rightSize := left.size, right.size
+	out := left.newLike()
+	switch {
+	case right.root == nil:
+		out.setRoot(left.root)
+	case left.root == nil:
+		out.setRoot(right.root)
+	default:
+		root, _ := concat(left.root, blackHeightOf(left.root), right.root, blackHeightOf(right.root))
+		out.setRoot(root)
+	}
+	left.root, left.size = nil, 0
+	right.root, right.size = nil, 0
+	left.afterClear(leftSize, leftPairs)
+	right.afterClear(rightSize, rightPairs)
+	out.evictOverflow()
+	return out
+}
+
+// newLike는 t와 같은 비교 함수와 설정을 가진 빈 트리를 만든다. expvar 등록은 물려주지 않는다.
+func (t *Tree[K, V]) newLike() *Tree[K, V] {
+	return &Tree[K, V]{
+		compare:    t.plainCompare(),
+		balance:    t.balance,
+		tombstones: t.tombstones,
+		maxSize:    t.maxSize,
+		eviction:   t.eviction,
+		lru:        t.lru.fresh(),
+		onEvict:    t.onEvict,
+	}
+}
+
+// setRoot는 join/split으로 만든 서브트리를 트리의 루트로 삼는다. 크기는 루트의 count에서 얻는다.
+func (t *Tree[K, V]) setRoot(root *Node[K, V]) {
+	t.root = root
+	t.size = countOf(root)
+	t.mods++
+	if root != nil {
+		root.Parent = nil
+		root.Color = black
+	}
+	t.balancer().normalize(t)
+	t.augmentAll()
+}
+
+// split은 높이가 h인 서브트리 n을 key 미만과 key 이상 두 서브트리로 나누고 각각의 검정 높이를 돌려준다.
+func (t *Tree[K, V]) split(n *Node[K, V], h int, key K) (l *Node[K, V], hl int, r *Node[K, V], hr int) {
+	if n == nil {
+		return nil, 0, nil, 0
+	}
+	left, right := detachChildren(n)
+	hc := h - blackness(n) // 두 자식 서브트리의 검정 높이
+	if t.compare(key, n.Key) <= 0 {
+		// n은 key 이상이므로 오른쪽 결과에 속한다.
+		ll, hll, lr, hlr := t.split(left, hc, key)
+		r, hr = join(lr, hlr, n, right, hc)
+		return ll, hll, r, hr
+	}
+	rl, hrl, rr, hrr := t.split(right, hc, key)
+	l, hl = join(left, hc, n, rl, hrl)
+	return l, hl, rr, hrr
+}
+
+// concat은 l의 모든 키가 r의 모든 키보다 작을 때 가운데 노드 없이 두 서브트리를 잇는다.
+// r의 최소 노드(톰스톤이어도 상관없다)를 떼어 join의 가운데 노드로 쓴다.
+func concat[K any, V any](l *Node[K, V], hl int, r *Node[K, V], hr int) (*Node[K, V], int) {
+	if r == nil {
+		return l, hl
+	}
+	rest := &Tree[K, V]{root: r}
+	mid := minimum(r)
+	rest.deleteNode(mid)
+	mid.Left, mid.Right, mid.Parent = nil, nil, nil
+	return join(l, hl, mid, rest.root, blackHeightOf(rest.root))
+}
+
+// join은 검정 높이가 hl, hr인 두 서브트리를 mid를 가운데 두고 잇는다. 결과 루트는 검정이고
+// Parent는 nil이며, 결과의 검정 높이를 함께 돌려준다.
+func join[K any, V any](l *Node[K, V], hl int, mid *Node[K, V], r *Node[K, V], hr int) (*Node[K, V], int) {
+	// 양쪽 루트를 검정으로 칠해 두면 척추를 내려갈 때 경우가 줄어든다. 빨강 루트를 검정으로
+	// 바꾸면 그 서브트리의 모든 경로에 검정이 하나씩 늘어난다.
+	if l != nil && l.Color == red {
+		l.Color = black
+		hl++
+	}
+	if r != nil && r.Color == red {
+		r.Color = black
+		hr++
+	}
+
+	var root *Node[K, V]
+	h := hl
+	switch {
+	case hl > hr:
+		root = joinRight(l, hl, mid, r, hr)
+	case hl < hr:
+		root = joinLeft(l, hl, mid, r, hr)
+		h = hr
+	default:
+		attach(mid, l, r)
+		mid.Color = black
+		return mid, hl + 1
+	}
+	root.Parent = nil
+	if root.Color == red {
+		root.Color = black
+		h++
+	}
+	return root, h
+}
+
+// joinRight는 l의 오른쪽 척추를 따라 검정 높이가 hr인 검정 노드 c를 찾아, 그 자리를 빨강 mid(c, r)로
+// 바꾼다. 돌아오는 길에 검정 노드 아래 빨강-빨강이 생겼으면 왼쪽 회전으로 풀어 준다.
+func joinRight[K any, V any](l *Node[K, V], hl int, mid *Node[K, V], r *Node[K, V], hr int) *Node[K, V] {
+	if colorOf(l) == black && hl == hr {
+		attach(mid, l, r)
+		mid.Color = red
+		return mid
+	}
+	child := joinRight(l.Right, hl-blackness(l), mid, r, hr)
+	l.Right = child
+	child.Parent = l
+	updateCount(l)
+	if l.Color == black && colorOf(l.Right) == red && colorOf(l.Right.Right) == red {
+		l.Right.Right.Color = black
+		return rotateLeftNode(l)
+	}
+	return l
+}
+
+// joinLeft는 joinRight의 좌우 대칭이다.
+func joinLeft[K any, V any](l *Node[K, V], hl int, mid *Node[K, V], r *Node[K, V], hr int) *Node[K, V] {
+	if colorOf(r) == black && hl == hr {
+		attach(mid, l, r)
+		mid.Color = red
+		return mid
+	}
+	child := joinLeft(l, hl, mid, r.Left, hr-blackness(r))
+	r.Left = child
+	child.Parent = r
+	updateCount(r)
+	if r.Color == black && colorOf(r.Left) == red && colorOf(r.Left.Left) == red {
+		r.Left.Left.Color = black
+		return rotateRightNode(r)
+	}
+	return r
+}
+
+// rotateLeftNode와 rotateRightNode는 트리 루트를 모르는 서브트리용 회전이다. 새 서브트리 루트를
+// 돌려주며, 그것을 원래 부모에 다시 매다는 일은 호출부가 맡는다.
+func rotateLeftNode[K any, V any](node *Node[K, V]) *Node[K, V] {
+	right := node.Right
+	node.Right = right.Left
+	if right.Left != nil {
+		right.Left.Parent = node
+	}
+	right.Parent = node.Parent
+	right.Left = node
+	node.Parent = right
+	right.count = node.count
+	updateCount(node)
+	return right
+}
+
+func rotateRightNode[K any, V any](node *Node[K, V]) *Node[K, V] {
+	left := node.Left
+	node.Left = left.Right
+	if left.Right != nil {
+		left.Right.Parent = node
+	}
+	left.Parent = node.Parent
+	left.Right = node
+	node.Parent = left
+	left.count = node.count
+	updateCount(node)
+	return left
+}
+
+// attach는 mid의 두 자식을 l, r로 바꾸고 부모 포인터와 count를 맞춘다.
+func attach[K any, V any](mid, l, r *Node[K, V]) {
+	mid.Left, mid.Right = l, r
+	if l != nil {
+		l.Parent = mid
+	}
+	if r != nil {
+		r.Parent = mid
+	}
+	updateCount(mid)
+}
+
+// detachChildren은 n에서 두 자식 서브트리를 떼어 내 독립된 서브트리로 돌려준다.
+func detachChildren[K any, V any](n *Node[K, V]) (left, right *Node[K, V]) {
+	left, right = n.Left, n.Right
+	n.Left, n.Right, n.Parent = nil, nil, nil
+	if left != nil {
+		left.Parent = nil
+	}
+	if right != nil {
+		right.Parent = nil
+	}
+	return left, right
+}
+
+// blackHeightOf는 왼쪽 척추를 따라 내려가며 검정 높이를 센다. 규칙 (4) 덕분에 어느 경로로 세도 같다.
+func blackHeightOf[K any, V any](node *Node[K, V]) int {
+	h := 0
+	for ; node != nil; node = node.Left {
+		h += blackness(node)
+	}
+	return h
+}
+
+// blackness는 노드가 검정이면 1, 빨강이면 0이다.
+func blackness[K any, V any](node *Node[K, V]) int {
+	if colorOf(node) == black {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,121 @@
+package rbtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 10, 100, 1000} {
+		for trial := 0; trial < 20; trial++ {
+			tree := New[int, int]()
+			for _, k := range rand.Perm(n) {
+				tree.Insert(k*2, k)
+			}
+			pivot := rand.Intn(2*n+3) - 1
+
+			left, right := tree.Split(pivot)
+			if tree.Size() != 0 || tree.Root() != nil {
+				t.Fatalf("Split should leave the source tree empty")
+			}
+			assertRBProperties(t, left)
+			assertRBProperties(t, right)
+			if left.Size()+right.Size() != n {
+				t.Fatalf("n=%d pivot=%d: sizes %d+%d do not add up", n, pivot, left.Size(), right.Size())
+			}
+			left.InOrder(func(key, value int) {
+				if key >= pivot || value != key/2 {
+					t.Fatalf("left part holds %d => %d for pivot %d", key, value, pivot)
+				}
+			})
+			right.InOrder(func(key, value int) {
+				if key < pivot {
+					t.Fatalf("right part holds %d for pivot %d", key, pivot)
+				}
+			})
+
+			// 나눈 트리도 평소처럼 삽입/삭제가 가능해야 한다.
+			left.Insert(-100, 0)
+			right.Insert(1<<20, 0)
+			right.DeleteMin()
+			assertRBProperties(t, left)
+			assertRBProperties(t, right)
+		}
+	}
+}
+
+func TestSplitTombstones(t *testing.T) {
+	tree := NewWithTombstones[int, int]()
+	for i := 0; i < 50; i++ {
+		tree.Insert(i, i)
+	}
+	for i := 0; i < 50; i += 3 {
+		tree.Delete(i)
+	}
+	left, right := tree.Split(25)
+	if left.Size() != 16 || right.Size() != 17 {
+		t.Fatalf("expected 16/17 live keys, got %d/%d", left.Size(), right.Size())
+	}
+	if left.Search(24) != nil || left.Search(23) == nil {
+		t.Fatalf("tombstoned keys should stay deleted after Split")
+	}
+	assertRBProperties(t, left)
+	assertRBProperties(t, right)
+}
+
+func TestJoin(t *testing.T) {
+	for trial := 0; trial < 200; trial++ {
+		nl, nr := rand.Intn(300), rand.Intn(300)
+		left, right := New[int, int](), New[int, int]()
+		for _, k := range rand.Perm(nl) {
+			left.Insert(k, k)
+		}
+		for _, k := range rand.Perm(nr) {
+			right.Insert(nl+k, nl+k)
+		}
+
+		joined := Join(left, right)
+		assertRBProperties(t, joined)
+		if joined.Size() != nl+nr || left.Size() != 0 || right.Size() != 0 {
+			t.Fatalf("expected %d keys in result and empty inputs, got %d/%d/%d", nl+nr, joined.Size(), left.Size(), right.Size())
+		}
+		next := 0
+		joined.InOrder(func(key, value int) {
+			if key != next {
+				t.Fatalf("expected key %d, got %d", next, key)
+			}
+			next++
+		})
+	}
+}
+
+func TestSplitJoinRoundTrip(t *testing.T) {
+	tree := New[int, int]()
+	for _, k := range rand.Perm(500) {
+		tree.Insert(k, k)
+	}
+	// [100, 200) 구간을 다른 트리로 옮긴다.
+	head, rest := tree.Split(100)
+	moved, tail := rest.Split(200)
+	remaining := Join(head, tail)
+	assertRBProperties(t, remaining)
+	assertRBProperties(t, moved)
+	if remaining.Size() != 400 || moved.Size() != 100 {
+		t.Fatalf("expected 400/100 keys, got %d/%d", remaining.Size(), moved.Size())
+	}
+	if remaining.Search(150) != nil || moved.Search(150) == nil {
+		t.Fatalf("key 150 should have moved")
+	}
+}
+
+func TestJoinRejectsOverlap(t *testing.T) {
+	left, right := New[int, int](), New[int, int]()
+	left.Insert(5, 5)
+	right.Insert(5, 5)
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Join with overlapping keys should panic")
+		}
+	}()
+	Join(left, right)
+}
@@ -0,0 +1,31 @@
+package rbtree
+
+import "context"
+
+// Stream은 모든 원소를 키 오름차순으로 채널에 흘려보내고, 다 보내거나 ctx가 끝나면 채널을 닫는다.
+// 채널에는 버퍼가 없으므로 받는 쪽이 느리면 보내는 고루틴도 기다린다. 슬라이스로 모으지 않고 큰 트리를
+// 워커 풀로 파이프라인할 때 쓴다. 받는 쪽이 중간에 그만두려면 ctx를 취소한다. 취소하지 않고
+// 채널을 버리면 보내는 고루틴이 남는다.
+//
+// 보내는 고루틴은 Stream을 부른 시점의 Snapshot을 걸으므로, 그동안 원본을 고쳐도 되고 고친 내용은
+// 채널에 나타나지 않는다. 대신 Snapshot처럼 원본은 Stream 뒤 첫 쓰기에서 O(n) 복사를 한 번 치른다.
+// 원본을 여러 고루틴이 함께 쓰고 있다면 Stream은 쓰기와 같은 잠금 아래에서 부른다.
+func (t *Tree[K, V]) Stream(ctx context.Context) <-chan Pair[K, V] {
+	s := t.Snapshot()
+	ch := make(chan Pair[K, V])
+	go func() {
+		defer close(ch)
+		for node := s.first(); node != nil; node = nextLive(node) {
+			// 두 경우가 함께 준비되면 select는 아무거나 고르므로, 취소된 뒤에는 더 보내지 않도록 먼저 본다.
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case ch <- Pair[K, V]{Key: node.Key, Value: node.Value}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,47 @@
+package rbtree
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	tree := New[int, int]()
+	for i := 0; i < 1000; i++ {
+		tree.Insert(i, -i)
+	}
+
+	next := 0
+	for p := range tree.Stream(context.Background()) {
+		if p.Key != next || p.Value != -next {
+			t.Fatalf("received %v, want key %d", p, next)
+		}
+		// 스트림은 시작 시점의 내용을 보내므로 원본을 고쳐도 된다.
+		tree.Delete(next + 1)
+		next++
+	}
+	if next != 1000 {
+		t.Fatalf("received %d pairs, want 1000", next)
+	}
+}
+
+func TestStreamCancel(t *testing.T) {
+	tree := New[int, int]()
+	for i := 0; i < 1000; i++ {
+		tree.Insert(i, i)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := tree.Stream(ctx)
+	for i := 0; i < 10; i++ {
+		<-ch
+	}
+	cancel()
+	// 취소 뒤에는 기껏해야 이미 보내려던 원소 하나를 받고 채널이 닫힌다.
+	n := 0
+	for range ch {
+		n++
+	}
+	if n > 1 {
+		t.Fatalf("received %d pairs after cancel", n)
+	}
+}
@@ -0,0 +1,144 @@
+package rbtree
+
+import "iter"
+
+// RangeView는 트리의 키 구간 하나만 보이는 가벼운 뷰다. Java TreeMap의 headMap·tailMap·subMap처럼
+// 원소를 복사하지 않고 부모 트리를 가리키기만 하므로, 뷰를 만든 뒤 부모에 넣거나 지운 원소도 바로
+// 보인다. 하나의 색인을 키 접두사 구간으로 나눠 쓰는 것처럼 논리적으로 쪼갤 때 쓴다.
+//
+// 구간은 AscendRange와 같은 반열림 [lo, hi)이다. HeadMap은 아래쪽이, TailMap은 위쪽이 열려 있다.
+// 뷰는 읽기만 하며 고칠 때는 부모 트리를 고친다. 동시성 규칙도 부모 트리와 같다.
+type RangeView[K any, V any] struct {
+	t            *Tree[K, V]
+	lo, hi       K
+	hasLo, hasHi bool
+}
+
+// HeadMap은 hi보다 작은 키만 보이는 뷰를 돌려준다.
+func (t *Tree[K, V]) HeadMap(hi K) *RangeView[K, V] {
+	return &RangeView[K, V]{t: t, hi: hi, hasHi: true}
+}
+
+// TailMap은 lo 이상인 키만 보이는 뷰를 돌려준다.
+func (t *Tree[K, V]) TailMap(lo K) *RangeView[K, V] {
+	return &RangeView[K, V]{t: t, lo: lo, hasLo: true}
+}
+
+// SubMap은 [lo, hi) 구간의 키만 보이는 뷰를 돌려준다. lo >= hi이면 늘 빈 뷰다.
+func (t *Tree[K, V]) SubMap(lo, hi K) *RangeView[K, V] {
+	return &RangeView[K, V]{t: t, lo: lo, hi: hi, hasLo: true, hasHi: true}
+}
+
+// InRange는 key가 뷰의 구간 안에 있는지 알려 준다.
+func (v *RangeView[K, V]) InRange(key K) bool {
+	return (!v.hasLo || v.t.compare(key, v.lo) >= 0) && (!v.hasHi || v.t.compare(key, v.hi) < 0)
+}
+
+// Size는 구간 안의 원소 수를 돌려준다. 노드마다 유지하는 서브트리 크기로 양 끝의 Rank만 구하므로
+// 구간이 넓어도 O(log n)이다.
+func (v *RangeView[K, V]) Size() int {
+	lo, hi := 0, v.t.size
+	if v.hasLo {
+		lo = v.t.Rank(v.lo)
+	}
+	if v.hasHi {
+		hi = v.t.Rank(v.hi)
+	}
+	return max(hi-lo, 0)
+}
+
+// Get은 구간 안에 있는 key의 값을 돌려준다. 구간 밖의 키는 부모에 있어도 없는 것으로 본다.
+func (v *RangeView[K, V]) Get(key K) (V, bool) {
+	if !v.InRange(key) {
+		var zero V
+		return zero, false
+	}
+	return v.t.Get(key)
+}
+
+// Contains는 key가 구간 안에 있고 부모 트리에도 있는지 알려 준다.
+func (v *RangeView[K, V]) Contains(key K) bool {
+	return v.InRange(key) && v.t.Contains(key)
+}
+
+// Min은 구간 안에서 가장 작은 키의 노드를 돌려준다. 구간이 비었으면 nil이다.
+func (v *RangeView[K, V]) Min() *Node[K, V] {
+	return v.clip(v.first())
+}
+
+// Max는 구간 안에서 가장 큰 키의 노드를 돌려준다. 구간이 비었으면 nil이다.
+func (v *RangeView[K, V]) Max() *Node[K, V] {
+	return v.clip(v.last())
+}
+
+// All은 구간 안의 원소를 키 오름차순으로 내놓는다. 순회 도중 부모 트리의 구조가 바뀌면 Tree.All처럼
+// ErrModifiedDuringIteration으로 panic한다.
+func (v *RangeView[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		mods := v.t.mods
+		for node := v.first(); node != nil && v.belowHi(node); node = nextLive(node) {
+			if !yield(node.Key, node.Value) {
+				return
+			}
+			v.t.checkMods(mods)
+		}
+	}
+}
+
+// Backward는 All과 같지만 키 내림차순으로 원소를 내놓는다.
+func (v *RangeView[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		mods := v.t.mods
+		for node := v.last(); node != nil && v.aboveLo(node); node = prevLive(node) {
+			if !yield(node.Key, node.Value) {
+				return
+			}
+			v.t.checkMods(mods)
+		}
+	}
+}
+
+// Keys는 구간 안의 키를 오름차순으로 담은 새 슬라이스를 돌려준다.
+func (v *RangeView[K, V]) Keys() []K {
+	keys := make([]K, 0, v.Size())
+	for k := range v.All() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// first는 아래 끝 이상인 첫 노드를 돌려준다. 위 끝은 보지 않는다.
+func (v *RangeView[K, V]) first() *Node[K, V] {
+	if !v.hasLo {
+		return v.t.first()
+	}
+	return v.t.ceiling(v.lo)
+}
+
+// last는 위 끝보다 작은 마지막 노드를 돌려준다. 아래 끝은 보지 않는다.
+func (v *RangeView[K, V]) last() *Node[K, V] {
+	if !v.hasHi {
+		return v.t.last()
+	}
+	node := v.t.floor(v.hi)
+	if node != nil && v.t.compare(node.Key, v.hi) == 0 {
+		node = prevLive(node)
+	}
+	return node
+}
+
+func (v *RangeView[K, V]) belowHi(node *Node[K, V]) bool {
+	return !v.hasHi || v.t.compare(node.Key, v.hi) < 0
+}
+
+func (v *RangeView[K, V]) aboveLo(node *Node[K, V]) bool {
+	return !v.hasLo || v.t.compare(node.Key, v.lo) >= 0
+}
+
+// clip은 node가 구간 밖이면 nil을 돌려준다.
+func (v *RangeView[K, V]) clip(node *Node[K, V]) *Node[K, V] {
+	if node == nil || !v.belowHi(node) || !v.aboveLo(node) {
+		return nil
+	}
+	return node
+}
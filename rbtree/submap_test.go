@@ -0,0 +1,89 @@
+package rbtree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestRangeViews(t *testing.T) {
+	tree := New[int, int]()
+	for i := 0; i < 100; i += 10 {
+		tree.Insert(i, i)
+	}
+	head, tail, sub := tree.HeadMap(30), tree.TailMap(70), tree.SubMap(25, 55)
+
+	if got := head.Keys(); !slices.Equal(got, []int{0, 10, 20}) {
+		t.Fatalf("HeadMap(30) keys = %v", got)
+	}
+	if got := tail.Keys(); !slices.Equal(got, []int{70, 80, 90}) {
+		t.Fatalf("TailMap(70) keys = %v", got)
+	}
+	if got := sub.Keys(); !slices.Equal(got, []int{30, 40, 50}) {
+		t.Fatalf("SubMap(25, 55) keys = %v", got)
+	}
+	if sub.Min().Key != 30 || sub.Max().Key != 50 || head.Max().Key != 20 || tail.Min().Key != 70 {
+		t.Fatalf("view bounds: sub [%d, %d], head max %d, tail min %d",
+			sub.Min().Key, sub.Max().Key, head.Max().Key, tail.Min().Key)
+	}
+	if sub.Contains(60) || !sub.Contains(40) {
+		t.Fatalf("Contains should be limited to the range")
+	}
+	if _, ok := head.Get(50); ok {
+		t.Fatalf("Get outside the range should miss")
+	}
+
+	// 부모의 변경이 바로 보인다.
+	tree.Insert(35, 35)
+	tree.Delete(50)
+	tree.Insert(25, 25)
+	if got := sub.Keys(); !slices.Equal(got, []int{25, 30, 35, 40}) || sub.Size() != 4 {
+		t.Fatalf("SubMap after changes = %v (size %d)", got, sub.Size())
+	}
+	var back []int
+	for k := range sub.Backward() {
+		back = append(back, k)
+	}
+	if !slices.Equal(back, []int{40, 35, 30, 25}) {
+		t.Fatalf("Backward = %v", back)
+	}
+
+	empty := tree.SubMap(41, 45)
+	if empty.Size() != 0 || empty.Min() != nil || empty.Max() != nil || len(empty.Keys()) != 0 {
+		t.Fatalf("SubMap(41, 45) should be empty")
+	}
+	if inverted := tree.SubMap(60, 10); inverted.Size() != 0 || inverted.Min() != nil {
+		t.Fatalf("inverted range should be empty")
+	}
+}
+
+func TestRangeViewAgainstFilter(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewWithTombstones[int, int]()
+	for i := 0; i < 2000; i++ {
+		k := rng.Intn(500)
+		if rng.Intn(3) == 0 {
+			tree.Delete(k)
+		} else {
+			tree.Insert(k, k)
+		}
+	}
+	for i := 0; i < 200; i++ {
+		lo, hi := rng.Intn(520)-10, rng.Intn(520)-10
+		var want []int
+		for k := range tree.All() {
+			if k >= lo && k < hi {
+				want = append(want, k)
+			}
+		}
+		view := tree.SubMap(lo, hi)
+		got := view.Keys()
+		if !slices.Equal(got, want) || view.Size() != len(want) {
+			t.Fatalf("SubMap(%d, %d) = %v (size %d), want %v", lo, hi, got, view.Size(), want)
+		}
+		if len(want) > 0 && (view.Min().Key != want[0] || view.Max().Key != want[len(want)-1]) {
+			t.Fatalf("SubMap(%d, %d) bounds [%d, %d], want [%d, %d]",
+				lo, hi, view.Min().Key, view.Max().Key, want[0], want[len(want)-1])
+		}
+	}
+}
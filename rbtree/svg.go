@@ -0,0 +1,94 @@
+package rbtree
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+)
+
+// SVG 배치 단위(픽셀). 노드의 x는 중위 순서, y는 깊이로 정하므로 서로 겹치지 않는다.
+const (
+	svgRadius  = 16
+	svgXStep   = 40
+	svgYStep   = 60
+	svgPadding = 24
+)
+
+// ToSVG는 트리를 외부 파일 없이 열리는 SVG 한 장으로 그린다. 노드는 색에 맞춰 빨강/검정 원으로,
+// 키는 원 안의 글자로 나타내며 톰스톤 노드는 회색 점선 원이다. 노드의 가로 위치는 중위 순서라서
+// 키 순서가 왼쪽에서 오른쪽으로 그대로 읽힌다. 강의 슬라이드에 트리 상태를 붙일 때 쓴다.
+func (t *Tree[K, V]) ToSVG(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	t.writeSVG(bw)
+	return bw.Flush()
+}
+
+// ToHTML은 ToSVG의 그림을 담은 독립 HTML 문서를 쓴다.
+func (t *Tree[K, V]) ToHTML(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("<!DOCTYPE html>\n<html>\n<head><meta charset=\"utf-8\"><title>rbtree</title></head>\n<body>\n")
+	t.writeSVG(bw)
+	bw.WriteString("</body>\n</html>\n")
+	return bw.Flush()
+}
+
+type svgNode[K any, V any] struct {
+	node   *Node[K, V]
+	x, y   int
+	parent int // 부모의 svgNode 인덱스, 루트는 -1
+}
+
+func (t *Tree[K, V]) writeSVG(w *bufio.Writer) {
+	var nodes []svgNode[K, V]
+	var layout func(n *Node[K, V], depth, parent int)
+	column := 0
+	layout = func(n *Node[K, V], depth, parent int) {
+		if n == nil {
+			return
+		}
+		// 부모 인덱스를 알아야 하므로 자리를 먼저 잡고 x는 중위 순서로 나중에 채운다.
+		i := len(nodes)
+		nodes = append(nodes, svgNode[K, V]{node: n, y: svgPadding + svgRadius + depth*svgYStep, parent: parent})
+		layout(n.Left, depth+1, i)
+		nodes[i].x = svgPadding + svgRadius + column*svgXStep
+		column++
+		layout(n.Right, depth+1, i)
+	}
+	layout(t.root, 0, -1)
+
+	width, height := 2*svgPadding, 2*svgPadding
+	for _, n := range nodes {
+		width = max(width, n.x+svgRadius+svgPadding)
+		height = max(height, n.y+svgRadius+svgPadding)
+	}
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		width, height, width, height)
+
+	// 선을 먼저 그려야 원이 선을 덮는다.
+	for _, n := range nodes {
+		if n.parent >= 0 {
+			p := nodes[n.parent]
+			fmt.Fprintf(w, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#888"/>`+"\n", p.x, p.y, n.x, n.y)
+		}
+	}
+	for _, n := range nodes {
+		fill, stroke, dash := "#222", "#000", ""
+		if n.node.Color == red {
+			fill, stroke = "#d22", "#900"
+		}
+		if n.node.deleted {
+			fill, stroke, dash = "#fff", "#999", ` stroke-dasharray="3,2"`
+		}
+		textColor := "#fff"
+		if n.node.deleted {
+			textColor = "#999"
+		}
+		key := html.EscapeString(fmt.Sprint(n.node.Key))
+		fmt.Fprintf(w, `<g><title>%s</title><circle cx="%d" cy="%d" r="%d" fill="%s" stroke="%s"%s/>`,
+			html.EscapeString(fmt.Sprintf("%v => %v", n.node.Key, n.node.Value)), n.x, n.y, svgRadius, fill, stroke, dash)
+		fmt.Fprintf(w, `<text x="%d" y="%d" fill="%s" text-anchor="middle" dominant-baseline="central">%s</text></g>`+"\n",
+			n.x, n.y, textColor, key)
+	}
+	w.WriteString("</svg>\n")
+}
@@ -0,0 +1,50 @@
+package rbtree
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestToSVG(t *testing.T) {
+	tree := New[string, int]()
+	for i, k := range []string{"m", "c", "x", "a", "<&>"} {
+		tree.Insert(k, i)
+	}
+	var buf bytes.Buffer
+	if err := tree.ToSVG(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	// 키에 든 특수 문자가 이스케이프되어 올바른 XML이어야 한다.
+	dec := xml.NewDecoder(strings.NewReader(out))
+	for {
+		if _, err := dec.Token(); err != nil {
+			if !errors.Is(err, io.EOF) {
+				t.Fatalf("ToSVG produced invalid XML: %v\n%s", err, out)
+			}
+			break
+		}
+	}
+	if n := strings.Count(out, "<circle"); n != tree.Size() {
+		t.Fatalf("expected %d circles, got %d", tree.Size(), n)
+	}
+	if n := strings.Count(out, "<line"); n != tree.Size()-1 {
+		t.Fatalf("expected %d edges, got %d", tree.Size()-1, n)
+	}
+	if !strings.Contains(out, `fill="#d22"`) || !strings.Contains(out, `fill="#222"`) {
+		t.Fatalf("expected both red and black nodes in %s", out)
+	}
+
+	buf.Reset()
+	if err := tree.ToHTML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "<!DOCTYPE html>") || !strings.Contains(buf.String(), "<svg") {
+		t.Fatalf("ToHTML should wrap the SVG in a document")
+	}
+}
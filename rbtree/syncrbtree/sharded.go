@@ -0,0 +1,221 @@
+package syncrbtree
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/EletricSaw/rbtree/rbtree"
+)
+
+// Sharded는 키 공간을 여러 내부 트리로 나누고 트리마다 따로 잠그는 트리다. 서로 다른 샤드에 대한
+// 쓰기는 동시에 진행되므로, 전역 잠금 하나로는 코어 하나밖에 못 쓰는 쓰기 위주 적재에 알맞다.
+//
+// 키를 나누는 방법은 두 가지다. NewShardedRange는 경계 키로 구간을 나누므로 순회가 샤드를 차례로
+// 잇기만 하면 되고, 구간 순회도 겹치는 샤드만 본다. 대신 키 분포에 맞게 경계를 골라야 쓰기가 고르게
+// 퍼진다. NewShardedHash는 해시로 나누므로 분포를 몰라도 고르게 퍼지지만, 순서 있는 순회는
+// 모든 샤드를 병합해야 한다.
+//
+// 순회(All, AscendRange)는 모든 샤드의 공유 잠금을 잡고 진행하므로 샤드 전체에 걸쳐 일관된
+// 모습을 보지만, 그동안 쓰기는 기다린다. 콜백 안에서 같은 트리에 쓰면 교착 상태가 된다.
+type Sharded[K cmp.Ordered, V any] struct {
+	shards  []shard[K, V]
+	shardOf func(key K) int
+	ranged  bool
+}
+
+type shard[K any, V any] struct {
+	mu   sync.RWMutex
+	tree *rbtree.Tree[K, V]
+}
+
+// NewShardedRange는 bounds로 키 공간을 len(bounds)+1개 구간으로 나눈다. i번째 샤드는
+// [bounds[i-1], bounds[i]) 구간의 키를 맡는다(양 끝 샤드는 한쪽이 열려 있다).
+// bounds가 엄격하게 오름차순이 아니면 panic한다.
+func NewShardedRange[K cmp.Ordered, V any](bounds ...K) *Sharded[K, V] {
+	for i := 1; i < len(bounds); i++ {
+		if bounds[i-1] >= bounds[i] {
+			panic("rbtree/syncrbtree: shard bounds must be strictly increasing")
+		}
+	}
+	bounds = slices.Clone(bounds)
+	s := newSharded[K, V](len(bounds) + 1)
+	s.ranged = true
+	s.shardOf = func(key K) int {
+		i, found := slices.BinarySearch(bounds, key)
+		if found {
+			i++ // 경계 키는 오른쪽 샤드의 첫 키다.
+		}
+		return i
+	}
+	return s
+}
+
+// NewShardedHash는 hash(key) % n으로 키를 n개 샤드에 나눈다. n이 1보다 작으면 panic한다.
+func NewShardedHash[K cmp.Ordered, V any](n int, hash func(key K) uint64) *Sharded[K, V] {
+	if n < 1 {
+		panic("rbtree/syncrbtree: shard count must be positive")
+	}
+	s := newSharded[K, V](n)
+	s.shardOf = func(key K) int {
+		return int(hash(key) % uint64(n))
+	}
+	return s
+}
+
+func newSharded[K cmp.Ordered, V any](n int) *Sharded[K, V] {
+	s := &Sharded[K, V]{shards: make([]shard[K, V], n)}
+	for i := range s.shards {
+		s.shards[i].tree = rbtree.New[K, V]()
+	}
+	return s
+}
+
+// Shards는 샤드 수를 돌려준다.
+func (s *Sharded[K, V]) Shards() int {
+	return len(s.shards)
+}
+
+func (s *Sharded[K, V]) shard(key K) *shard[K, V] {
+	return &s.shards[s.shardOf(key)]
+}
+
+// Insert는 key가 속한 샤드에 원소를 넣는다.
+func (s *Sharded[K, V]) Insert(key K, value V) {
+	sh := s.shard(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	sh.tree.Insert(key, value)
+}
+
+// Put은 rbtree.Tree.Put과 같다.
+func (s *Sharded[K, V]) Put(key K, value V) (previous V, replaced bool) {
+	sh := s.shard(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	return sh.tree.Put(key, value)
+}
+
+// Update는 rbtree.Tree.Update와 같다. fn은 해당 샤드의 배타 잠금 아래에서 불린다.
+func (s *Sharded[K, V]) Update(key K, fn func(old V, exists bool) (value V, keep bool)) {
+	sh := s.shard(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	sh.tree.Update(key, fn)
+}
+
+// Delete는 rbtree.Tree.Delete와 같다.
+func (s *Sharded[K, V]) Delete(key K) bool {
+	sh := s.shard(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	return sh.tree.Delete(key)
+}
+
+// Pop은 rbtree.Tree.Pop과 같다.
+func (s *Sharded[K, V]) Pop(key K) (V, bool) {
+	sh := s.shard(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	return sh.tree.Pop(key)
+}
+
+// Get은 rbtree.Tree.Get과 같다.
+func (s *Sharded[K, V]) Get(key K) (V, bool) {
+	sh := s.shard(key)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	return sh.tree.Get(key)
+}
+
+// Contains는 rbtree.Tree.Contains와 같다.
+func (s *Sharded[K, V]) Contains(key K) bool {
+	sh := s.shard(key)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	return sh.tree.Contains(key)
+}
+
+// Size는 모든 샤드의 원소 수를 더한다. 샤드를 하나씩 잠그므로 동시 쓰기 중에는 근삿값이다.
+func (s *Sharded[K, V]) Size() int {
+	n := 0
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.RLock()
+		n += sh.tree.Size()
+		sh.mu.RUnlock()
+	}
+	return n
+}
+
+// All은 모든 원소를 키 오름차순으로 내놓는다.
+func (s *Sharded[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		s.ascend(nil, nil, yield)
+	}
+}
+
+// AscendRange는 [lo, hi) 구간의 원소를 키 오름차순으로 방문한다. fn이 false를 돌려주면 멈춘다.
+func (s *Sharded[K, V]) AscendRange(lo, hi K, fn func(key K, value V) bool) {
+	s.ascend(&lo, &hi, fn)
+}
+
+// ascend는 lo, hi가 nil이면 그쪽 끝을 열어 둔 채로 순회한다.
+func (s *Sharded[K, V]) ascend(lo, hi *K, fn func(key K, value V) bool) {
+	// 쓰기는 샤드 하나만 잠그므로 모든 샤드를 같은 순서로 잠가도 교착 상태가 생기지 않는다.
+	for i := range s.shards {
+		s.shards[i].mu.RLock()
+		defer s.shards[i].mu.RUnlock()
+	}
+
+	its := make([]*rbtree.Iterator[K, V], 0, len(s.shards))
+	for i := range s.shards {
+		it := s.shards[i].tree.Iter()
+		var ok bool
+		if lo != nil {
+			ok = it.Seek(*lo)
+		} else {
+			ok = it.Next()
+		}
+		if ok {
+			its = append(its, it)
+		}
+	}
+
+	if s.ranged {
+		// 구간 샤드는 샤드 순서가 곧 키 순서다.
+		for _, it := range its {
+			for ok := true; ok; ok = it.Next() {
+				if hi != nil && it.Key() >= *hi {
+					return
+				}
+				if !fn(it.Key(), it.Value()) {
+					return
+				}
+			}
+		}
+		return
+	}
+
+	// 해시 샤드는 샤드마다 가장 작은 머리를 골라 내놓는 k-way 병합이다. 샤드 수는 보통 코어 수
+	// 정도로 작으므로 힙 대신 선형으로 찾는다.
+	for len(its) > 0 {
+		min := 0
+		for i := 1; i < len(its); i++ {
+			if its[i].Key() < its[min].Key() {
+				min = i
+			}
+		}
+		it := its[min]
+		if hi != nil && it.Key() >= *hi {
+			return
+		}
+		if !fn(it.Key(), it.Value()) {
+			return
+		}
+		if !it.Next() {
+			its = slices.Delete(its, min, min+1)
+		}
+	}
+}
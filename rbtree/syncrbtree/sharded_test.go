@@ -0,0 +1,77 @@
+package syncrbtree
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestSharded(t *testing.T) {
+	trees := map[string]*Sharded[int, int]{
+		"range": NewShardedRange[int, int](250, 500, 750),
+		"hash":  NewShardedHash[int, int](4, func(k int) uint64 { return uint64(k) * 0x9E3779B97F4A7C15 }),
+	}
+	for name, tree := range trees {
+		var wg sync.WaitGroup
+		for w := 0; w < 4; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for i := w; i < 1000; i += 4 {
+					tree.Insert(i, i*2)
+				}
+			}(w)
+		}
+		wg.Wait()
+
+		if tree.Size() != 1000 {
+			t.Fatalf("%s: expected 1000 keys, got %d", name, tree.Size())
+		}
+		prev := -1
+		for k, v := range tree.All() {
+			if k != prev+1 || v != k*2 {
+				t.Fatalf("%s: All yielded %d=%d after %d", name, k, v, prev)
+			}
+			prev = k
+		}
+		if prev != 999 {
+			t.Fatalf("%s: All stopped at %d", name, prev)
+		}
+
+		var got []int
+		tree.AscendRange(240, 260, func(k, v int) bool {
+			got = append(got, k)
+			return true
+		})
+		if len(got) != 20 || got[0] != 240 || got[19] != 259 {
+			t.Fatalf("%s: AscendRange(240, 260) crossed shards wrongly: %v", name, got)
+		}
+
+		for i := 0; i < 100; i++ {
+			k := rand.Intn(1000)
+			tree.Delete(k)
+			if tree.Contains(k) {
+				t.Fatalf("%s: key %d survived Delete", name, k)
+			}
+		}
+	}
+}
+
+func TestShardedRangeBounds(t *testing.T) {
+	tree := NewShardedRange[int, string](10, 20)
+	for _, k := range []int{5, 10, 19, 20, 25} {
+		tree.Insert(k, "")
+	}
+	want := map[int]int{5: 0, 10: 1, 19: 1, 20: 2, 25: 2}
+	for k, i := range want {
+		if got := tree.shardOf(k); got != i {
+			t.Fatalf("key %d expected in shard %d, got %d", k, i, got)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("unsorted bounds should panic")
+		}
+	}()
+	NewShardedRange[int, string](20, 10)
+}
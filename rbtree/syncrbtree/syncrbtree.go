@@ -0,0 +1,344 @@
+// Package syncrbtree는 rbtree.Tree를 sync.RWMutex로 감싼, 여러 고루틴이 함께 쓸 수 있는 트리를 제공한다.
+//
+// 쓰기 연산은 배타 잠금을, 조회와 순회는 공유 잠금을 잡는다. 잠금 밖으로 노드 포인터가 새어 나가면
+// 안전하지 않으므로 *rbtree.Node를 돌려주는 메서드(Search, Min, At 등)는 값 복사본을 돌려주는
+// 형태(Get, Min, Select 등)로 바꿔 노출한다. 그 밖의 연산은 View와 Do로 잠금 아래에서 직접 부를 수 있다.
+//
+// 순회 콜백(InOrder, AscendRange, All 등)은 공유 잠금을 잡은 채 불리므로, 콜백 안에서 같은 트리에
+// 쓰면 교착 상태가 된다.
+package syncrbtree
+
+import (
+	"cmp"
+	"iter"
+	"sync"
+
+	"github.com/EletricSaw/rbtree/rbtree"
+)
+
+// Tree는 잠금으로 보호되는 rbtree.Tree다. 제로값은 쓸 수 없으므로 New, NewFunc, Wrap으로 만든다.
+type Tree[K any, V any] struct {
+	mu   sync.RWMutex
+	tree *rbtree.Tree[K, V]
+}
+
+// New는 K의 기본 순서를 쓰는 빈 트리를 만든다.
+func New[K cmp.Ordered, V any](opts ...rbtree.Option) *Tree[K, V] {
+	return Wrap(rbtree.New[K, V](opts...))
+}
+
+// NewFunc는 less로 키 순서를 정하는 빈 트리를 만든다. less의 조건은 rbtree.NewFunc와 같다.
+func NewFunc[K any, V any](less func(a, b K) bool, opts ...rbtree.Option) *Tree[K, V] {
+	return Wrap(rbtree.NewFunc[K, V](less, opts...))
+}
+
+// Wrap은 이미 만든 트리를 감싼다. 이후로는 t를 직접 만지지 말고 반환된 Tree를 통해서만 써야 한다.
+// 톰스톤 모드나 크기 제한 같은 설정을 가진 트리를 공유할 때 쓴다.
+func Wrap[K any, V any](t *rbtree.Tree[K, V]) *Tree[K, V] {
+	return &Tree[K, V]{tree: t}
+}
+
+// View는 공유 잠금을 잡고 fn에 내부 트리를 넘긴다. fn 안에서는 트리를 읽기만 해야 하고,
+// 트리나 노드 포인터를 fn 밖으로 가지고 나가면 안 된다.
+func (s *Tree[K, V]) View(fn func(t *rbtree.Tree[K, V])) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fn(s.tree)
+}
+
+// Do는 배타 잠금을 잡고 fn에 내부 트리를 넘긴다. 여러 연산을 하나로 묶어 원자적으로 처리할 때 쓴다.
+func (s *Tree[K, V]) Do(fn func(t *rbtree.Tree[K, V])) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.tree)
+}
+
+// Insert는 rbtree.Tree.Insert와 같다.
+func (s *Tree[K, V]) Insert(key K, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tree.Insert(key, value)
+}
+
+// Put은 rbtree.Tree.Put과 같다.
+func (s *Tree[K, V]) Put(key K, value V) (previous V, replaced bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.Put(key, value)
+}
+
+// InsertMany는 rbtree.Tree.InsertMany와 같다. pairs는 제자리에서 정렬된다.
+func (s *Tree[K, V]) InsertMany(pairs []rbtree.Pair[K, V]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tree.InsertMany(pairs)
+}
+
+// Update는 rbtree.Tree.Update와 같다. fn은 배타 잠금 아래에서 불리므로 읽고-고치고-쓰기가 원자적이다.
+func (s *Tree[K, V]) Update(key K, fn func(old V, exists bool) (value V, keep bool)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tree.Update(key, fn)
+}
+
+// CompareAndSwapFunc는 rbtree.Tree.CompareAndSwapFunc와 같다.
+func (s *Tree[K, V]) CompareAndSwapFunc(key K, old, new V, eq func(a, b V) bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.CompareAndSwapFunc(key, old, new, eq)
+}
+
+// CompareAndSwap은 rbtree.CompareAndSwap을 잠금 아래에서 부른다.
+func CompareAndSwap[K any, V comparable](s *Tree[K, V], key K, old, new V) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return rbtree.CompareAndSwap(s.tree, key, old, new)
+}
+
+// Delete는 rbtree.Tree.Delete와 같다.
+func (s *Tree[K, V]) Delete(key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.Delete(key)
+}
+
+// Pop은 rbtree.Tree.Pop과 같다.
+func (s *Tree[K, V]) Pop(key K) (V, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.Pop(key)
+}
+
+// PopMin은 rbtree.Tree.PopMin과 같다.
+func (s *Tree[K, V]) PopMin() (key K, value V, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.PopMin()
+}
+
+// PopMax는 rbtree.Tree.PopMax와 같다.
+func (s *Tree[K, V]) PopMax() (key K, value V, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.PopMax()
+}
+
+// DeleteRange는 rbtree.Tree.DeleteRange와 같다.
+func (s *Tree[K, V]) DeleteRange(lo, hi K) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.DeleteRange(lo, hi)
+}
+
+// DeleteIf는 rbtree.Tree.DeleteIf와 같다. pred는 배타 잠금 아래에서 불린다.
+func (s *Tree[K, V]) DeleteIf(pred func(key K, value V) bool) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.DeleteIf(pred)
+}
+
+// Clear는 rbtree.Tree.Clear와 같다.
+func (s *Tree[K, V]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tree.Clear()
+}
+
+// Get은 rbtree.Tree.Get과 같다.
+func (s *Tree[K, V]) Get(key K) (V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.Get(key)
+}
+
+// TryGet은 Get과 같지만 읽기 잠금을 바로 얻지 못하면 기다리지 않는다. 세 번째 결과가 잠금을 얻었는지를
+// 알려 주며, false이면 앞의 두 결과는 제로값과 false다. 긴 쓰기(DeleteRange, InsertMany 등)가 진행 중일 때
+// 지연 시간 예산이 빠듯한 요청 경로가 캐시 조회를 건너뛰고 원본으로 가는 식으로 쓴다.
+func (s *Tree[K, V]) TryGet(key K) (value V, found, acquired bool) {
+	if !s.mu.TryRLock() {
+		return value, false, false
+	}
+	defer s.mu.RUnlock()
+	value, found = s.tree.Get(key)
+	return value, found, true
+}
+
+// Contains는 rbtree.Tree.Contains와 같다.
+func (s *Tree[K, V]) Contains(key K) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.Contains(key)
+}
+
+// Size는 rbtree.Tree.Size와 같다.
+func (s *Tree[K, V]) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.Size()
+}
+
+// Metrics는 rbtree.Tree.Metrics와 같다. 비교 횟수 세기를 켜려면 Do 안에서 CountComparisons를 부른다.
+func (s *Tree[K, V]) Metrics() rbtree.Metrics {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.Metrics()
+}
+
+// MemoryUsage는 rbtree.Tree.MemoryUsage와 같다. 값 크기 함수는 Do 안에서 MeasureValues로 등록한다.
+func (s *Tree[K, V]) MemoryUsage() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.MemoryUsage()
+}
+
+// Height는 rbtree.Tree.Height와 같다. 있으면 metrics 패키지가 높이를 함께 게시한다.
+func (s *Tree[K, V]) Height() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.Height()
+}
+
+// BlackHeight는 rbtree.Tree.BlackHeight와 같다.
+func (s *Tree[K, V]) BlackHeight() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.BlackHeight()
+}
+
+// Min은 가장 작은 원소의 키와 값을 돌려준다. 비었으면 ok가 false다.
+func (s *Tree[K, V]) Min() (key K, value V, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return pairOf(s.tree.Min())
+}
+
+// Max는 가장 큰 원소의 키와 값을 돌려준다. 비었으면 ok가 false다.
+func (s *Tree[K, V]) Max() (key K, value V, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return pairOf(s.tree.Max())
+}
+
+// Floor는 key 이하인 가장 큰 원소의 키와 값을 돌려준다.
+func (s *Tree[K, V]) Floor(key K) (K, V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return pairOf(s.tree.Floor(key))
+}
+
+// Ceiling은 key 이상인 가장 작은 원소의 키와 값을 돌려준다.
+func (s *Tree[K, V]) Ceiling(key K) (K, V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return pairOf(s.tree.Ceiling(key))
+}
+
+// Rank는 rbtree.Tree.Rank와 같다.
+func (s *Tree[K, V]) Rank(key K) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.Rank(key)
+}
+
+// Select는 rbtree.Tree.Select와 같다.
+func (s *Tree[K, V]) Select(i int) (key K, value V, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.Select(i)
+}
+
+// TopK는 rbtree.Tree.TopK와 같다.
+func (s *Tree[K, V]) TopK(k int) []rbtree.Pair[K, V] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.TopK(k)
+}
+
+// BottomK는 rbtree.Tree.BottomK와 같다.
+func (s *Tree[K, V]) BottomK(k int) []rbtree.Pair[K, V] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.BottomK(k)
+}
+
+// Percentile은 rbtree.Tree.Percentile과 같다.
+func (s *Tree[K, V]) Percentile(p float64) (key K, value V, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.Percentile(p)
+}
+
+// Keys는 rbtree.Tree.Keys와 같다.
+func (s *Tree[K, V]) Keys() []K {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.Keys()
+}
+
+// Values는 rbtree.Tree.Values와 같다.
+func (s *Tree[K, V]) Values() []V {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.Values()
+}
+
+// Clone은 잠금 아래에서 내부 트리를 복제해 잠금 없는 rbtree.Tree로 돌려준다.
+// 긴 순회를 하는 동안 쓰기를 막고 싶지 않을 때 스냅숏으로 쓸 수 있다.
+func (s *Tree[K, V]) Clone() *rbtree.Tree[K, V] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.Clone()
+}
+
+// InOrder는 rbtree.Tree.InOrder와 같다. 순회 내내 공유 잠금을 잡는다.
+func (s *Tree[K, V]) InOrder(fn func(key K, value V)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.tree.InOrder(fn)
+}
+
+// AscendRange는 rbtree.Tree.AscendRange와 같다. 순회 내내 공유 잠금을 잡는다.
+func (s *Tree[K, V]) AscendRange(lo, hi K, fn func(key K, value V) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.tree.AscendRange(lo, hi, fn)
+}
+
+// Descend는 rbtree.Tree.Descend와 같다. 순회 내내 공유 잠금을 잡는다.
+func (s *Tree[K, V]) Descend(fn func(key K, value V) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.tree.Descend(fn)
+}
+
+// DescendRange는 rbtree.Tree.DescendRange와 같다. 순회 내내 공유 잠금을 잡는다.
+func (s *Tree[K, V]) DescendRange(hi, lo K, fn func(key K, value V) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.tree.DescendRange(hi, lo, fn)
+}
+
+// All은 rbtree.Tree.All과 같다. 순회가 끝나거나 멈출 때까지 공유 잠금을 잡는다.
+func (s *Tree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		s.tree.All()(yield)
+	}
+}
+
+// Backward는 rbtree.Tree.Backward와 같다. 순회가 끝나거나 멈출 때까지 공유 잠금을 잡는다.
+func (s *Tree[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		s.tree.Backward()(yield)
+	}
+}
+
+func pairOf[K any, V any](node *rbtree.Node[K, V]) (key K, value V, ok bool) {
+	if node == nil {
+		return key, value, false
+	}
+	return node.Key, node.Value, true
+}
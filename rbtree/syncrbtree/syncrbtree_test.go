@@ -0,0 +1,132 @@
+package syncrbtree
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/EletricSaw/rbtree/rbtree"
+)
+
+// go test -race로 돌려야 잠금 누락을 잡을 수 있다.
+func TestConcurrentAccess(t *testing.T) {
+	tree := New[int, int]()
+	const writers, readers, perWriter = 4, 4, 500
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				key := w*perWriter + i
+				tree.Insert(key, key)
+				tree.Update(-1, func(old int, exists bool) (int, bool) { return old + 1, true })
+				if i%3 == 0 {
+					tree.Delete(key)
+				}
+			}
+		}(w)
+	}
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				tree.Get(i)
+				tree.Min()
+				prev := -2
+				for k := range tree.All() {
+					if k <= prev {
+						t.Errorf("All yielded %d after %d", k, prev)
+						return
+					}
+					prev = k
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if v, _ := tree.Get(-1); v != writers*perWriter {
+		t.Fatalf("counter expected %d increments, got %d", writers*perWriter, v)
+	}
+	deleted := writers * ((perWriter + 2) / 3)
+	if want := writers*perWriter - deleted + 1; tree.Size() != want {
+		t.Fatalf("expected %d keys, got %d", want, tree.Size())
+	}
+}
+
+// EvictLRU 트리의 Get은 읽기 잠금 아래에서 사용 순서를 바꾼다. go test -race로 돌린다.
+func TestConcurrentLRUGet(t *testing.T) {
+	tree := New[int, int](rbtree.WithMaxSize(64, rbtree.EvictLRU))
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				tree.Insert(g*1000+i, i)
+			}
+		}(g)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				tree.Get(i)
+			}
+		}()
+	}
+	wg.Wait()
+	if tree.Size() != 64 {
+		t.Fatalf("expected the bound of 64 keys, got %d", tree.Size())
+	}
+}
+
+func TestTryGet(t *testing.T) {
+	tree := New[int, string]()
+	tree.Insert(1, "one")
+	if v, found, acquired := tree.TryGet(1); !acquired || !found || v != "one" {
+		t.Fatalf("uncontended TryGet = %q, %v, %v", v, found, acquired)
+	}
+
+	locked, release, done := make(chan struct{}), make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		tree.Do(func(*rbtree.Tree[int, string]) {
+			close(locked)
+			<-release
+		})
+	}()
+	<-locked
+	// 쓰기 잠금이 풀리기를 기다리지 않고 바로 돌아와야 한다. 막히면 테스트 시간 제한에 걸린다.
+	if v, found, acquired := tree.TryGet(1); acquired || found || v != "" {
+		t.Fatalf("TryGet under a held write lock = %q, %v, %v", v, found, acquired)
+	}
+	close(release)
+	<-done
+	if _, found, acquired := tree.TryGet(1); !acquired || !found {
+		t.Fatalf("TryGet after the writer finished = %v, %v", found, acquired)
+	}
+}
+
+func TestNodeFreeAccessors(t *testing.T) {
+	tree := New[string, int]()
+	if _, _, ok := tree.Min(); ok {
+		t.Fatalf("Min on empty tree should report !ok")
+	}
+	tree.Insert("b", 2)
+	tree.Insert("d", 4)
+	if k, v, ok := tree.Ceiling("c"); !ok || k != "d" || v != 4 {
+		t.Fatalf("Ceiling(c) expected d=4, got %q=%d ok=%v", k, v, ok)
+	}
+	if k, _, ok := tree.Floor("c"); !ok || k != "b" {
+		t.Fatalf("Floor(c) expected b, got %q ok=%v", k, ok)
+	}
+	if !CompareAndSwap(tree, "b", 2, 20) {
+		t.Fatalf("CompareAndSwap should succeed on the current value")
+	}
+	clone := tree.Clone()
+	tree.Clear()
+	if clone.Size() != 2 || tree.Size() != 0 {
+		t.Fatalf("Clone should be independent of later writes")
+	}
+}
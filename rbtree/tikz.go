@@ -0,0 +1,75 @@
+package rbtree
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ToTikZ는 트리를 LaTeX forest 환경(TikZ 기반)으로 쓴다. 문서 프리앰블에 \usepackage{forest}만
+// 있으면 그대로 붙여 넣어 조판할 수 있다. 노드는 색에 맞춰 빨강/검정 원으로 칠하고, 자식이 하나뿐인
+// 노드는 빈 쪽에 보이지 않는 자리(phantom)를 두어 왼쪽/오른쪽 자식이 바뀌어 보이지 않게 한다.
+// 키에 든 LaTeX 특수 문자는 이스케이프한다. 빈 트리는 주석 한 줄만 쓴다.
+func (t *Tree[K, V]) ToTikZ(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	if t.root == nil {
+		bw.WriteString("% empty red-black tree\n")
+		return bw.Flush()
+	}
+	bw.WriteString(`\begin{forest}
+  for tree={circle, draw, minimum size=2em, inner sep=1pt, font=\small, s sep=4mm},
+  rb red/.style={fill=red!75!black, text=white},
+  rb black/.style={fill=black, text=white},
+  rb deleted/.style={fill=white, draw=gray, dashed, text=gray},
+`)
+	writeTikZNode(bw, t.root, 1)
+	bw.WriteString("\\end{forest}\n")
+	return bw.Flush()
+}
+
+func writeTikZNode[K any, V any](w *bufio.Writer, n *Node[K, V], depth int) {
+	indent := strings.Repeat("  ", depth)
+	style := "rb black"
+	switch {
+	case n.deleted:
+		style = "rb deleted"
+	case n.Color == red:
+		style = "rb red"
+	}
+	if n.Left == nil && n.Right == nil {
+		fmt.Fprintf(w, "%s[{%s}, %s]\n", indent, latexEscape(fmt.Sprint(n.Key)), style)
+		return
+	}
+	fmt.Fprintf(w, "%s[{%s}, %s\n", indent, latexEscape(fmt.Sprint(n.Key)), style)
+	for _, child := range []*Node[K, V]{n.Left, n.Right} {
+		if child == nil {
+			fmt.Fprintf(w, "%s  [, phantom]\n", indent)
+		} else {
+			writeTikZNode(w, child, depth+1)
+		}
+	}
+	fmt.Fprintf(w, "%s]\n", indent)
+}
+
+var latexReplacer = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`$`, `\$`,
+	`&`, `\&`,
+	`#`, `\#`,
+	`%`, `\%`,
+	`_`, `\_`,
+	`^`, `\textasciicircum{}`,
+	`~`, `\textasciitilde{}`,
+	`[`, `{[}`,
+	`]`, `{]}`,
+	`,`, `{,}`,
+)
+
+// latexEscape는 s를 forest 노드 내용에 안전하게 넣을 수 있도록 LaTeX 특수 문자와
+// forest의 구분자(대괄호, 쉼표)를 이스케이프한다.
+func latexEscape(s string) string {
+	return latexReplacer.Replace(s)
+}
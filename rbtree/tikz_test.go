@@ -0,0 +1,44 @@
+package rbtree
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestToTikZ(t *testing.T) {
+	tree := New[int, int]()
+	for _, k := range []int{20, 10, 30, 5} {
+		tree.Insert(k, k)
+	}
+	var buf bytes.Buffer
+	if err := tree.ToTikZ(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	want := `  [{20}, rb black
+    [{10}, rb black
+      [{5}, rb red]
+      [, phantom]
+    ]
+    [{30}, rb black]
+  ]
+\end{forest}
+`
+	if !strings.HasPrefix(out, `\begin{forest}`) || !strings.HasSuffix(out, want) {
+		t.Fatalf("unexpected TikZ output:\n%s", out)
+	}
+	if strings.Count(out, "[") != strings.Count(out, "]") {
+		t.Fatalf("unbalanced brackets in:\n%s", out)
+	}
+
+	if got := latexEscape(`a_b%c[d],{e}`); got != `a\_b\%c{[}d{]}{,}\{e\}` {
+		t.Fatalf("latexEscape produced %q", got)
+	}
+
+	buf.Reset()
+	New[int, int]().ToTikZ(&buf)
+	if !strings.HasPrefix(buf.String(), "%") {
+		t.Fatalf("empty tree should produce only a comment, got %q", buf.String())
+	}
+}
@@ -0,0 +1,51 @@
+package rbtree
+
+import "cmp"
+
+// NewWithTombstones는 톰스톤(지연 삭제) 모드로 동작하는 빈 트리를 만든다.
+// 이 모드에서 Delete는 노드에 삭제 표시만 하고 회전/재색칠을 하지 않으므로 삭제가 잦은
+// 워크로드에서 비용을 아낄 수 있다. 표시된 노드는 Search와 InOrder에 나타나지 않으며,
+// 실제 제거는 Compact를 호출할 때 한꺼번에 이루어진다.
+func NewWithTombstones[K cmp.Ordered, V any](opts ...Option) *Tree[K, V] {
+	return (&Tree[K, V]{compare: cmp.Compare[K], tombstones: true}).apply(opts)
+}
+
+// Compact는 톰스톤으로 표시된 노드와, isZero(값)가 true인 노드를 모두 물리적으로 제거하고
+// 제거한 개수를 돌려준다. 일반 트리에서도 값을 제로 값으로 바꿔 "지운 셈" 치던 원소를 한꺼번에
+// 정리할 때 쓸 수 있다(isZero의 부정으로 제자리 필터링하는 것과 같다). isZero가 nil이면
+// 톰스톤만 정리한다. 제거된 원소는 Size에서 빠지고 이후 Search에도 나타나지 않는다.
+func (t *Tree[K, V]) Compact(isZero func(V) bool) int {
+	t.willWrite()
+	if t.dead == 0 && isZero == nil {
+		return 0
+	}
+	var victims []*Node[K, V]
+	collectCompactable(t.root, isZero, &victims)
+	// 삭제는 후속 노드를 키 복사 없이 통째로 옮기므로 모아 둔 포인터는 계속 유효하다.
+	if len(victims) > 0 {
+		t.mods++
+	}
+	for _, node := range victims {
+		t.balancer().unlink(t, node)
+		if node.deleted {
+			t.dead--
+		} else {
+			t.size--
+			t.vars.deleted(t.size)
+			t.afterDelete(node.Key, node.Value)
+		}
+		t.release(node)
+	}
+	return len(victims)
+}
+
+func collectCompactable[K any, V any](node *Node[K, V], isZero func(V) bool, out *[]*Node[K, V]) {
+	if node == nil {
+		return
+	}
+	collectCompactable(node.Left, isZero, out)
+	if node.deleted || (isZero != nil && isZero(node.Value)) {
+		*out = append(*out, node)
+	}
+	collectCompactable(node.Right, isZero, out)
+}
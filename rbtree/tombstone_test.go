@@ -0,0 +1,130 @@
+package rbtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestTombstoneDelete(t *testing.T) {
+	tree := NewWithTombstones[int, int]()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, i*10)
+	}
+	root := tree.Root()
+
+	for i := 0; i < 100; i += 2 {
+		if !tree.Delete(i) {
+			t.Fatalf("expected delete(%d) to succeed", i)
+		}
+		if tree.Delete(i) {
+			t.Fatalf("second delete(%d) should report missing key", i)
+		}
+	}
+	if tree.Root() != root {
+		t.Fatalf("tombstone delete must not restructure the tree")
+	}
+	if tree.Size() != 50 {
+		t.Fatalf("expected size 50, got %d", tree.Size())
+	}
+	for i := 0; i < 100; i++ {
+		found := tree.Search(i) != nil
+		if found != (i%2 == 1) {
+			t.Fatalf("key %d: expected found=%v", i, i%2 == 1)
+		}
+	}
+	tree.InOrder(func(key, value int) {
+		if key%2 == 0 {
+			t.Fatalf("tombstoned key %d visited by InOrder", key)
+		}
+	})
+
+	// 톰스톤 키를 다시 넣으면 되살아난다.
+	tree.Insert(4, 44)
+	if node := tree.Search(4); node == nil || node.Value != 44 {
+		t.Fatalf("expected key 4 to be revived with value 44")
+	}
+	if tree.Size() != 51 {
+		t.Fatalf("expected size 51 after revive, got %d", tree.Size())
+	}
+
+	if removed := tree.Compact(nil); removed != 49 {
+		t.Fatalf("expected Compact to remove 49 nodes, got %d", removed)
+	}
+	if removed := tree.Compact(nil); removed != 0 {
+		t.Fatalf("second Compact should remove nothing, got %d", removed)
+	}
+	assertRBProperties(t, tree)
+	count := 0
+	tree.InOrder(func(key, value int) { count++ })
+	if count != tree.Size() || count != 51 {
+		t.Fatalf("expected 51 live nodes after compact, walked %d (size %d)", count, tree.Size())
+	}
+}
+
+// 1M 키 트리에서 절반을 삭제하는 워크로드로 표준 삭제와 톰스톤 삭제를 비교한다.
+func BenchmarkDeleteHalf(b *testing.B) {
+	const n = 1_000_000
+	keys := rand.Perm(n)
+	victims := keys[:n/2]
+
+	run := func(b *testing.B, newTree func(...Option) *Tree[int, int]) {
+		build := func() *Tree[int, int] {
+			tree := newTree()
+			for _, k := range keys {
+				tree.Insert(k, k)
+			}
+			return tree
+		}
+		tree := build()
+		b.ResetTimer()
+		for i, j := 0, 0; i < b.N; i, j = i+1, j+1 {
+			if j == len(victims) {
+				b.StopTimer()
+				tree, j = build(), 0
+				b.StartTimer()
+			}
+			tree.Delete(victims[j])
+		}
+	}
+	b.Run("standard", func(b *testing.B) { run(b, New[int, int]) })
+	b.Run("tombstone", func(b *testing.B) { run(b, NewWithTombstones[int, int]) })
+}
+
+func TestCompactZeroValues(t *testing.T) {
+	isZero := func(v int) bool { return v == 0 }
+
+	tree := New[int, int]()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, i%4) // 네 개 중 하나는 값이 0이다.
+	}
+	if removed := tree.Compact(isZero); removed != 25 {
+		t.Fatalf("expected 25 zero-valued nodes removed, got %d", removed)
+	}
+	if tree.Size() != 75 {
+		t.Fatalf("expected size 75 after compact, got %d", tree.Size())
+	}
+	for i := 0; i < 100; i += 4 {
+		if tree.Search(i) != nil {
+			t.Fatalf("zero-valued key %d still searchable", i)
+		}
+	}
+	assertRBProperties(t, tree)
+
+	// 톰스톤 모드에서는 톰스톤과 제로 값 노드를 함께 정리한다.
+	tomb := NewWithTombstones[int, int]()
+	for i := 0; i < 20; i++ {
+		tomb.Insert(i, i%2)
+	}
+	tomb.Delete(1)
+	tomb.Delete(3)
+	if removed := tomb.Compact(isZero); removed != 12 {
+		t.Fatalf("expected 2 tombstones + 10 zero values removed, got %d", removed)
+	}
+	if tomb.Size() != 8 {
+		t.Fatalf("expected 8 live entries, got %d", tomb.Size())
+	}
+	if removed := tomb.Compact(nil); removed != 0 {
+		t.Fatalf("nothing should be left to compact, got %d", removed)
+	}
+	assertRBProperties(t, tomb)
+}
@@ -0,0 +1,42 @@
+package rbtree
+
+import "math"
+
+// TopK는 키가 가장 큰 원소 k개를 큰 키부터 돌려준다. 가장 큰 노드에서 시작해 k개만 거슬러 가므로
+// 트리 전체를 훑지 않고 O(log n + k)에 끝난다. 원소가 k개보다 적으면 모두 돌려주고, k가 0 이하이면
+// 빈 슬라이스를 돌려준다.
+func (t *Tree[K, V]) TopK(k int) []Pair[K, V] {
+	if k <= 0 {
+		return []Pair[K, V]{}
+	}
+	out := make([]Pair[K, V], 0, min(k, t.size))
+	for node := t.last(); node != nil && len(out) < k; node = prevLive(node) {
+		out = append(out, Pair[K, V]{Key: node.Key, Value: node.Value})
+	}
+	return out
+}
+
+// BottomK는 키가 가장 작은 원소 k개를 작은 키부터 돌려준다. 나머지 규칙은 TopK와 같다.
+func (t *Tree[K, V]) BottomK(k int) []Pair[K, V] {
+	if k <= 0 {
+		return []Pair[K, V]{}
+	}
+	out := make([]Pair[K, V], 0, min(k, t.size))
+	for node := t.first(); node != nil && len(out) < k; node = nextLive(node) {
+		out = append(out, Pair[K, V]{Key: node.Key, Value: node.Value})
+	}
+	return out
+}
+
+// Percentile은 키 순서로 p 백분위(0 <= p <= 100)에 있는 원소를 돌려준다. 최근접 순위(nearest-rank)
+// 방식을 따라 원소의 p%가 그 키 이하가 되는 가장 작은 원소를 고르므로, 결과는 항상 트리에 있는
+// 키이고 보간하지 않는다. p가 0이면 가장 작은 원소, 100이면 가장 큰 원소다. 지연 시간을 키로 둔
+// 트리에서 p95 같은 경계를 구할 때 Select로 한 번 내려가면 되므로 O(log n)이다.
+// 트리가 비었거나 p가 범위를 벗어나거나 NaN이면 ok가 false다.
+func (t *Tree[K, V]) Percentile(p float64) (key K, value V, ok bool) {
+	if t.size == 0 || !(p >= 0 && p <= 100) {
+		return key, value, false
+	}
+	rank := int(math.Ceil(p * float64(t.size) / 100))
+	return t.Select(max(rank-1, 0))
+}
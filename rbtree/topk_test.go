@@ -0,0 +1,87 @@
+package rbtree
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTopKBottomK(t *testing.T) {
+	tree := NewWithTombstones[int, int]()
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, i*i)
+	}
+	tree.Delete(9)
+	tree.Delete(0)
+
+	top := tree.TopK(3)
+	if len(top) != 3 || top[0] != (Pair[int, int]{8, 64}) || top[1].Key != 7 || top[2].Key != 6 {
+		t.Fatalf("TopK(3) = %v", top)
+	}
+	bottom := tree.BottomK(3)
+	if len(bottom) != 3 || bottom[0] != (Pair[int, int]{1, 1}) || bottom[1].Key != 2 || bottom[2].Key != 3 {
+		t.Fatalf("BottomK(3) = %v", bottom)
+	}
+	if got := tree.TopK(100); len(got) != tree.Size() || got[len(got)-1].Key != 1 {
+		t.Fatalf("TopK past the size = %v", got)
+	}
+	if got := tree.BottomK(0); got == nil || len(got) != 0 {
+		t.Fatalf("BottomK(0) should be an empty slice, got %v", got)
+	}
+	if got := New[int, int]().TopK(5); len(got) != 0 {
+		t.Fatalf("TopK on an empty tree = %v", got)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	tree := New[int, string]()
+	// 1부터 100까지이므로 p 백분위는 p를 올림한 값이다.
+	for i := 1; i <= 100; i++ {
+		tree.Insert(i, "")
+	}
+	for _, tc := range []struct {
+		p    float64
+		want int
+	}{
+		{0, 1}, {1, 1}, {50, 50}, {95, 95}, {99.5, 100}, {100, 100}, {0.1, 1},
+	} {
+		if k, _, ok := tree.Percentile(tc.p); !ok || k != tc.want {
+			t.Fatalf("Percentile(%v) = %d, %v; want %d", tc.p, k, ok, tc.want)
+		}
+	}
+	for _, p := range []float64{-1, 100.01, math.NaN()} {
+		if _, _, ok := tree.Percentile(p); ok {
+			t.Fatalf("Percentile(%v) should fail", p)
+		}
+	}
+
+	// 원소가 적을 때 최근접 순위: 4개 중 p50은 두 번째, p51은 세 번째다.
+	small := New[int, string]()
+	for _, k := range []int{10, 20, 30, 40} {
+		small.Insert(k, "")
+	}
+	if k, _, _ := small.Percentile(50); k != 20 {
+		t.Fatalf("Percentile(50) of 4 = %d, want 20", k)
+	}
+	if k, _, _ := small.Percentile(51); k != 30 {
+		t.Fatalf("Percentile(51) of 4 = %d, want 30", k)
+	}
+	// p·n/100이 정수로 떨어지면 그 순위가 답이다. 나누기를 먼저 하면 오차로 하나 넘어간다.
+	for _, tc := range []struct {
+		n    int
+		p    float64
+		want int
+	}{
+		{50, 14, 7}, {25, 28, 7}, {20, 95, 19}, {1000, 95, 950}, {1000, 99.9, 999}, {3, 50, 2}, {7, 100, 7},
+	} {
+		tree := New[int, string]()
+		for i := 1; i <= tc.n; i++ {
+			tree.Insert(i, "")
+		}
+		if k, _, _ := tree.Percentile(tc.p); k != tc.want {
+			t.Fatalf("Percentile(%v) of %d = %d, want %d", tc.p, tc.n, k, tc.want)
+		}
+	}
+	if _, _, ok := New[int, int]().Percentile(50); ok {
+		t.Fatalf("Percentile on an empty tree should fail")
+	}
+}
@@ -0,0 +1,43 @@
+package rbtree
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+)
+
+// ErrInvertConflict는 Invert 도중 서로 다른 키가 같은 값을 가리켜 뒤집은 트리의 키가 겹칠 때 돌려주는 에러다.
+var ErrInvertConflict = errors.New("rbtree: duplicate value while inverting")
+
+// Reindex는 모든 원소의 키를 newKeyFn(기존 키, 값)으로 바꾼 새 트리를 돌려준다.
+// 원래 트리는 바뀌지 않는다. 서로 다른 키가 같은 새 키로 모이면 중위 순서상 나중에 방문한
+// 원소의 값이 남는다(last-write-wins). 새 트리는 원래 트리의 비교 함수와 톰스톤 모드를 물려받는다.
+func (t *Tree[K, V]) Reindex(newKeyFn func(K, V) K) *Tree[K, V] {
+	out := &Tree[K, V]{compare: t.plainCompare(), tombstones: t.tombstones}
+	t.InOrder(func(key K, value V) {
+		out.Insert(newKeyFn(key, value), value)
+	})
+	return out
+}
+
+// Invert는 키와 값의 타입이 같은 트리에서 (k, v)를 (v, k)로 뒤집은 새 트리를 돌려준다.
+// 역방향 조회 테이블을 만들 때 쓴다. 두 키가 같은 값을 가지면 뒤집은 결과가 모호하므로
+// ErrInvertConflict를 감싼 에러와 nil 트리를 돌려준다. 원래 트리는 바뀌지 않는다.
+func Invert[K cmp.Ordered](t *Tree[K, K]) (*Tree[K, K], error) {
+	out := New[K, K]()
+	var err error
+	t.InOrder(func(key, value K) {
+		if err != nil {
+			return
+		}
+		if prev := out.Search(value); prev != nil {
+			err = fmt.Errorf("%w: keys %v and %v both map to %v", ErrInvertConflict, prev.Value, key, value)
+			return
+		}
+		out.Insert(value, key)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
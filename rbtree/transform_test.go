@@ -0,0 +1,70 @@
+package rbtree
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReindexCaseFolding(t *testing.T) {
+	tree := New[string, int]()
+	tree.Insert("Apple", 1)
+	tree.Insert("BANANA", 2)
+	tree.Insert("apple", 3)
+	tree.Insert("banana", 4)
+	tree.Insert("cherry", 5)
+
+	lower := tree.Reindex(func(key string, _ int) string {
+		return strings.ToLower(key)
+	})
+
+	if lower.Size() != 3 {
+		t.Fatalf("expected 3 deduplicated keys, got %d", lower.Size())
+	}
+	// 중위 순서는 "Apple" < "BANANA" < "apple" < "banana" 이므로 소문자 키의 값이 이긴다.
+	want := map[string]int{"apple": 3, "banana": 4, "cherry": 5}
+	for key, value := range want {
+		node := lower.Search(key)
+		if node == nil || node.Value != value {
+			t.Fatalf("key %q expected value %d, got %+v", key, value, node)
+		}
+	}
+	if tree.Size() != 5 || tree.Search("Apple") == nil {
+		t.Fatalf("Reindex must not modify the source tree")
+	}
+	assertRBProperties(t, lower)
+}
+
+func TestInvert(t *testing.T) {
+	tree := New[string, string]()
+	tree.Insert("ko", "korean")
+	tree.Insert("en", "english")
+	tree.Insert("ja", "japanese")
+
+	inverted, err := Invert(tree)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if node := inverted.Search("korean"); node == nil || node.Value != "ko" {
+		t.Fatalf("expected korean => ko in inverted tree")
+	}
+	assertRBProperties(t, inverted)
+
+	back, err := Invert(inverted)
+	if err != nil {
+		t.Fatalf("unexpected error on double invert: %v", err)
+	}
+	if back.Size() != tree.Size() {
+		t.Fatalf("expected size %d, got %d", tree.Size(), back.Size())
+	}
+	tree.InOrder(func(key, value string) {
+		if node := back.Search(key); node == nil || node.Value != value {
+			t.Fatalf("Invert(Invert(t)) lost %q => %q", key, value)
+		}
+	})
+
+	tree.Insert("kr", "korean")
+	if _, err := Invert(tree); !errors.Is(err, ErrInvertConflict) {
+		t.Fatalf("expected ErrInvertConflict, got %v", err)
+	}
+}
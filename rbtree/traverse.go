@@ -0,0 +1,157 @@
+package rbtree
+
+// PreOrder는 노드를 부모, 왼쪽 서브트리, 오른쪽 서브트리 순서로 방문하며 fn을 호출한다. 트리 모양을
+// 그대로 옮겨 적거나 직렬화할 때 쓴다. InOrder처럼 톰스톤은 건너뛰고, fn 안에서 트리 구조를 바꾸면
+// ErrModifiedDuringIteration으로 panic한다. 부모 포인터를 따라 움직이므로 추가 메모리가 들지 않는다.
+func (t *Tree[K, V]) PreOrder(fn func(key K, value V)) {
+	mods := t.mods
+	preOrder(t.root, func(n *Node[K, V]) {
+		if !n.deleted {
+			fn(n.Key, n.Value)
+			t.checkMods(mods)
+		}
+	})
+}
+
+// PostOrder는 노드를 왼쪽 서브트리, 오른쪽 서브트리, 부모 순서로 방문하며 fn을 호출한다. 자식을 모두
+// 본 뒤 부모를 보므로 서브트리 단위로 값을 모으거나 풀어 줄 때 쓴다. 나머지 규칙은 PreOrder와 같다.
+func (t *Tree[K, V]) PostOrder(fn func(key K, value V)) {
+	mods := t.mods
+	postOrder(t.root, func(n *Node[K, V]) {
+		if !n.deleted {
+			fn(n.Key, n.Value)
+			t.checkMods(mods)
+		}
+	})
+}
+
+// LevelOrder는 루트부터 깊이 순서로, 같은 깊이에서는 왼쪽부터 노드를 방문하며 fn을 호출한다.
+// 너비 우선 레이아웃으로 그리거나 배열 형태로 직렬화할 때 쓴다. 한 층의 노드를 큐에 담으므로
+// 원소 수에 비례하는 메모리가 든다. 나머지 규칙은 PreOrder와 같다.
+func (t *Tree[K, V]) LevelOrder(fn func(key K, value V)) {
+	if t.root == nil {
+		return
+	}
+	mods := t.mods
+	queue := []*Node[K, V]{t.root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if !node.deleted {
+			fn(node.Key, node.Value)
+			t.checkMods(mods)
+		}
+		if node.Left != nil {
+			queue = append(queue, node.Left)
+		}
+		if node.Right != nil {
+			queue = append(queue, node.Right)
+		}
+	}
+}
+
+// Walk는 InOrder처럼 키 오름차순으로 원소를 방문하되, 노드의 깊이(루트가 0)와 색도 함께 fn에
+// 넘긴다. Node의 Left·Right·Parent를 직접 따라가지 않고도 시각화 도구나 분석기가 트리 모양을
+// 알 수 있다. 키 순서가 곧 가로 위치이므로 방문 순서를 x, depth를 y로 쓰면 트리를 바로 그릴 수 있다.
+// fn이 false를 돌려주면 즉시 멈춘다. 톰스톤은 건너뛰지만 깊이에는 포함된다. fn 안에서 트리 구조를
+// 바꾸면 ErrModifiedDuringIteration으로 panic한다.
+func (t *Tree[K, V]) Walk(fn func(key K, value V, depth int, color Color) bool) {
+	if t.root == nil {
+		return
+	}
+	mods := t.mods
+	node, depth := t.root, 0
+	for ; node.Left != nil; node = node.Left {
+		depth++
+	}
+	for {
+		if !node.deleted {
+			if !fn(node.Key, node.Value, depth, node.Color) {
+				return
+			}
+			t.checkMods(mods)
+		}
+		if node.Right != nil {
+			node = node.Right
+			depth++
+			for ; node.Left != nil; node = node.Left {
+				depth++
+			}
+			continue
+		}
+		for node.Parent != nil && node == node.Parent.Right {
+			node = node.Parent
+			depth--
+		}
+		if node.Parent == nil {
+			return
+		}
+		node = node.Parent
+		depth--
+	}
+}
+
+// preOrder는 root 서브트리의 노드를 톰스톤까지 모두 전위 순서로 fn에 넘긴다. root 위로는 올라가지 않는다.
+func preOrder[K any, V any](root *Node[K, V], fn func(*Node[K, V])) {
+	node := root
+	for node != nil {
+		fn(node)
+		if node.Left != nil {
+			node = node.Left
+			continue
+		}
+		if node.Right != nil {
+			node = node.Right
+			continue
+		}
+		// 잎이다. 아직 방문하지 않은 오른쪽 형제가 있는 곳까지 올라간다.
+		node = nextPreOrderBranch(root, node)
+	}
+}
+
+// nextPreOrderBranch는 잎 node 다음으로 전위 순서에서 방문할 노드를 돌려준다. root 아래에 더
+// 없으면 nil이다.
+func nextPreOrderBranch[K any, V any](root, node *Node[K, V]) *Node[K, V] {
+	for node != root {
+		parent := node.Parent
+		if node == parent.Left && parent.Right != nil {
+			return parent.Right
+		}
+		node = parent
+	}
+	return nil
+}
+
+// postOrder는 root 서브트리의 노드를 톰스톤까지 모두 후위 순서로 fn에 넘긴다. root 위로는 올라가지 않는다.
+func postOrder[K any, V any](root *Node[K, V], fn func(*Node[K, V])) {
+	if root == nil {
+		return
+	}
+	node := firstPostOrder(root)
+	for {
+		fn(node)
+		if node == root {
+			return
+		}
+		parent := node.Parent
+		if node == parent.Left && parent.Right != nil {
+			node = firstPostOrder(parent.Right)
+		} else {
+			node = parent
+		}
+	}
+}
+
+// firstPostOrder는 node 서브트리에서 후위 순서로 처음 방문할 노드, 즉 왼쪽을 우선해 내려간 첫 잎이다.
+func firstPostOrder[K any, V any](node *Node[K, V]) *Node[K, V] {
+	for {
+		switch {
+		case node.Left != nil:
+			node = node.Left
+		case node.Right != nil:
+			node = node.Right
+		default:
+			return node
+		}
+	}
+}
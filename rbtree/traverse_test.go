@@ -0,0 +1,157 @@
+package rbtree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestTraversalOrders(t *testing.T) {
+	// 4를 루트로 두 층이 꽉 찬 트리를 손으로 만든다.
+	tree := New[int, int]()
+	tree.InsertMany([]Pair[int, int]{{1, 1}, {2, 2}, {3, 3}, {4, 4}, {5, 5}, {6, 6}, {7, 7}})
+	var pre, post, level []int
+	tree.PreOrder(func(k, _ int) { pre = append(pre, k) })
+	tree.PostOrder(func(k, _ int) { post = append(post, k) })
+	tree.LevelOrder(func(k, _ int) { level = append(level, k) })
+
+	var wantPre, wantPost, wantLevel []int
+	var walk func(n *Node[int, int])
+	walk = func(n *Node[int, int]) {
+		if n == nil {
+			return
+		}
+		wantPre = append(wantPre, n.Key)
+		walk(n.Left)
+		walk(n.Right)
+		wantPost = append(wantPost, n.Key)
+	}
+	walk(tree.Root())
+	for queue := []*Node[int, int]{tree.Root()}; len(queue) > 0; queue = queue[1:] {
+		n := queue[0]
+		wantLevel = append(wantLevel, n.Key)
+		for _, c := range []*Node[int, int]{n.Left, n.Right} {
+			if c != nil {
+				queue = append(queue, c)
+			}
+		}
+	}
+	if !slices.Equal(pre, wantPre) || !slices.Equal(post, wantPost) || !slices.Equal(level, wantLevel) {
+		t.Fatalf("pre %v want %v, post %v want %v, level %v want %v", pre, wantPre, post, wantPost, level, wantLevel)
+	}
+	if level[0] != tree.Root().Key || post[len(post)-1] != tree.Root().Key {
+		t.Fatalf("level order should start and post order should end at the root")
+	}
+}
+
+func TestTraversalsAgainstRecursion(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	tree := NewWithTombstones[int, int]()
+	for i := 0; i < 3000; i++ {
+		k := rng.Intn(1000)
+		if rng.Intn(4) == 0 {
+			tree.Delete(k)
+		} else {
+			tree.Insert(k, k)
+		}
+	}
+
+	var wantPre, wantPost []int
+	var walk func(n *Node[int, int])
+	walk = func(n *Node[int, int]) {
+		if n == nil {
+			return
+		}
+		if !n.deleted {
+			wantPre = append(wantPre, n.Key)
+		}
+		walk(n.Left)
+		walk(n.Right)
+		if !n.deleted {
+			wantPost = append(wantPost, n.Key)
+		}
+	}
+	walk(tree.Root())
+
+	var pre, post, level []int
+	tree.PreOrder(func(k, _ int) { pre = append(pre, k) })
+	tree.PostOrder(func(k, _ int) { post = append(post, k) })
+	tree.LevelOrder(func(k, _ int) { level = append(level, k) })
+	if !slices.Equal(pre, wantPre) {
+		t.Fatalf("PreOrder differs from the recursive walk")
+	}
+	if !slices.Equal(post, wantPost) {
+		t.Fatalf("PostOrder differs from the recursive walk")
+	}
+	if len(level) != tree.Size() {
+		t.Fatalf("LevelOrder visited %d elements, want %d", len(level), tree.Size())
+	}
+
+	empty := New[int, int]()
+	empty.PreOrder(func(int, int) { t.Fatal("visited an empty tree") })
+	empty.PostOrder(func(int, int) { t.Fatal("visited an empty tree") })
+	empty.LevelOrder(func(int, int) { t.Fatal("visited an empty tree") })
+}
+
+func TestTraversalPanicsOnModification(t *testing.T) {
+	tree := New[int, int]()
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, i)
+	}
+	for name, walk := range map[string]func(func(int, int)){
+		"PreOrder": tree.PreOrder, "PostOrder": tree.PostOrder, "LevelOrder": tree.LevelOrder,
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%s should panic when fn modifies the tree", name)
+				}
+			}()
+			i := 100
+			walk(func(int, int) { tree.Insert(i, i); i++ })
+		}()
+	}
+}
+
+func TestWalk(t *testing.T) {
+	tree := New[int, int]()
+	for i := 0; i < 500; i++ {
+		tree.Insert(i, i*2)
+	}
+	depthOf := func(n *Node[int, int]) int {
+		d := 0
+		for ; n.Parent != nil; n = n.Parent {
+			d++
+		}
+		return d
+	}
+
+	next := 0
+	tree.Walk(func(key, value, depth int, color Color) bool {
+		n := tree.Search(key)
+		if key != next || value != key*2 {
+			t.Fatalf("Walk visited %d=%d, want %d in order", key, value, next)
+		}
+		if depth != depthOf(n) || color != n.Color {
+			t.Fatalf("key %d: depth %d color %v, want %d %v", key, depth, color, depthOf(n), n.Color)
+		}
+		next++
+		return true
+	})
+	if next != 500 {
+		t.Fatalf("Walk visited %d elements, want 500", next)
+	}
+
+	visited := 0
+	tree.Walk(func(key, value, depth int, color Color) bool {
+		visited++
+		return visited < 3
+	})
+	if visited != 3 {
+		t.Fatalf("Walk should stop when fn returns false, visited %d", visited)
+	}
+	New[int, int]().Walk(func(int, int, int, Color) bool {
+		t.Fatal("visited an empty tree")
+		return false
+	})
+}
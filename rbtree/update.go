@@ -0,0 +1,53 @@
+package rbtree
+
+// Update는 key의 현재 값을 fn에 넘기고 그 결과에 따라 원소를 만들거나 고치거나 지운다.
+// 키가 없으면 old는 V의 제로값, exists는 false다. fn이 keep=true를 돌려주면 새 값을 저장하고
+// (없던 키면 삽입하고), keep=false면 키를 지운다(없던 키면 아무 일도 없다).
+// 탐색은 한 번만 하므로 카운터나 집계처럼 읽고-고치고-쓰는 패턴을 Search/Insert 두 번 없이 쓸 수 있다.
+// fn 안에서 트리를 바꾸면 안 된다.
+func (t *Tree[K, V]) Update(key K, fn func(old V, exists bool) (value V, keep bool)) {
+	t.willWrite()
+	node, parent, left := t.locate(key)
+	exists := node != nil && !node.deleted
+	var old V
+	if exists {
+		old = node.Value
+	}
+	value, keep := fn(old, exists)
+
+	switch {
+	case exists && keep:
+		node.Value = value
+		t.augmentPath(node)
+		t.afterUpdate(key, old, value)
+	case exists:
+		t.remove(node)
+	case !keep:
+	case node != nil:
+		t.revive(node, value) // 톰스톤 노드가 자리를 지키고 있었다.
+	default:
+		t.link(parent, left, key, value)
+	}
+}
+
+// CompareAndSwap은 key의 현재 값이 old와 같을 때만 new로 바꾸고, 바꿨으면 true를 돌려준다.
+// 키가 없으면 false다. 값 비교에 ==를 쓰므로 V가 comparable이어야 하고, Tree의 V는 any라서
+// 메서드가 아닌 함수로 둔다. 그 밖의 값 타입에는 CompareAndSwapFunc를 쓴다.
+// 트리 자체는 동기화하지 않으므로, 여러 고루틴이 공유할 때는 같은 잠금 아래에서 불러야 원자적이다.
+func CompareAndSwap[K any, V comparable](t *Tree[K, V], key K, old, new V) bool {
+	return t.CompareAndSwapFunc(key, old, new, func(a, b V) bool { return a == b })
+}
+
+// CompareAndSwapFunc는 CompareAndSwap과 같지만 값이 같은지를 eq로 판단한다.
+func (t *Tree[K, V]) CompareAndSwapFunc(key K, old, new V, eq func(a, b V) bool) bool {
+	t.willWrite()
+	node := t.Search(key)
+	if node == nil || !eq(node.Value, old) {
+		return false
+	}
+	prev := node.Value
+	node.Value = new
+	t.augmentPath(node)
+	t.afterUpdate(node.Key, prev, new)
+	return true
+}
@@ -0,0 +1,64 @@
+package rbtree
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUpdate(t *testing.T) {
+	for _, tree := range []*Tree[string, int]{New[string, int](), NewWithTombstones[string, int]()} {
+		incr := func(old int, exists bool) (int, bool) { return old + 1, true }
+		for _, word := range []string{"a", "b", "a", "c", "a"} {
+			tree.Update(word, incr)
+		}
+		if v, _ := tree.Get("a"); v != 3 {
+			t.Fatalf("expected a counted 3 times, got %d", v)
+		}
+
+		// keep=false는 있던 키를 지우고, 없던 키에는 아무 일도 하지 않는다.
+		drop := func(old int, exists bool) (int, bool) { return 0, false }
+		tree.Update("b", drop)
+		tree.Update("zzz", drop)
+		if tree.Contains("b") || tree.Contains("zzz") || tree.Size() != 2 {
+			t.Fatalf("unexpected contents after dropping updates, size %d", tree.Size())
+		}
+
+		// 지워진 키는 exists=false로 보인다(톰스톤 모드에서도).
+		tree.Update("b", func(old int, exists bool) (int, bool) {
+			if exists || old != 0 {
+				t.Fatalf("deleted key should look absent, got (%d, %v)", old, exists)
+			}
+			return 10, true
+		})
+		if v, _ := tree.Get("b"); v != 10 || tree.Size() != 3 {
+			t.Fatalf("expected b=10 and size 3, got b=%d size %d", v, tree.Size())
+		}
+		assertRBProperties(t, tree)
+	}
+}
+
+func TestCompareAndSwap(t *testing.T) {
+	tree := New[string, int]()
+	tree.Insert("a", 1)
+	if CompareAndSwap(tree, "a", 2, 3) {
+		t.Fatalf("swap with a stale old value should fail")
+	}
+	if !CompareAndSwap(tree, "a", 1, 3) {
+		t.Fatalf("swap with the current value should succeed")
+	}
+	if v, _ := tree.Get("a"); v != 3 {
+		t.Fatalf("expected a=3 after swap, got %d", v)
+	}
+	if CompareAndSwap(tree, "missing", 0, 1) || tree.Contains("missing") {
+		t.Fatalf("swap on a missing key should fail without inserting")
+	}
+
+	lists := New[string, []int]()
+	lists.Insert("s", []int{1, 2})
+	if !lists.CompareAndSwapFunc("s", []int{1, 2}, []int{3}, slices.Equal[[]int]) {
+		t.Fatalf("CompareAndSwapFunc should compare with eq")
+	}
+	if v, _ := lists.Get("s"); !slices.Equal(v, []int{3}) {
+		t.Fatalf("expected [3] after swap, got %v", v)
+	}
+}
@@ -0,0 +1,133 @@
+package rbtree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidTree는 Validate가 레드블랙 규칙이나 내부 기록이 깨진 트리를 찾았을 때 감싸 돌려주는 에러다.
+var ErrInvalidTree = errors.New("rbtree: invalid tree")
+
+// Validate는 트리가 올바른지 O(n)에 확인하고, 깨진 곳이 있으면 ErrInvalidTree를 감싼 에러를
+// 돌려준다. 확인하는 규칙은 다음과 같다.
+//
+//   - 루트는 검정이고 부모가 없다.
+//   - 빨강 노드의 자식은 모두 검정이다.
+//   - 루트에서 모든 잎까지 지나는 검정 노드 수가 같다.
+//   - 중위 순서로 키가 엄격하게 증가한다.
+//   - 모든 자식의 Parent가 그 부모를 가리킨다.
+//   - 노드마다 기록해 둔 서브트리 원소 수와 Size, 톰스톤 수가 실제와 같다.
+//   - LeftLeaningRB 트리라면 빨강 노드가 오른쪽 자식으로 오지 않는다.
+//
+// 에러 메시지에는 문제가 된 노드의 키와 루트에서 그 노드까지의 경로(L은 왼쪽, R은 오른쪽 자식)가
+// 담긴다. 트리를 품은 프로그램이 자기 테스트나 상태 점검에서 트리가 멀쩡한지 단언할 때 쓴다.
+// WithLogger로 로거를 주었다면 결과를 로그로도 남긴다.
+func (t *Tree[K, V]) Validate() error {
+	err := t.check()
+	t.logCheck(err)
+	return err
+}
+
+// check는 로그를 남기지 않는 Validate다.
+func (t *Tree[K, V]) check() error {
+	err := t.validate(t.root)
+	if err == nil && t.root != nil && t.root.Parent != nil {
+		err = errors.New("root has a parent")
+	}
+	if err == nil {
+		err = t.balancer().check(t.root)
+	}
+	if err == nil {
+		if live := countOf(t.root); live != t.size {
+			err = fmt.Errorf("size is %d but the tree holds %d live elements", t.size, live)
+		} else if dead := countDead(t.root); dead != t.dead {
+			err = fmt.Errorf("tombstone count is %d but the tree holds %d tombstones", t.dead, dead)
+		}
+	}
+	if err != nil {
+		err = fmt.Errorf("%w: %v", ErrInvalidTree, err)
+	}
+	return err
+}
+
+// validate는 root 아래가 키 순서, 레드블랙 규칙, 부모 포인터, 서브트리 원소 수를 지키는지 O(n)에 확인한다.
+// ReadFrom이 읽어 들인 덤프를 받아들이기 전에도 쓴다.
+func (t *Tree[K, V]) validate(root *Node[K, V]) error {
+	if colorOf(root) == red {
+		return errors.New("red root")
+	}
+	var prev *Node[K, V]
+	var path []byte
+	at := func(n *Node[K, V]) string {
+		if len(path) == 0 {
+			return fmt.Sprintf("%v at root", n.Key)
+		}
+		return fmt.Sprintf("%v at path %s", n.Key, path)
+	}
+	// walk는 n의 검정 높이(nil 잎 포함)를 돌려준다.
+	var walk func(n *Node[K, V]) (int, error)
+	walk = func(n *Node[K, V]) (int, error) {
+		if n == nil {
+			return 1, nil
+		}
+		for _, child := range []*Node[K, V]{n.Left, n.Right} {
+			if child != nil && child.Parent != n {
+				return 0, fmt.Errorf("child %v of node %s has a wrong parent pointer", child.Key, at(n))
+			}
+		}
+		if n.Color == red && (colorOf(n.Left) == red || colorOf(n.Right) == red) {
+			return 0, fmt.Errorf("red node %s has a red child", at(n))
+		}
+
+		path = append(path, 'L')
+		lh, err := walk(n.Left)
+		path = path[:len(path)-1]
+		if err != nil {
+			return 0, err
+		}
+		if prev != nil && t.compare(prev.Key, n.Key) >= 0 {
+			return 0, fmt.Errorf("key %s is out of order after %v", at(n), prev.Key)
+		}
+		prev = n
+		path = append(path, 'R')
+		rh, err := walk(n.Right)
+		path = path[:len(path)-1]
+		if err != nil {
+			return 0, err
+		}
+
+		if lh != rh {
+			return 0, fmt.Errorf("black height differs under %s (left %d, right %d)", at(n), lh, rh)
+		}
+		want := countOf(n.Left) + countOf(n.Right)
+		if !n.deleted {
+			want++
+		}
+		if n.count != want {
+			return 0, fmt.Errorf("node %s records %d elements but its subtree holds %d", at(n), n.count, want)
+		}
+		return lh + blackness(n), nil
+	}
+	_, err := walk(root)
+	return err
+}
+
+// logCheck는 Validate 결과를 로그로 남긴다.
+func (t *Tree[K, V]) logCheck(err error) {
+	if t.log == nil {
+		return
+	}
+	level := t.log.levels.Check
+	if err != nil {
+		level = t.log.levels.CheckFailure
+	}
+	if !t.log.l.Enabled(context.Background(), level) {
+		return
+	}
+	if err != nil {
+		t.log.l.Log(context.Background(), level, "rbtree validate failed", "size", t.size, "error", err)
+		return
+	}
+	t.log.l.Log(context.Background(), level, "rbtree validate ok", "size", t.size)
+}
@@ -0,0 +1,84 @@
+package rbtree
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// validTree는 1..7을 차례로 넣은 트리다. 각 경우는 모양에 덜 기대도록 Min, Max 같은 노드를 골라 망가뜨린다.
+func validTree() *Tree[int, int] {
+	tree := New[int, int]()
+	for i := 1; i <= 7; i++ {
+		tree.Insert(i, i)
+	}
+	return tree
+}
+
+func TestValidate(t *testing.T) {
+	if err := validTree().Validate(); err != nil {
+		t.Fatalf("valid tree: %v", err)
+	}
+	if err := New[int, int]().Validate(); err != nil {
+		t.Fatalf("empty tree: %v", err)
+	}
+	tomb := NewWithTombstones[int, int]()
+	for i := 0; i < 10; i++ {
+		tomb.Insert(i, i)
+	}
+	tomb.Delete(3)
+	if err := tomb.Validate(); err != nil {
+		t.Fatalf("tree with tombstones: %v", err)
+	}
+
+	cases := []struct {
+		name    string
+		corrupt func(tree *Tree[int, int])
+		want    string
+	}{
+		{"red root", func(tree *Tree[int, int]) { tree.root.Color = red }, "red root"},
+		{"red-red", func(tree *Tree[int, int]) {
+			n := tree.Max()
+			n.Color, n.Parent.Color = red, red
+		}, "has a red child"},
+		{"black height", func(tree *Tree[int, int]) {
+			tree.Min().Color = red
+		}, "black height differs"},
+		{"order", func(tree *Tree[int, int]) { tree.Min().Key = 100 }, "out of order"},
+		{"parent", func(tree *Tree[int, int]) { tree.Max().Parent = tree.root }, "wrong parent pointer"},
+		{"count", func(tree *Tree[int, int]) { tree.Max().count = 5 }, "records 5 elements"},
+		{"size", func(tree *Tree[int, int]) { tree.size = 9 }, "size is 9"},
+	}
+	for _, c := range cases {
+		tree := validTree()
+		c.corrupt(tree)
+		err := tree.Validate()
+		if !errors.Is(err, ErrInvalidTree) || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("%s: Validate() = %v, want an ErrInvalidTree mentioning %q", c.name, err, c.want)
+		}
+	}
+}
+
+func TestValidateReportsPath(t *testing.T) {
+	tree := validTree()
+	tree.root.Right.Left.Key = 0 // 오른쪽 서브트리에 가장 작은 키를 넣는다.
+	err := tree.Validate()
+	if err == nil || !strings.Contains(err.Error(), "at path RL") {
+		t.Fatalf("Validate() = %v, want the path to the broken node", err)
+	}
+}
+
+func TestValidateLogs(t *testing.T) {
+	var buf bytes.Buffer
+	tree := New[int, int](WithLogger(newTestLogger(&buf, slog.LevelDebug)))
+	tree.Validate()
+	tree.size = 1
+	tree.Validate()
+	out := buf.String()
+	if !strings.Contains(out, `level=DEBUG msg="rbtree validate ok" size=0`) ||
+		!strings.Contains(out, `level=ERROR msg="rbtree validate failed" size=1`) {
+		t.Fatalf("unexpected log:\n%s", out)
+	}
+}
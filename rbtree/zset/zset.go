@@ -0,0 +1,219 @@
+// Package zset은 Redis의 정렬 집합(ZSET)처럼 멤버마다 float64 점수를 두고 점수 순서로 순위와 구간을
+// 묻는 집합을 제공한다.
+//
+// 멤버는 (점수, 멤버) 순서로 정렬한 rbtree.Tree에 들어가고, 멤버에서 점수를 찾는 map이 곁에 있다.
+// 트리가 서브트리 크기를 유지하므로 순위와 순위 구간은 Select와 Rank로 O(log n)에 시작점을 찾고,
+// 점수 구간은 AscendRange로 훑는다. 점수가 같으면 Redis처럼 멤버 순서로 줄 세운다.
+package zset
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/EletricSaw/rbtree/rbtree"
+)
+
+// Entry는 멤버와 그 점수다.
+type Entry[M cmp.Ordered] struct {
+	Member M
+	Score  float64
+}
+
+// ZSet은 정렬 집합이다. 제로값은 쓸 수 없으므로 New로 만든다.
+// 여러 고루틴이 함께 쓰려면 호출하는 쪽에서 잠가야 한다.
+type ZSet[M cmp.Ordered] struct {
+	tree   *rbtree.Tree[key[M], struct{}]
+	scores map[M]float64
+}
+
+// key는 트리의 키다. edge가 -1이면 같은 점수의 어떤 멤버보다도 앞, 1이면 뒤에 오는 구간 경계다.
+type key[M cmp.Ordered] struct {
+	score  float64
+	member M
+	edge   int8
+}
+
+func less[M cmp.Ordered](a, b key[M]) bool {
+	if a.score != b.score {
+		return a.score < b.score
+	}
+	if a.edge != 0 || b.edge != 0 {
+		return a.edge < b.edge
+	}
+	return a.member < b.member
+}
+
+// New는 빈 정렬 집합을 만든다.
+func New[M cmp.Ordered]() *ZSet[M] {
+	return &ZSet[M]{
+		tree:   rbtree.NewFunc[key[M], struct{}](less[M]),
+		scores: make(map[M]float64),
+	}
+}
+
+// Len은 멤버 수를 돌려준다.
+func (z *ZSet[M]) Len() int {
+	return len(z.scores)
+}
+
+// Add는 member의 점수를 score로 정하고, 새 멤버였으면 true를 돌려준다. 이미 있으면 점수만 바꾼다.
+// score가 NaN이면 순서를 정할 수 없으므로 panic한다.
+func (z *ZSet[M]) Add(member M, score float64) bool {
+	checkScore(score)
+	old, exists := z.scores[member]
+	if exists {
+		if old == score {
+			return false
+		}
+		z.tree.Delete(key[M]{score: old, member: member})
+	}
+	z.scores[member] = score
+	z.tree.Insert(key[M]{score: score, member: member}, struct{}{})
+	return !exists
+}
+
+// Score는 member의 점수를 돌려준다. 없으면 ok가 false다.
+func (z *ZSet[M]) Score(member M) (score float64, ok bool) {
+	score, ok = z.scores[member]
+	return score, ok
+}
+
+// Remove는 member를 지우고, 있었으면 true를 돌려준다.
+func (z *ZSet[M]) Remove(member M) bool {
+	score, ok := z.scores[member]
+	if !ok {
+		return false
+	}
+	delete(z.scores, member)
+	z.tree.Delete(key[M]{score: score, member: member})
+	return true
+}
+
+// IncrBy는 member의 점수에 delta를 더하고 새 점수를 돌려준다. 없는 멤버는 0점에서 시작한다.
+// +Inf와 -Inf를 더하는 것처럼 결과가 NaN이 되면 panic한다.
+func (z *ZSet[M]) IncrBy(member M, delta float64) float64 {
+	score := z.scores[member] + delta
+	z.Add(member, score)
+	return score
+}
+
+// Rank는 점수 오름차순에서 0부터 센 member의 순위를 돌려준다. 없으면 ok가 false다.
+func (z *ZSet[M]) Rank(member M) (rank int, ok bool) {
+	score, ok := z.scores[member]
+	if !ok {
+		return 0, false
+	}
+	return z.tree.Rank(key[M]{score: score, member: member}), true
+}
+
+// RevRank는 점수 내림차순에서 0부터 센 member의 순위를 돌려준다. 없으면 ok가 false다.
+func (z *ZSet[M]) RevRank(member M) (rank int, ok bool) {
+	rank, ok = z.Rank(member)
+	if !ok {
+		return 0, false
+	}
+	return z.Len() - 1 - rank, true
+}
+
+// RangeByRank는 점수 오름차순에서 순위 start부터 stop까지(양 끝 포함) 멤버를 돌려준다. Redis의
+// ZRANGE처럼 음수 순위는 끝에서 센다(-1이 마지막). 범위를 벗어난 부분은 잘라 내고, 남는 것이
+// 없으면 빈 슬라이스다. 시작점을 Select로 찾으므로 O(log n + 결과 수)다.
+func (z *ZSet[M]) RangeByRank(start, stop int) []Entry[M] {
+	start, count := z.rankRange(start, stop)
+	if count == 0 {
+		return []Entry[M]{}
+	}
+	first, _, _ := z.tree.Select(start)
+	return entries(z.tree.EnumerateFrom(first, true, count))
+}
+
+// RevRangeByRank는 RangeByRank와 같지만 순위를 점수 내림차순으로 센다. 0이 가장 높은 점수다.
+func (z *ZSet[M]) RevRangeByRank(start, stop int) []Entry[M] {
+	start, count := z.rankRange(start, stop)
+	if count == 0 {
+		return []Entry[M]{}
+	}
+	first, _, _ := z.tree.Select(z.Len() - 1 - start)
+	return entries(z.tree.EnumerateFrom(first, false, count))
+}
+
+// rankRange는 양 끝을 포함하는 Redis식 순위 구간을 시작 순위와 개수로 바꾼다.
+func (z *ZSet[M]) rankRange(start, stop int) (int, int) {
+	n := z.Len()
+	if start < 0 {
+		start += n
+	}
+	if stop < 0 {
+		stop += n
+	}
+	start, stop = max(start, 0), min(stop, n-1)
+	if start > stop {
+		return 0, 0
+	}
+	return start, stop - start + 1
+}
+
+// RangeByScore는 점수가 min 이상 max 이하인 멤버를 점수 오름차순으로 돌려준다. 양 끝을 포함하는
+// 것은 Redis의 ZRANGEBYSCORE와 같다. min > max이거나 어느 쪽이 NaN이면 빈 슬라이스다.
+func (z *ZSet[M]) RangeByScore(min, max float64) []Entry[M] {
+	out := []Entry[M]{}
+	if !(min <= max) {
+		return out
+	}
+	z.tree.AscendRange(key[M]{score: min, edge: -1}, key[M]{score: max, edge: 1}, func(k key[M], _ struct{}) bool {
+		out = append(out, Entry[M]{Member: k.member, Score: k.score})
+		return true
+	})
+	return out
+}
+
+// Count는 점수가 min 이상 max 이하인 멤버 수를 멤버를 훑지 않고 O(log n)에 돌려준다.
+func (z *ZSet[M]) Count(min, max float64) int {
+	if !(min <= max) {
+		return 0
+	}
+	return z.tree.Rank(key[M]{score: max, edge: 1}) - z.tree.Rank(key[M]{score: min, edge: -1})
+}
+
+// PopMin은 점수가 가장 낮은 멤버를 지우고 돌려준다. 비었으면 ok가 false다.
+func (z *ZSet[M]) PopMin() (Entry[M], bool) {
+	return z.pop(z.tree.PopMin())
+}
+
+// PopMax는 점수가 가장 높은 멤버를 지우고 돌려준다. 비었으면 ok가 false다.
+func (z *ZSet[M]) PopMax() (Entry[M], bool) {
+	return z.pop(z.tree.PopMax())
+}
+
+func (z *ZSet[M]) pop(k key[M], _ struct{}, ok bool) (Entry[M], bool) {
+	if !ok {
+		return Entry[M]{}, false
+	}
+	delete(z.scores, k.member)
+	return Entry[M]{Member: k.member, Score: k.score}, true
+}
+
+// All은 모든 멤버와 점수를 점수 오름차순으로 내놓는다. 순회 도중 집합을 고치면 panic한다.
+func (z *ZSet[M]) All() iter.Seq2[M, float64] {
+	return func(yield func(M, float64) bool) {
+		for k := range z.tree.All() {
+			if !yield(k.member, k.score) {
+				return
+			}
+		}
+	}
+}
+
+func entries[M cmp.Ordered](pairs []rbtree.Pair[key[M], struct{}]) []Entry[M] {
+	out := make([]Entry[M], len(pairs))
+	for i, p := range pairs {
+		out[i] = Entry[M]{Member: p.Key.member, Score: p.Key.score}
+	}
+	return out
+}
+
+func checkScore(score float64) {
+	if score != score {
+		panic("zset: NaN score")
+	}
+}
@@ -0,0 +1,180 @@
+package zset
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// sorted는 model을 점수, 멤버 순으로 정렬한 참조 결과다.
+func sorted(model map[string]float64) []Entry[string] {
+	out := make([]Entry[string], 0, len(model))
+	for m, s := range model {
+		out = append(out, Entry[string]{m, s})
+	}
+	slices.SortFunc(out, func(a, b Entry[string]) int {
+		if a.Score != b.Score {
+			if a.Score < b.Score {
+				return -1
+			}
+			return 1
+		}
+		if a.Member < b.Member {
+			return -1
+		}
+		if a.Member > b.Member {
+			return 1
+		}
+		return 0
+	})
+	return out
+}
+
+func TestAgainstModel(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	z := New[string]()
+	model := map[string]float64{}
+	for i := 0; i < 3000; i++ {
+		member := string(rune('a' + rng.Intn(26)))
+		score := float64(rng.Intn(10)) // 같은 점수가 많아야 멤버 순서가 드러난다.
+		switch rng.Intn(4) {
+		case 0:
+			_, had := model[member]
+			delete(model, member)
+			if z.Remove(member) != had {
+				t.Fatalf("Remove(%s) disagreed with the model", member)
+			}
+		case 1:
+			model[member] += score
+			if got := z.IncrBy(member, score); got != model[member] {
+				t.Fatalf("IncrBy(%s) = %v, want %v", member, got, model[member])
+			}
+		default:
+			_, had := model[member]
+			model[member] = score
+			if z.Add(member, score) == had {
+				t.Fatalf("Add(%s) reported new=%v for an existing=%v member", member, !had, had)
+			}
+		}
+
+		want := sorted(model)
+		if z.Len() != len(want) {
+			t.Fatalf("Len = %d, want %d", z.Len(), len(want))
+		}
+		for rank, e := range want {
+			if r, ok := z.Rank(e.Member); !ok || r != rank {
+				t.Fatalf("Rank(%s) = %d, %v; want %d", e.Member, r, ok, rank)
+			}
+			if r, _ := z.RevRank(e.Member); r != len(want)-1-rank {
+				t.Fatalf("RevRank(%s) = %d, want %d", e.Member, r, len(want)-1-rank)
+			}
+		}
+		lo, hi := float64(rng.Intn(30)), float64(rng.Intn(30))
+		var inRange []Entry[string]
+		for _, e := range want {
+			if e.Score >= lo && e.Score <= hi {
+				inRange = append(inRange, e)
+			}
+		}
+		if got := z.RangeByScore(lo, hi); !slices.Equal(got, inRange) {
+			t.Fatalf("RangeByScore(%v, %v) = %v, want %v", lo, hi, got, inRange)
+		}
+		if got := z.Count(lo, hi); got != len(inRange) {
+			t.Fatalf("Count(%v, %v) = %d, want %d", lo, hi, got, len(inRange))
+		}
+	}
+}
+
+func TestRangeByRank(t *testing.T) {
+	z := New[string]()
+	for i, m := range []string{"a", "b", "c", "d", "e"} {
+		z.Add(m, float64(i*10))
+	}
+	members := func(es []Entry[string]) []string {
+		out := []string{}
+		for _, e := range es {
+			out = append(out, e.Member)
+		}
+		return out
+	}
+	for _, tc := range []struct {
+		start, stop int
+		want, rev   []string
+	}{
+		{0, -1, []string{"a", "b", "c", "d", "e"}, []string{"e", "d", "c", "b", "a"}},
+		{1, 2, []string{"b", "c"}, []string{"d", "c"}},
+		{-2, -1, []string{"d", "e"}, []string{"b", "a"}},
+		{3, 100, []string{"d", "e"}, []string{"b", "a"}},
+		{-100, 0, []string{"a"}, []string{"e"}},
+		{3, 1, []string{}, []string{}},
+		{5, 6, []string{}, []string{}},
+	} {
+		if got := members(z.RangeByRank(tc.start, tc.stop)); !slices.Equal(got, tc.want) {
+			t.Fatalf("RangeByRank(%d, %d) = %v, want %v", tc.start, tc.stop, got, tc.want)
+		}
+		if got := members(z.RevRangeByRank(tc.start, tc.stop)); !slices.Equal(got, tc.rev) {
+			t.Fatalf("RevRangeByRank(%d, %d) = %v, want %v", tc.start, tc.stop, got, tc.rev)
+		}
+	}
+	if got := z.RangeByRank(1, 1); got[0] != (Entry[string]{"b", 10}) {
+		t.Fatalf("RangeByRank should carry scores, got %v", got)
+	}
+}
+
+func TestScoreEdges(t *testing.T) {
+	z := New[string]()
+	z.Add("low", math.Inf(-1))
+	z.Add("high", math.Inf(1))
+	z.Add("x", 1)
+	z.Add("y", 1)
+	if got := z.RangeByScore(math.Inf(-1), math.Inf(1)); len(got) != 4 || got[0].Member != "low" || got[3].Member != "high" {
+		t.Fatalf("RangeByScore over all scores = %v", got)
+	}
+	// 양 끝 점수와 같은 멤버도 모두 들어간다.
+	if got := z.RangeByScore(1, 1); len(got) != 2 || got[0].Member != "x" || got[1].Member != "y" {
+		t.Fatalf("RangeByScore(1, 1) = %v", got)
+	}
+	if z.Count(2, 1) != 0 || len(z.RangeByScore(math.NaN(), 1)) != 0 {
+		t.Fatalf("an empty or NaN score range should match nothing")
+	}
+	if s, ok := z.Score("x"); !ok || s != 1 {
+		t.Fatalf("Score(x) = %v, %v", s, ok)
+	}
+	if _, ok := z.Rank("missing"); ok {
+		t.Fatalf("Rank of a missing member should fail")
+	}
+
+	if e, ok := z.PopMin(); !ok || e.Member != "low" {
+		t.Fatalf("PopMin = %v, %v", e, ok)
+	}
+	if e, ok := z.PopMax(); !ok || e.Member != "high" {
+		t.Fatalf("PopMax = %v, %v", e, ok)
+	}
+	if _, ok := z.Score("high"); ok || z.Len() != 2 {
+		t.Fatalf("popped members should leave the score map, len %d", z.Len())
+	}
+	var got []string
+	for m, s := range z.All() {
+		got = append(got, m)
+		if s != 1 {
+			t.Fatalf("All yielded score %v for %s", s, m)
+		}
+	}
+	if !slices.Equal(got, []string{"x", "y"}) {
+		t.Fatalf("All = %v", got)
+	}
+	z.PopMin()
+	z.PopMin()
+	if _, ok := z.PopMin(); ok {
+		t.Fatalf("PopMin on an empty set should fail")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("IncrBy producing NaN should panic")
+		}
+	}()
+	z.Add("inf", math.Inf(1))
+	z.IncrBy("inf", math.Inf(-1))
+}